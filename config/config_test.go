@@ -49,6 +49,10 @@ func TestLoadConfig(t *testing.T) {
 					DbPath:  "dbPath",
 					RpcPort: 200,
 				},
+				FaucetConfig: &configpb.FaucetConfig{
+					PrivKey:   "bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e",
+					MaxAmount: 1000,
+				},
 			},
 		},
 		{
@@ -65,6 +69,19 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "MultipleSeeds",
+			content: multipleSeedsContent(),
+			expected: &configpb.Config{
+				NodeConfig: &configpb.NodeConfig{
+					Port: 5,
+					Seeds: []string{
+						"/ip4/127.0.0.1/tcp/34836/ipfs/QmPtahvwSvnSHymR5HZiSTpkm9xHymx9QLNkUjJ7mfygGs",
+						"/ip4/127.0.0.1/tcp/34837/ipfs/QmNzA9rsEcM5nAzX9PzTrabJsGiifzaUU85Qe78HSDzSSE",
+					},
+				},
+			},
+		},
 		{
 			name:    "WrongFileContent",
 			content: "WrongFileContent",
@@ -89,6 +106,28 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Genesis(t *testing.T) {
+	content := `
+	producers: ["1BpXBb3uunLa9PL8MmkMtKNd3jzb5DHFkG"]
+	allocations: [
+		{address: "1BpXBb3uunLa9PL8MmkMtKNd3jzb5DHFkG" amount: 1000},
+		{address: "1ArH9WoB9F7i6qoJiAi7McZMFVQSsBKXZR" amount: 20}
+	]`
+	filename := "genesis_config.conf"
+	ioutil.WriteFile(filename, []byte(content), 0644)
+	defer os.Remove(filename)
+
+	genesisConf := &configpb.DynastyConfig{}
+	LoadConfig(filename, genesisConf)
+	assert.Equal(t, &configpb.DynastyConfig{
+		Producers: []string{"1BpXBb3uunLa9PL8MmkMtKNd3jzb5DHFkG"},
+		Allocations: []*configpb.GenesisAllocation{
+			{Address: "1BpXBb3uunLa9PL8MmkMtKNd3jzb5DHFkG", Amount: 1000},
+			{Address: "1ArH9WoB9F7i6qoJiAi7McZMFVQSsBKXZR", Amount: 20},
+		},
+	}, genesisConf)
+}
+
 func fakeFileContent() string {
 	return `
 	consensusConfig{
@@ -100,6 +139,10 @@ func fakeFileContent() string {
 		seed: "/ip4/127.0.0.1/tcp/34836/ipfs/QmPtahvwSvnSHymR5HZiSTpkm9xHymx9QLNkUjJ7mfygGs"
 		dbPath: "dbPath"
 		rpcPort: 200
+	}
+	faucetConfig{
+		privKey: "bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e"
+		maxAmount: 1000
 	}`
 }
 
@@ -113,3 +156,12 @@ func noSeedContent() string {
 		port: 5
 	}`
 }
+
+func multipleSeedsContent() string {
+	return `
+	nodeConfig{
+		port: 5
+		seeds: "/ip4/127.0.0.1/tcp/34836/ipfs/QmPtahvwSvnSHymR5HZiSTpkm9xHymx9QLNkUjJ7mfygGs"
+		seeds: "/ip4/127.0.0.1/tcp/34837/ipfs/QmNzA9rsEcM5nAzX9PzTrabJsGiifzaUU85Qe78HSDzSSE"
+	}`
+}
@@ -21,6 +21,8 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type Config struct {
 	ConsensusConfig      *ConsensusConfig `protobuf:"bytes,1,opt,name=consensusConfig,proto3" json:"consensusConfig,omitempty"`
 	NodeConfig           *NodeConfig      `protobuf:"bytes,2,opt,name=nodeConfig,proto3" json:"nodeConfig,omitempty"`
+	FaucetConfig         *FaucetConfig    `protobuf:"bytes,3,opt,name=faucetConfig,proto3" json:"faucetConfig,omitempty"`
+	TxPoolConfig         *TxPoolConfig    `protobuf:"bytes,4,opt,name=txPoolConfig,proto3" json:"txPoolConfig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -30,7 +32,7 @@ func (m *Config) Reset()         { *m = Config{} }
 func (m *Config) String() string { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()    {}
 func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_d656ea8a995aa76d, []int{0}
+	return fileDescriptor_config_4f40efdad01fb80f, []int{0}
 }
 func (m *Config) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Config.Unmarshal(m, b)
@@ -64,9 +66,25 @@ func (m *Config) GetNodeConfig() *NodeConfig {
 	return nil
 }
 
+func (m *Config) GetFaucetConfig() *FaucetConfig {
+	if m != nil {
+		return m.FaucetConfig
+	}
+	return nil
+}
+
+func (m *Config) GetTxPoolConfig() *TxPoolConfig {
+	if m != nil {
+		return m.TxPoolConfig
+	}
+	return nil
+}
+
 type ConsensusConfig struct {
 	MinerAddr            string   `protobuf:"bytes,1,opt,name=minerAddr,proto3" json:"minerAddr,omitempty"`
 	PrivKey              string   `protobuf:"bytes,2,opt,name=privKey,proto3" json:"privKey,omitempty"`
+	MaxBlockSize         uint32   `protobuf:"varint,3,opt,name=maxBlockSize,proto3" json:"maxBlockSize,omitempty"`
+	MaxBlockTxCount      uint32   `protobuf:"varint,4,opt,name=maxBlockTxCount,proto3" json:"maxBlockTxCount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -76,7 +94,7 @@ func (m *ConsensusConfig) Reset()         { *m = ConsensusConfig{} }
 func (m *ConsensusConfig) String() string { return proto.CompactTextString(m) }
 func (*ConsensusConfig) ProtoMessage()    {}
 func (*ConsensusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_d656ea8a995aa76d, []int{1}
+	return fileDescriptor_config_4f40efdad01fb80f, []int{1}
 }
 func (m *ConsensusConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusConfig.Unmarshal(m, b)
@@ -110,12 +128,30 @@ func (m *ConsensusConfig) GetPrivKey() string {
 	return ""
 }
 
+func (m *ConsensusConfig) GetMaxBlockSize() uint32 {
+	if m != nil {
+		return m.MaxBlockSize
+	}
+	return 0
+}
+
+func (m *ConsensusConfig) GetMaxBlockTxCount() uint32 {
+	if m != nil {
+		return m.MaxBlockTxCount
+	}
+	return 0
+}
+
 type NodeConfig struct {
 	Port                 uint32   `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
 	Seed                 string   `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
 	DbPath               string   `protobuf:"bytes,3,opt,name=dbPath,proto3" json:"dbPath,omitempty"`
 	RpcPort              uint32   `protobuf:"varint,4,opt,name=rpcPort,proto3" json:"rpcPort,omitempty"`
 	KeyPath              string   `protobuf:"bytes,5,opt,name=keyPath,proto3" json:"keyPath,omitempty"`
+	MaxFrameSize         uint32   `protobuf:"varint,6,opt,name=maxFrameSize,proto3" json:"maxFrameSize,omitempty"`
+	MaxOutboundPeers     uint32   `protobuf:"varint,7,opt,name=maxOutboundPeers,proto3" json:"maxOutboundPeers,omitempty"`
+	MaxInboundPeers      uint32   `protobuf:"varint,8,opt,name=maxInboundPeers,proto3" json:"maxInboundPeers,omitempty"`
+	Seeds                []string `protobuf:"bytes,9,rep,name=seeds,proto3" json:"seeds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -125,7 +161,7 @@ func (m *NodeConfig) Reset()         { *m = NodeConfig{} }
 func (m *NodeConfig) String() string { return proto.CompactTextString(m) }
 func (*NodeConfig) ProtoMessage()    {}
 func (*NodeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_d656ea8a995aa76d, []int{2}
+	return fileDescriptor_config_4f40efdad01fb80f, []int{2}
 }
 func (m *NodeConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeConfig.Unmarshal(m, b)
@@ -180,18 +216,47 @@ func (m *NodeConfig) GetKeyPath() string {
 	return ""
 }
 
+func (m *NodeConfig) GetMaxFrameSize() uint32 {
+	if m != nil {
+		return m.MaxFrameSize
+	}
+	return 0
+}
+
+func (m *NodeConfig) GetMaxOutboundPeers() uint32 {
+	if m != nil {
+		return m.MaxOutboundPeers
+	}
+	return 0
+}
+
+func (m *NodeConfig) GetMaxInboundPeers() uint32 {
+	if m != nil {
+		return m.MaxInboundPeers
+	}
+	return 0
+}
+
+func (m *NodeConfig) GetSeeds() []string {
+	if m != nil {
+		return m.Seeds
+	}
+	return nil
+}
+
 type DynastyConfig struct {
-	Producers            []string `protobuf:"bytes,1,rep,name=producers,proto3" json:"producers,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Producers            []string             `protobuf:"bytes,1,rep,name=producers,proto3" json:"producers,omitempty"`
+	Allocations          []*GenesisAllocation `protobuf:"bytes,2,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DynastyConfig) Reset()         { *m = DynastyConfig{} }
 func (m *DynastyConfig) String() string { return proto.CompactTextString(m) }
 func (*DynastyConfig) ProtoMessage()    {}
 func (*DynastyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_d656ea8a995aa76d, []int{3}
+	return fileDescriptor_config_4f40efdad01fb80f, []int{3}
 }
 func (m *DynastyConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DynastyConfig.Unmarshal(m, b)
@@ -218,6 +283,159 @@ func (m *DynastyConfig) GetProducers() []string {
 	return nil
 }
 
+func (m *DynastyConfig) GetAllocations() []*GenesisAllocation {
+	if m != nil {
+		return m.Allocations
+	}
+	return nil
+}
+
+type GenesisAllocation struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenesisAllocation) Reset()         { *m = GenesisAllocation{} }
+func (m *GenesisAllocation) String() string { return proto.CompactTextString(m) }
+func (*GenesisAllocation) ProtoMessage()    {}
+func (*GenesisAllocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4f40efdad01fb80f, []int{4}
+}
+func (m *GenesisAllocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenesisAllocation.Unmarshal(m, b)
+}
+func (m *GenesisAllocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenesisAllocation.Marshal(b, m, deterministic)
+}
+func (dst *GenesisAllocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenesisAllocation.Merge(dst, src)
+}
+func (m *GenesisAllocation) XXX_Size() int {
+	return xxx_messageInfo_GenesisAllocation.Size(m)
+}
+func (m *GenesisAllocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenesisAllocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenesisAllocation proto.InternalMessageInfo
+
+func (m *GenesisAllocation) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GenesisAllocation) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type FaucetConfig struct {
+	PrivKey              string   `protobuf:"bytes,1,opt,name=privKey,proto3" json:"privKey,omitempty"`
+	MaxAmount            uint64   `protobuf:"varint,2,opt,name=maxAmount,proto3" json:"maxAmount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FaucetConfig) Reset()         { *m = FaucetConfig{} }
+func (m *FaucetConfig) String() string { return proto.CompactTextString(m) }
+func (*FaucetConfig) ProtoMessage()    {}
+func (*FaucetConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4f40efdad01fb80f, []int{5}
+}
+func (m *FaucetConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FaucetConfig.Unmarshal(m, b)
+}
+func (m *FaucetConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FaucetConfig.Marshal(b, m, deterministic)
+}
+func (dst *FaucetConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FaucetConfig.Merge(dst, src)
+}
+func (m *FaucetConfig) XXX_Size() int {
+	return xxx_messageInfo_FaucetConfig.Size(m)
+}
+func (m *FaucetConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_FaucetConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FaucetConfig proto.InternalMessageInfo
+
+func (m *FaucetConfig) GetPrivKey() string {
+	if m != nil {
+		return m.PrivKey
+	}
+	return ""
+}
+
+func (m *FaucetConfig) GetMaxAmount() uint64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
+type TxPoolConfig struct {
+	Ttl                  uint32   `protobuf:"varint,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	MaxTxsPerAddress     uint32   `protobuf:"varint,2,opt,name=maxTxsPerAddress,proto3" json:"maxTxsPerAddress,omitempty"`
+	MinTip               uint64   `protobuf:"varint,3,opt,name=minTip,proto3" json:"minTip,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxPoolConfig) Reset()         { *m = TxPoolConfig{} }
+func (m *TxPoolConfig) String() string { return proto.CompactTextString(m) }
+func (*TxPoolConfig) ProtoMessage()    {}
+func (*TxPoolConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_4f40efdad01fb80f, []int{6}
+}
+func (m *TxPoolConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxPoolConfig.Unmarshal(m, b)
+}
+func (m *TxPoolConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxPoolConfig.Marshal(b, m, deterministic)
+}
+func (dst *TxPoolConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxPoolConfig.Merge(dst, src)
+}
+func (m *TxPoolConfig) XXX_Size() int {
+	return xxx_messageInfo_TxPoolConfig.Size(m)
+}
+func (m *TxPoolConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxPoolConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxPoolConfig proto.InternalMessageInfo
+
+func (m *TxPoolConfig) GetTtl() uint32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *TxPoolConfig) GetMaxTxsPerAddress() uint32 {
+	if m != nil {
+		return m.MaxTxsPerAddress
+	}
+	return 0
+}
+
+func (m *TxPoolConfig) GetMinTip() uint64 {
+	if m != nil {
+		return m.MinTip
+	}
+	return 0
+}
+
 type CliConfig struct {
 	Port                 uint32   `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
 	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
@@ -230,7 +448,7 @@ func (m *CliConfig) Reset()         { *m = CliConfig{} }
 func (m *CliConfig) String() string { return proto.CompactTextString(m) }
 func (*CliConfig) ProtoMessage()    {}
 func (*CliConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_d656ea8a995aa76d, []int{4}
+	return fileDescriptor_config_4f40efdad01fb80f, []int{7}
 }
 func (m *CliConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CliConfig.Unmarshal(m, b)
@@ -269,29 +487,47 @@ func init() {
 	proto.RegisterType((*ConsensusConfig)(nil), "configpb.ConsensusConfig")
 	proto.RegisterType((*NodeConfig)(nil), "configpb.NodeConfig")
 	proto.RegisterType((*DynastyConfig)(nil), "configpb.DynastyConfig")
+	proto.RegisterType((*GenesisAllocation)(nil), "configpb.GenesisAllocation")
+	proto.RegisterType((*FaucetConfig)(nil), "configpb.FaucetConfig")
+	proto.RegisterType((*TxPoolConfig)(nil), "configpb.TxPoolConfig")
 	proto.RegisterType((*CliConfig)(nil), "configpb.CliConfig")
 }
 
-func init() { proto.RegisterFile("pb/config.proto", fileDescriptor_config_d656ea8a995aa76d) }
-
-var fileDescriptor_config_d656ea8a995aa76d = []byte{
-	// 273 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x4f, 0x4b, 0xc4, 0x30,
-	0x10, 0xc5, 0x89, 0xbb, 0xd6, 0xed, 0xc8, 0x52, 0x08, 0x22, 0x55, 0x3c, 0x2c, 0x39, 0x79, 0xb1,
-	0x82, 0x7a, 0xf3, 0x24, 0xf5, 0x22, 0x82, 0x2c, 0xf9, 0x06, 0x6d, 0x13, 0xb5, 0xa8, 0x49, 0x98,
-	0x74, 0x95, 0xde, 0x04, 0xbf, 0xb8, 0x64, 0xfa, 0x4f, 0xf7, 0xb0, 0xb7, 0xfc, 0x5e, 0xe6, 0xbd,
-	0x79, 0x21, 0x90, 0xb8, 0xf2, 0xb2, 0xb2, 0xe6, 0xb9, 0x7e, 0xc9, 0x1c, 0xda, 0xc6, 0xf2, 0x45,
-	0x47, 0xae, 0x14, 0x3f, 0x0c, 0xa2, 0x9c, 0x80, 0xe7, 0x90, 0x54, 0xd6, 0x78, 0x6d, 0xfc, 0xc6,
-	0x77, 0x52, 0xca, 0x56, 0xec, 0xfc, 0xf0, 0xea, 0x24, 0x1b, 0xc6, 0xb3, 0xfc, 0xff, 0x80, 0xdc,
-	0x76, 0xf0, 0x1b, 0x00, 0x63, 0x95, 0xee, 0xfd, 0x7b, 0xe4, 0x3f, 0x9a, 0xfc, 0x4f, 0xe3, 0x9d,
-	0xfc, 0x33, 0x27, 0x1e, 0x20, 0xd9, 0x4a, 0xe6, 0x67, 0x10, 0x7f, 0xd4, 0x46, 0xe3, 0x9d, 0x52,
-	0x48, 0x3d, 0x62, 0x39, 0x09, 0x3c, 0x85, 0x03, 0x87, 0xf5, 0xe7, 0xa3, 0x6e, 0x69, 0x47, 0x2c,
-	0x07, 0x14, 0xdf, 0x0c, 0x60, 0xda, 0xc2, 0x39, 0xcc, 0x9d, 0xc5, 0x86, 0x12, 0x96, 0x92, 0xce,
-	0x41, 0xf3, 0x5a, 0xab, 0xde, 0x49, 0x67, 0x7e, 0x0c, 0x91, 0x2a, 0xd7, 0x45, 0xf3, 0x9a, 0xce,
-	0x48, 0xed, 0x29, 0x2c, 0x42, 0x57, 0xad, 0x43, 0xc4, 0x9c, 0x22, 0x06, 0x0c, 0x37, 0x6f, 0xba,
-	0x25, 0xcb, 0x7e, 0x57, 0xa1, 0x47, 0x71, 0x01, 0xcb, 0xfb, 0xd6, 0x14, 0xbe, 0x69, 0xa7, 0xb7,
-	0x38, 0xb4, 0x6a, 0x53, 0x69, 0xf4, 0x29, 0x5b, 0xcd, 0xc2, 0x5b, 0x46, 0x41, 0xdc, 0x42, 0x9c,
-	0xbf, 0xd7, 0x3b, 0xfa, 0x9e, 0xc2, 0xc2, 0x15, 0xde, 0x7f, 0x59, 0x1c, 0x3a, 0x8f, 0x5c, 0x46,
-	0xf4, 0xa1, 0xd7, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x2f, 0x15, 0x35, 0xaf, 0xe3, 0x01, 0x00,
-	0x00,
+func init() { proto.RegisterFile("pb/config.proto", fileDescriptor_config_4f40efdad01fb80f) }
+
+var fileDescriptor_config_4f40efdad01fb80f = []byte{
+	// 518 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xc1, 0x8e, 0xd3, 0x3c,
+	0x18, 0x54, 0xda, 0x6e, 0xb7, 0xf9, 0xda, 0xaa, 0xfb, 0x5b, 0xab, 0x55, 0x7f, 0xe0, 0x50, 0xe5,
+	0x54, 0x71, 0x28, 0xd2, 0xc2, 0x09, 0xc4, 0xa1, 0x14, 0x8a, 0x10, 0x12, 0x54, 0xa6, 0x2f, 0xe0,
+	0xc4, 0x5e, 0x88, 0x36, 0xb1, 0x23, 0xdb, 0x81, 0x94, 0xa7, 0xe0, 0xc6, 0xc3, 0x72, 0x41, 0x76,
+	0x9c, 0xc4, 0xe9, 0x4a, 0xdc, 0x3c, 0xe3, 0x99, 0xe6, 0x1b, 0x7f, 0xa3, 0xc2, 0xa2, 0x88, 0x9f,
+	0x25, 0x82, 0xdf, 0xa5, 0x5f, 0x37, 0x85, 0x14, 0x5a, 0xa0, 0x49, 0x8d, 0x8a, 0x38, 0xfa, 0x13,
+	0xc0, 0x78, 0x67, 0x01, 0xda, 0xc1, 0x22, 0x11, 0x5c, 0x31, 0xae, 0x4a, 0x55, 0x53, 0xcb, 0x60,
+	0x15, 0xac, 0xa7, 0xb7, 0xff, 0x6f, 0x1a, 0xf9, 0x66, 0xd7, 0x17, 0xe0, 0x73, 0x07, 0x7a, 0x01,
+	0xc0, 0x05, 0x65, 0xce, 0x3f, 0xb0, 0xfe, 0xeb, 0xce, 0xff, 0xa9, 0xbd, 0xc3, 0x9e, 0x0e, 0xbd,
+	0x84, 0xd9, 0x1d, 0x29, 0x13, 0xa6, 0x9d, 0x6f, 0x68, 0x7d, 0x37, 0x9d, 0x6f, 0xef, 0xdd, 0xe2,
+	0x9e, 0xd6, 0x78, 0x75, 0x75, 0x10, 0x22, 0x73, 0xde, 0xd1, 0xb9, 0xf7, 0xe8, 0xdd, 0xe2, 0x9e,
+	0x36, 0xfa, 0x1d, 0xc0, 0xe2, 0x2c, 0x12, 0x7a, 0x02, 0x61, 0x9e, 0x72, 0x26, 0xb7, 0x94, 0x4a,
+	0xfb, 0x00, 0x21, 0xee, 0x08, 0xb4, 0x84, 0xcb, 0x42, 0xa6, 0xdf, 0x3f, 0xb2, 0x93, 0x0d, 0x17,
+	0xe2, 0x06, 0xa2, 0x08, 0x66, 0x39, 0xa9, 0xde, 0x64, 0x22, 0xb9, 0xff, 0x92, 0xfe, 0x64, 0x36,
+	0xc3, 0x1c, 0xf7, 0x38, 0xb4, 0x86, 0x45, 0x83, 0x8f, 0xd5, 0x4e, 0x94, 0x5c, 0xdb, 0x71, 0xe7,
+	0xf8, 0x9c, 0x8e, 0x7e, 0x0d, 0x00, 0xba, 0xc7, 0x42, 0x08, 0x46, 0x85, 0x90, 0xda, 0xce, 0x33,
+	0xc7, 0xf6, 0x6c, 0x38, 0xc5, 0x18, 0x75, 0x73, 0xd8, 0x33, 0xba, 0x81, 0x31, 0x8d, 0x0f, 0x44,
+	0x7f, 0xb3, 0x9f, 0x0f, 0xb1, 0x43, 0x66, 0x6c, 0x59, 0x24, 0x07, 0x21, 0x9b, 0x0f, 0x36, 0xd0,
+	0xdc, 0xdc, 0xb3, 0x93, 0xb5, 0x5c, 0xd4, 0x81, 0x1c, 0x74, 0x81, 0xf6, 0x92, 0xe4, 0xcc, 0x06,
+	0x1a, 0xb7, 0x81, 0x5a, 0x0e, 0x3d, 0x85, 0xab, 0x9c, 0x54, 0x9f, 0x4b, 0x1d, 0x8b, 0x92, 0xd3,
+	0x03, 0x63, 0x52, 0x2d, 0x2f, 0xad, 0xee, 0x01, 0xef, 0xc2, 0x7f, 0xe0, 0x9e, 0x74, 0xd2, 0x86,
+	0xf7, 0x69, 0x74, 0x0d, 0x17, 0x26, 0x8d, 0x5a, 0x86, 0xab, 0xe1, 0x3a, 0xc4, 0x35, 0x88, 0x32,
+	0x98, 0xbf, 0x3d, 0x71, 0xa2, 0xf4, 0xa9, 0xdb, 0x54, 0x21, 0x05, 0x2d, 0x13, 0xf3, 0x53, 0x81,
+	0x95, 0x76, 0x04, 0x7a, 0x0d, 0x53, 0x92, 0x65, 0x22, 0x21, 0x3a, 0x15, 0x5c, 0x2d, 0x07, 0xab,
+	0xe1, 0x7a, 0x7a, 0xfb, 0xb8, 0xab, 0xc5, 0x7b, 0xc6, 0x99, 0x4a, 0xd5, 0xb6, 0xd5, 0x60, 0x5f,
+	0x1f, 0xbd, 0x83, 0xff, 0x1e, 0x28, 0xcc, 0x63, 0x11, 0x4a, 0x25, 0x53, 0xca, 0x35, 0xa3, 0x81,
+	0xe6, 0xe1, 0x49, 0x6e, 0x17, 0x6a, 0xd6, 0x31, 0xc2, 0x0e, 0x45, 0x7b, 0x98, 0xf9, 0xdd, 0xf5,
+	0xfb, 0x13, 0xf4, 0xfb, 0x63, 0x7a, 0x47, 0xaa, 0xad, 0xff, 0x23, 0x1d, 0x11, 0x51, 0x98, 0xf9,
+	0x3d, 0x46, 0x57, 0x30, 0xd4, 0x3a, 0x73, 0x7d, 0x30, 0x47, 0xb7, 0x8a, 0x63, 0xa5, 0x0e, 0x75,
+	0x55, 0xcd, 0x90, 0x83, 0x76, 0x15, 0x3d, 0xde, 0x4c, 0x9b, 0xa7, 0xfc, 0x98, 0x16, 0xb6, 0x26,
+	0x23, 0xec, 0x50, 0xf4, 0x0a, 0xc2, 0x5d, 0x96, 0xfe, 0xa3, 0x73, 0x8f, 0x60, 0x52, 0x10, 0xa5,
+	0x7e, 0x08, 0xd9, 0xf4, 0xae, 0xc5, 0xf1, 0xd8, 0xfe, 0xb7, 0x3c, 0xff, 0x3b, 0x00, 0xe1, 0x3e,
+	0xd5, 0x7d, 0x6e, 0x04, 0x00, 0x00,
 }
@@ -71,6 +71,11 @@ func (dpos *Dpos) SetTargetBit(bit int) {
 	dpos.miner.SetTargetBit(bit)
 }
 
+// SetBlockLimits sets the maximum size in bytes and number of transactions of the blocks produced and accepted
+func (dpos *Dpos) SetBlockLimits(maxSize int, maxTxCount int) {
+	dpos.miner.SetBlockLimits(maxSize, maxTxCount)
+}
+
 func (dpos *Dpos) SetKey(key string) {
 	dpos.miner.SetPrivKey(key)
 }
@@ -96,6 +101,9 @@ func (dpos *Dpos) GetBlockChain() *core.Blockchain {
 	return dpos.bc
 }
 
+// Validate returns true if block is within the limits of the miner, is produced by the producer of its time slot and is
+// the only block of that time slot added to the blockchain. It doesn't record block, so that a block received both
+// by broadcast and by sync is valid each time.
 func (dpos *Dpos) Validate(block *core.Block) bool {
 	if !dpos.miner.Validate(block) {
 		logger.Debug("Dpos: miner validate block failed")
@@ -109,10 +117,12 @@ func (dpos *Dpos) Validate(block *core.Block) bool {
 		logger.Debug("Dpos: doubleminting case found!")
 		return false
 	}
+	return true
+}
 
+// RecordBlock records block in its time slot, so that another block produced in the same time slot is rejected
+func (dpos *Dpos) RecordBlock(block *core.Block) {
 	dpos.slot.Add(block.GetTimestamp(), block)
-
-	return true
 }
 
 func (dpos *Dpos) Start() {
@@ -148,8 +158,9 @@ func (dpos *Dpos) isForking() bool {
 	return false
 }
 
+// isDoubleMint returns true if another block of the time slot of block has been added to the blockchain
 func (dpos *Dpos) isDoubleMint(block *core.Block) bool {
-	if _, exist := dpos.slot.Get(block.GetTimestamp()); exist {
+	if recorded, exist := dpos.slot.Get(block.GetTimestamp()); exist && !core.IsHashEqual(recorded.(*core.Block).GetHash(), block.GetHash()) {
 		logger.Debug("Someone is minting when they are not supposed to!")
 		return true
 	}
@@ -166,6 +177,7 @@ func (dpos *Dpos) FullyStop() bool {
 func (dpos *Dpos) updateNewBlock(newBlock *core.Block) {
 	logger.Info("DPoS: Minted a new block. height:", newBlock.GetHeight())
 	dpos.bc.AddBlockToTail(newBlock)
+	dpos.bc.GetTxPool().RemoveConflictingTransactions(newBlock.GetTransactions())
 	dpos.node.BroadcastBlock(newBlock)
 }
 
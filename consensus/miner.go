@@ -21,6 +21,7 @@ package consensus
 import (
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/dappley/go-dappley/core"
 	logger "github.com/sirupsen/logrus"
@@ -28,6 +29,13 @@ import (
 
 const defaulttargetBits = 0
 
+const (
+	defaultMaxBlockSize    = 1024 * 1024
+	defaultMaxBlockTxCount = 4096
+	// blockHeaderReserve is the room left in a block for the hash and signature set once it is mined
+	blockHeaderReserve = 128
+)
+
 type State int
 
 var maxNonce int64 = math.MaxInt64
@@ -47,6 +55,10 @@ type Miner struct {
 	nonce    int64
 	retChan  chan (*MinedBlock)
 	stop     bool
+	// maxBlockSize and maxBlockTxCount limit the size in bytes and the number of transactions of the blocks mined and
+	// validated
+	maxBlockSize    int
+	maxBlockTxCount int
 }
 
 func NewMiner() *Miner {
@@ -58,6 +70,9 @@ func NewMiner() *Miner {
 		newBlock: &MinedBlock{nil, false},
 		nonce:    0,
 		stop:     true,
+
+		maxBlockSize:    defaultMaxBlockSize,
+		maxBlockTxCount: defaultMaxBlockTxCount,
 	}
 	m.SetTargetBit(defaulttargetBits)
 	return m
@@ -71,6 +86,17 @@ func (miner *Miner) SetTargetBit(bit int) {
 	miner.target = target.Lsh(target, uint(256-bit))
 }
 
+// SetBlockLimits sets the maximum size in bytes and number of transactions, coinbase included, of the blocks mined and
+// of the blocks accepted from peers. A limit of 0 keeps the current one.
+func (miner *Miner) SetBlockLimits(maxSize int, maxTxCount int) {
+	if maxSize > 0 {
+		miner.maxBlockSize = maxSize
+	}
+	if maxTxCount > 0 {
+		miner.maxBlockTxCount = maxTxCount
+	}
+}
+
 func (miner *Miner) SetPrivKey(key string) {
 	miner.key = key
 }
@@ -120,7 +146,13 @@ func (miner *Miner) Stop() {
 	}
 }
 
+// Validate returns true if the hash of blk meets the target and blk is within the block limits
 func (miner *Miner) Validate(blk *core.Block) bool {
+	if !blk.IsWithinLimits(miner.maxBlockSize, miner.maxBlockTxCount) {
+		logger.Warn("Miner: block exceeds the block size or transaction count limit")
+		return false
+	}
+
 	var hashInt big.Int
 
 	hash := blk.GetHash()
@@ -157,18 +189,37 @@ func (miner *Miner) prepareBlock() *MinedBlock {
 
 	//verify all transactions
 	miner.verifyTransactions()
-	//get all transactions
-	txs := miner.bc.GetTxPool().PopSortedTransactions()
-	//add coinbase transaction to transaction pool
-	cbtx := core.NewCoinbaseTX(miner.cbAddr, "", miner.bc.GetMaxHeight()+1)
+	//get the transactions with the highest tips per byte that fit in the block
+	txs := miner.selectTransactions(parentBlock)
+	//the coinbase transaction claims the subsidy and the fees of all transactions in the block
+	fees, err := core.CalculateTotalTips(txs)
+	if err != nil {
+		logger.Error(err)
+		fees = 0
+	}
+	cbtx := core.NewCoinbaseTX(miner.cbAddr, "", miner.bc.GetMaxHeight()+1, fees)
 	txs = append(txs, &cbtx)
-	// TODO: add tips to txs
 
 	miner.nonce = 0
 	//prepare the new block (without the correct nonce value)
 	return &MinedBlock{core.NewBlock(txs, parentBlock), false}
 }
 
+// selectTransactions pops from the pool the transactions filling a block mined on top of parentBlock greedily up to
+// the block limits of the miner. The other transactions stay in the pool.
+func (miner *Miner) selectTransactions(parentBlock *core.Block) []*core.Transaction {
+	// Leave room for a coinbase transaction claiming the largest possible fees
+	cbtx := core.NewCoinbaseTX(miner.cbAddr, "", miner.bc.GetMaxHeight()+1, math.MaxUint64)
+	overhead := len(core.NewBlock([]*core.Transaction{&cbtx}, parentBlock).Serialize()) + blockHeaderReserve
+
+	maxSize := miner.maxBlockSize - overhead
+	maxCount := miner.maxBlockTxCount - 1
+	if maxSize <= 0 || maxCount <= 0 {
+		return []*core.Transaction{}
+	}
+	return miner.bc.GetTxPool().PopSortedTransactions(maxSize, maxCount)
+}
+
 //returns true if a block is mined; returns false if the nonce value does not satisfy the difficulty requirement
 func (miner *Miner) mineBlock(nonce int64) bool {
 	hash, ok := miner.verifyNonce(nonce, miner.newBlock.block)
@@ -203,5 +254,5 @@ func (miner *Miner) verifyNonce(nonce int64, blk *core.Block) (core.Hash, bool)
 func (miner *Miner) verifyTransactions() {
 	utxoPool := core.LoadUTXOIndex(miner.bc.GetDb())
 	txPool := miner.bc.GetTxPool()
-	txPool.FilterAllTransactions(utxoPool)
+	txPool.FilterAllTransactions(utxoPool, miner.bc.GetMaxHeight()+1, time.Now().Unix())
 }
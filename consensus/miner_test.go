@@ -130,4 +130,53 @@ func TestMiner_Start(t *testing.T) {
 	assert.True(t,blk.isValid)
 	assert.True(t,blk.block.VerifyHash())
 	assert.True(t,miner.Validate(blk.block))
-}
\ No newline at end of file
+}
+func TestMiner_SelectTransactions(t *testing.T) {
+	miner := NewMiner()
+	cbAddr := "1FoupuhmPN4q1wiUrM5QaYZjYKKLLXzPPg"
+	bc := core.CreateBlockchain(core.Address{cbAddr}, storage.NewRamStorage(), nil)
+	defer bc.GetDb().Close()
+	miner.Setup(bc, cbAddr, nil)
+	for i := 0; i < 3; i++ {
+		tx := core.MockTransaction()
+		tx.ID = []byte{byte(i)}
+		assert.Nil(t, bc.GetTxPool().Push(*tx))
+	}
+	parentBlock, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+
+	//the coinbase transaction counts towards the transaction limit
+	miner.SetBlockLimits(0, 2)
+	assert.Len(t, miner.selectTransactions(parentBlock), 1)
+	assert.Equal(t, 2, bc.GetTxPool().Len())
+
+	//no transaction fits in a block only large enough for its header and coinbase
+	miner.SetBlockLimits(200, defaultMaxBlockTxCount)
+	assert.Len(t, miner.selectTransactions(parentBlock), 0)
+	assert.Equal(t, 2, bc.GetTxPool().Len())
+
+	miner.SetBlockLimits(defaultMaxBlockSize, defaultMaxBlockTxCount)
+	assert.Len(t, miner.selectTransactions(parentBlock), 2)
+	assert.Equal(t, 0, bc.GetTxPool().Len())
+}
+
+func TestMiner_ValidateBlockLimits(t *testing.T) {
+	miner := NewMiner()
+	var txs []*core.Transaction
+	for i := 0; i < 3; i++ {
+		tx := core.MockTransaction()
+		tx.ID = []byte{byte(i)}
+		txs = append(txs, tx)
+	}
+	blk := core.NewBlock(txs, nil)
+	assert.True(t, miner.Validate(blk))
+
+	miner.SetBlockLimits(0, 2)
+	assert.False(t, miner.Validate(blk))
+
+	miner.SetBlockLimits(len(blk.Serialize())-1, defaultMaxBlockTxCount)
+	assert.False(t, miner.Validate(blk))
+
+	miner.SetBlockLimits(len(blk.Serialize()), 3)
+	assert.True(t, miner.Validate(blk))
+}
@@ -52,6 +52,11 @@ func (pow *ProofOfWork) SetTargetBit(bit int) {
 	pow.miner.SetTargetBit(bit)
 }
 
+// SetBlockLimits sets the maximum size in bytes and number of transactions of the blocks produced and accepted
+func (pow *ProofOfWork) SetBlockLimits(maxSize int, maxTxCount int) {
+	pow.miner.SetBlockLimits(maxSize, maxTxCount)
+}
+
 func (pow *ProofOfWork) SetKey(key string) {
 	pow.miner.SetPrivKey(key)
 }
@@ -88,6 +93,9 @@ func (pow *ProofOfWork) Validate(blk *core.Block) bool {
 	return pow.miner.Validate(blk)
 }
 
+// RecordBlock does nothing, as proof of work keeps no state about the blocks added to the blockchain
+func (pow *ProofOfWork) RecordBlock(blk *core.Block) {}
+
 func (pow *ProofOfWork) updateNewBlock(newBlock *core.Block) {
 	logger.Info("PoW: Minted a new block. height:", newBlock.GetHeight())
 	if !newBlock.VerifyHash() {
@@ -95,6 +103,7 @@ func (pow *ProofOfWork) updateNewBlock(newBlock *core.Block) {
 
 	}
 	pow.bc.AddBlockToTail(newBlock)
+	pow.bc.GetTxPool().RemoveConflictingTransactions(newBlock.GetTransactions())
 	pow.broadcastNewBlock(newBlock)
 }
 
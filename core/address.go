@@ -46,7 +46,14 @@ func (a Address) ValidateAddress() bool {
 	pubKeyHash = pubKeyHash[1 : len(pubKeyHash)-addressChecksumLen]
 	targetChecksum := Checksum(append([]byte{version}, pubKeyHash...))
 
-	return bytes.Compare(actualChecksum, targetChecksum) == 0
+	if bytes.Compare(actualChecksum, targetChecksum) != 0 {
+		return false
+	}
+	if version == conditionVersion {
+		_, err := decodeConditionPayload(pubKeyHash)
+		return err == nil
+	}
+	return true
 }
 
 func (a Address) GetPubKeyHash() ([]byte, bool) {
@@ -60,9 +67,31 @@ func (a Address) GetPubKeyHash() ([]byte, bool) {
 	pubKeyHash = pubKeyHash[1 : len(pubKeyHash)-addressChecksumLen]
 	targetChecksum := Checksum(append([]byte{version}, pubKeyHash...))
 
-	if bytes.Compare(actualChecksum, targetChecksum) == 0 {
-		return pubKeyHash, true
-	} else {
+	if bytes.Compare(actualChecksum, targetChecksum) != 0 {
 		return nil, false
 	}
+	// outputs paid to a condition address are indexed under the hash of the condition
+	if version == conditionVersion {
+		condition, err := decodeConditionPayload(pubKeyHash)
+		if err != nil {
+			return nil, false
+		}
+		return condition.Hash(), true
+	}
+	return pubKeyHash, true
+}
+
+// GetLockCondition returns the LockCondition encoded in a condition address
+func (a Address) GetLockCondition() (*LockCondition, error) {
+	payload := util.Base58Decode([]byte(a.Address))
+
+	if len(payload) < addressChecksumLen+1 || payload[0] != conditionVersion {
+		return nil, ErrNotConditionAddress
+	}
+	actualChecksum := payload[len(payload)-addressChecksumLen:]
+	targetChecksum := Checksum(payload[:len(payload)-addressChecksumLen])
+	if bytes.Compare(actualChecksum, targetChecksum) != 0 {
+		return nil, ErrNotConditionAddress
+	}
+	return decodeConditionPayload(payload[1 : len(payload)-addressChecksumLen])
 }
@@ -21,7 +21,6 @@ package core
 import (
 	"bytes"
 	"crypto/sha256"
-	"encoding/gob"
 	"time"
 
 	logger "github.com/sirupsen/logrus"
@@ -42,8 +41,9 @@ type BlockHeader struct {
 	prevHash  Hash
 	nonce     int64
 	timestamp int64
-	sign      Hash
-	height    uint64
+	sign       Hash
+	height     uint64
+	merkleRoot Hash
 }
 
 type Block struct {
@@ -66,7 +66,7 @@ func NewBlock(transactions []*Transaction, parent *Block) *Block {
 	if transactions == nil {
 		transactions = []*Transaction{}
 	}
-	return &Block{
+	block := &Block{
 		header: &BlockHeader{
 			hash:      []byte{},
 			prevHash:  prevHash,
@@ -77,70 +77,71 @@ func NewBlock(transactions []*Transaction, parent *Block) *Block {
 		},
 		transactions: transactions,
 	}
+	block.header.merkleRoot = block.HashTransactions()
+	return block
 }
 
+// HashTransactions returns the Merkle root of the hashes of the transactions in the block
 func (b *Block) HashTransactions() []byte {
-	var txHashes [][]byte
-	var txHash [32]byte
+	return MerkleRoot(b.getTransactionHashes())
+}
 
+func (b *Block) getTransactionHashes() [][]byte {
+	var txHashes [][]byte
 	for _, tx := range b.transactions {
 		txHashes = append(txHashes, tx.Hash())
 	}
-	txHash = sha256.Sum256(bytes.Join(txHashes, []byte{}))
-
-	return txHash[:]
+	return txHashes
 }
 
-func (b *Block) Serialize() []byte {
-	var result bytes.Buffer
-	encoder := gob.NewEncoder(&result)
-
-	bs := &BlockStream{
-		Header: &BlockHeaderStream{
-			Hash:      b.header.hash,
-			PrevHash:  b.header.prevHash,
-			Nonce:     b.header.nonce,
-			Timestamp: b.header.timestamp,
-			Sign:      b.header.sign,
-			Height:    b.header.height,
-		},
-		Transactions: b.transactions,
+// GetTransactionProof returns the position of the transaction identified by txid in the block and the Merkle branch
+// linking its hash to the Merkle root in the block header
+func (b *Block) GetTransactionProof(txid []byte) (int, [][]byte, error) {
+	for i, tx := range b.transactions {
+		if bytes.Compare(tx.ID, txid) == 0 {
+			branch, err := MerkleBranch(b.getTransactionHashes(), i)
+			return i, branch, err
+		}
 	}
+	return 0, nil, ErrTransactionNotFound
+}
 
-	err := encoder.Encode(bs)
+// Serialize returns the canonical encoding of a Block
+func (b *Block) Serialize() []byte {
+	encoded, err := marshalCanonical(b.ToProto())
 	if err != nil {
 		logger.Panic(err)
 	}
-	return result.Bytes()
+	return encoded
 }
 
 func Deserialize(d []byte) *Block {
-	var bs BlockStream
-	decoder := gob.NewDecoder(bytes.NewReader(d))
-	err := decoder.Decode(&bs)
-	if err != nil {
-		logger.Panic(err)
+	blockpb := &corepb.Block{}
+	err := proto.Unmarshal(d, blockpb)
+	if err != nil || blockpb.Header == nil {
+		logger.Panicf("failed to deserialize block: %v", err)
 	}
-	if bs.Header.Hash == nil {
-		bs.Header.Hash = Hash{}
+	b := &Block{}
+	b.FromProto(blockpb)
+	return normalizeBlock(b)
+}
+
+// normalizeBlock replaces the nil fields of a decoded block with empty values
+func normalizeBlock(b *Block) *Block {
+	if b.header.hash == nil {
+		b.header.hash = Hash{}
 	}
-	if bs.Header.PrevHash == nil {
-		bs.Header.PrevHash = Hash{}
+	if b.header.prevHash == nil {
+		b.header.prevHash = Hash{}
 	}
-	if bs.Transactions == nil {
-		bs.Transactions = []*Transaction{}
+	if b.transactions == nil {
+		b.transactions = []*Transaction{}
 	}
-	return &Block{
-		header: &BlockHeader{
-			hash:      bs.Header.Hash,
-			prevHash:  bs.Header.PrevHash,
-			nonce:     bs.Header.Nonce,
-			timestamp: bs.Header.Timestamp,
-			sign:      bs.Header.Sign,
-			height:    bs.Header.Height,
-		},
-		transactions: bs.Transactions,
+	// blocks stored before the Merkle root was part of the header carry none
+	if len(b.header.merkleRoot) == 0 {
+		b.header.merkleRoot = b.HashTransactions()
 	}
+	return b
 }
 
 func (b *Block) GetHeader() *BlockHeader {
@@ -167,6 +168,10 @@ func (b *Block) GetPrevHash() Hash {
 	return b.header.prevHash
 }
 
+func (b *Block) GetMerkleRoot() Hash {
+	return b.header.merkleRoot
+}
+
 func (b *Block) SetNonce(nonce int64) {
 	b.header.nonce = nonce
 }
@@ -214,12 +219,13 @@ func (b *Block) FromProto(pb proto.Message) {
 
 func (bh *BlockHeader) ToProto() proto.Message {
 	return &corepb.BlockHeader{
-		Hash:      bh.hash,
-		Prevhash:  bh.prevHash,
-		Nonce:     bh.nonce,
-		Timestamp: bh.timestamp,
-		Sign:      bh.sign,
-		Height:    bh.height,
+		Hash:       bh.hash,
+		Prevhash:   bh.prevHash,
+		Nonce:      bh.nonce,
+		Timestamp:  bh.timestamp,
+		Sign:       bh.sign,
+		Height:     bh.height,
+		MerkleRoot: bh.merkleRoot,
 	}
 }
 
@@ -230,6 +236,7 @@ func (bh *BlockHeader) FromProto(pb proto.Message) {
 	bh.timestamp = pb.(*corepb.BlockHeader).Timestamp
 	bh.sign = pb.(*corepb.BlockHeader).Sign
 	bh.height = pb.(*corepb.BlockHeader).Height
+	bh.merkleRoot = pb.(*corepb.BlockHeader).MerkleRoot
 }
 
 func (b *Block) CalculateHash() Hash {
@@ -240,7 +247,7 @@ func (b *Block) CalculateHashWithoutNonce() Hash {
 	data := bytes.Join(
 		[][]byte{
 			b.GetPrevHash(),
-			b.HashTransactions(),
+			b.GetMerkleRoot(),
 			util.IntToHex(b.GetTimestamp()),
 		},
 		[]byte{},
@@ -255,7 +262,7 @@ func (b *Block) CalculateHashWithNonce(nonce int64) Hash {
 	data := bytes.Join(
 		[][]byte{
 			b.GetPrevHash(),
-			b.HashTransactions(),
+			b.GetMerkleRoot(),
 			util.IntToHex(b.GetTimestamp()),
 			//util.IntToHex(targetBits),
 			util.IntToHex(nonce),
@@ -287,13 +294,62 @@ func (b *Block) SignBlock(key string, data []byte) bool {
 	return true
 }
 
+// VerifyHash returns true if the Merkle root and hash of the block match its content. Blocks listing a transaction
+// twice are rejected: the Merkle tree duplicates the last hash of odd levels, so such a block can have the same root as
+// a valid one.
 func (b *Block) VerifyHash() bool {
-	return bytes.Compare(b.GetHash(), b.CalculateHash()) == 0
+	return !b.hasDuplicateTransactions() &&
+		bytes.Compare(b.GetMerkleRoot(), b.HashTransactions()) == 0 &&
+		bytes.Compare(b.GetHash(), b.CalculateHash()) == 0
 }
 
+// hasDuplicateTransactions returns true if two transactions of the block have the same ID
+func (b *Block) hasDuplicateTransactions() bool {
+	txids := make(map[string]bool)
+	for _, tx := range b.transactions {
+		if txids[string(tx.ID)] {
+			return true
+		}
+		txids[string(tx.ID)] = true
+	}
+	return false
+}
+
+// IsWithinLimits returns true if the block is at most maxSize bytes and has at most maxTxCount transactions, coinbase
+// included
+func (b *Block) IsWithinLimits(maxSize int, maxTxCount int) bool {
+	return len(b.GetTransactions()) <= maxTxCount && len(b.Serialize()) <= maxSize
+}
+
+// VerifyTransactions verifies each transaction in the block against utxo and checks that the block has at most one
+// coinbase transaction, claiming no more than the fees paid by the other transactions
 func (b *Block) VerifyTransactions(utxo UTXOIndex) bool {
+	if b.hasDuplicateTransactions() {
+		logger.Error("Block: transaction listed more than once")
+		return false
+	}
+
+	// Transactions may spend the outputs of the transactions before them in the block, but not an output spent before
+	utxos := utxo.deepCopy()
+	var coinbase *Transaction
 	for _, tx := range b.GetTransactions() {
-		if !tx.Verify(utxo, b.GetHeight()) {
+		if tx.IsCoinbase() {
+			if coinbase != nil {
+				logger.Error("Block: more than one coinbase transaction")
+				return false
+			}
+			coinbase = tx
+		}
+		if !tx.Verify(utxos, b.GetHeight(), b.GetTimestamp()) {
+			return false
+		}
+		utxos.applyTransaction(tx, b.GetHeight(), b.GetTimestamp())
+	}
+
+	if coinbase != nil {
+		fees, err := CalculateTotalTips(b.GetTransactions())
+		if err != nil || coinbase.Tip > fees {
+			logger.Error("Block: coinbase transaction claims more than the fees of the block")
 			return false
 		}
 	}
@@ -20,6 +20,7 @@ package core
 
 import (
 	"encoding/hex"
+	"errors"
 
 	"github.com/dappley/go-dappley/common"
 	"github.com/hashicorp/golang-lru"
@@ -30,6 +31,11 @@ import (
 const BlockCacheLRUCacheLimit = 1024
 const ForkCacheLRUCacheLimit = 128
 
+var (
+	ErrInvalidBlockHash      = errors.New("ERROR: Block cannot pass hash verification")
+	ErrInvalidBlockSignature = errors.New("ERROR: Block cannot pass signature verification")
+)
+
 type BlockRequestPars struct {
 	BlockHash Hash
 	Pid       peer.ID
@@ -78,28 +84,30 @@ func (pool *BlockPool) VerifyTransactions(utxo UTXOIndex, forkBlks []*Block) boo
 			return false
 		}
 		logger.Info("Verifyed a block. Height: ", forkBlks[i].GetHeight(), "Have ", i, "block left")
-		utxoIndex := LoadUTXOIndex(pool.blockchain.GetDb())
-		utxoIndex.BuildForkUtxoIndex(forkBlks[i], pool.blockchain.GetDb())
+		// Apply the verified block in memory only, so the next block in the fork is verified against it
+		utxo.applyBlock(forkBlks[i])
 	}
 	return true
 }
 
-func (pool *BlockPool) Push(block *Block, pid peer.ID) {
+//Push verifies a block received from a peer and adds it to the pool. An error is returned if the block is invalid.
+func (pool *BlockPool) Push(block *Block, pid peer.ID) error {
 	logger.Debug("BlockPool: Has received a new block")
 
 	if !block.VerifyHash() {
 		logger.Warn("BlockPool: The received block cannot pass hash verification!")
-		return
+		return ErrInvalidBlockHash
 	}
 
 	if !(pool.blockchain.GetConsensus().VerifyBlock(block)) {
 		logger.Warn("BlockPool: The received block cannot pass signature verification!")
-		return
+		return ErrInvalidBlockSignature
 	}
 	//TODO: Verify double spending transactions in the same block
 
 	logger.Debug("BlockPool: Block has been verified")
 	pool.handleRecvdBlock(block, pid)
+	return nil
 }
 
 func (pool *BlockPool) handleRecvdBlock(blk *Block, sender peer.ID) {
@@ -133,7 +141,9 @@ func (pool *BlockPool) handleRecvdBlock(blk *Block, sender peer.ID) {
 		_, forkTailTree = tree.FindHeightestChild(forkTailTree, 0, 0)
 		trees := forkTailTree.GetParentTreesRange(tree)
 		forkBlks := getBlocksFromTrees(trees)
-		pool.blockchain.MergeFork(forkBlks)
+		if err := pool.blockchain.MergeFork(forkBlks); err != nil {
+			logger.Warn("BlockPool: Unable to merge the fork of block ", hex.EncodeToString(blk.GetHash()), ": ", err)
+		}
 		tree.Delete()
 	} else {
 		pool.requestPrevBlock(tree, sender)
@@ -19,10 +19,13 @@
 package core
 
 import (
+	"encoding/hex"
 	"testing"
 	"time"
 
+	"github.com/dappley/go-dappley/common"
 	"github.com/dappley/go-dappley/core/pb"
+	"github.com/dappley/go-dappley/storage"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
@@ -38,7 +41,7 @@ var blk = &Block{
 }
 
 var expect = []byte{0x42, 0xff, 0x81, 0x3, 0x1, 0x1, 0xb, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1, 0xff, 0x82, 0x0, 0x1, 0x3, 0x1, 0x6, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x1, 0xff, 0x84, 0x0, 0x1, 0xc, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1, 0xff, 0x90, 0x0, 0x1, 0x6, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x1, 0x6, 0x0, 0x0, 0x0, 0x4d, 0xff, 0x83, 0x3, 0x1, 0x1, 0x11, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1, 0xff, 0x84, 0x0, 0x1, 0x4, 0x1, 0x4, 0x48, 0x61, 0x73, 0x68, 0x1, 0xa, 0x0, 0x1, 0x8, 0x50, 0x72, 0x65, 0x76, 0x48, 0x61, 0x73, 0x68, 0x1, 0xa, 0x0, 0x1, 0x5, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x1, 0x4, 0x0, 0x1, 0x9, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x1, 0x4, 0x0, 0x0, 0x0, 0x22, 0xff, 0x8f, 0x2, 0x1, 0x1, 0x13, 0x5b, 0x5d, 0x2a, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1, 0xff, 0x90, 0x0, 0x1, 0xff, 0x86, 0x0, 0x0, 0x2e, 0xff, 0x85, 0x3, 0x1, 0x2, 0xff, 0x86, 0x0, 0x1, 0x4, 0x1, 0x2, 0x49, 0x44, 0x1, 0xa, 0x0, 0x1, 0x3, 0x56, 0x69, 0x6e, 0x1, 0xff, 0x8a, 0x0, 0x1, 0x4, 0x56, 0x6f, 0x75, 0x74, 0x1, 0xff, 0x8e, 0x0, 0x1, 0x3, 0x54, 0x69, 0x70, 0x1, 0x4, 0x0, 0x0, 0x0, 0x1d, 0xff, 0x89, 0x2, 0x1, 0x1, 0xe, 0x5b, 0x5d, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x54, 0x58, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1, 0xff, 0x8a, 0x0, 0x1, 0xff, 0x88, 0x0, 0x0, 0x40, 0xff, 0x87, 0x3, 0x1, 0x1, 0x7, 0x54, 0x58, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1, 0xff, 0x88, 0x0, 0x1, 0x4, 0x1, 0x4, 0x54, 0x78, 0x69, 0x64, 0x1, 0xa, 0x0, 0x1, 0x4, 0x56, 0x6f, 0x75, 0x74, 0x1, 0x4, 0x0, 0x1, 0x9, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x1, 0xa, 0x0, 0x1, 0x6, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x1, 0xa, 0x0, 0x0, 0x0, 0x1e, 0xff, 0x8d, 0x2, 0x1, 0x1, 0xf, 0x5b, 0x5d, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x54, 0x58, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x1, 0xff, 0x8e, 0x0, 0x1, 0xff, 0x8c, 0x0, 0x0, 0x2f, 0xff, 0x8b, 0x3, 0x1, 0x1, 0x8, 0x54, 0x58, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x1, 0xff, 0x8c, 0x0, 0x1, 0x2, 0x1, 0x5, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1, 0x4, 0x0, 0x1, 0xa, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x1, 0xa, 0x0, 0x0, 0x0, 0x13, 0xff, 0x82, 0x1, 0x2, 0x1, 0x61, 0x2, 0xfc, 0xb6, 0xb2, 0x24, 0x6a, 0x0, 0x1, 0x1, 0x0, 0x1, 0x1, 0x0}
-var expectHash = []uint8([]byte{0xff, 0xc1, 0x71, 0x5c, 0xce, 0x28, 0x40, 0xf, 0x9, 0xf4, 0xc1, 0xc8, 0x31, 0xdd, 0x58, 0xb, 0x1f, 0x5e, 0xa, 0xfb, 0xbc, 0x94, 0xcb, 0xe2, 0x60, 0x76, 0xf8, 0x73, 0x48, 0x3a, 0x60, 0xa0})
+var expectHash = []uint8([]byte{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0xfb, 0xf4, 0xc8, 0x99, 0x6f, 0xb9, 0x24, 0x27, 0xae, 0x41, 0xe4, 0x64, 0x9b, 0x93, 0x4c, 0xa4, 0x95, 0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55})
 var header2 = &BlockHeader{
 	hash:      []byte{'a'},
 	prevHash:  []byte{'e', 'c'},
@@ -105,6 +108,7 @@ func TestBlockHeader_Proto(t *testing.T) {
 		2,
 		nil,
 		0,
+		[]byte("root"),
 	}
 
 	pb := bh1.ToProto()
@@ -145,6 +149,16 @@ func TestBlock_Proto(t *testing.T) {
 	assert.Equal(t, *b1, *b2)
 }
 
+func TestBlock_SerializeDeserialize(t *testing.T) {
+	b1 := GenerateMockBlock()
+
+	encoded := b1.Serialize()
+	assert.Equal(t, encoded, b1.Serialize())
+
+	b2 := Deserialize(encoded)
+	assert.Equal(t, *b1, *b2)
+}
+
 func TestBlock_VerifyHash(t *testing.T) {
 	b1 := GenerateMockBlock()
 
@@ -159,13 +173,67 @@ func TestBlock_VerifyHash(t *testing.T) {
 	assert.True(t, b1.VerifyHash())
 }
 
+func TestBlock_VerifyTransactionsCoinbaseFees(t *testing.T) {
+	keyPair := NewKeyPair()
+	address := keyPair.GenerateAddress()
+	prevTx := NewCoinbaseTX(address.Address, "", 0, 0)
+	utxoIndex := NewUTXOIndex()
+	utxoIndex.addUTXO(prevTx.Vout[0], prevTx.ID, 0)
+
+	// Pays a fee of 2 out of the 10 spent
+	tx := Transaction{nil, []TXInput{{prevTx.ID, 0, nil, keyPair.PublicKey, nil}}, []TXOutput{*NewTXOutput(common.NewAmount(8), address.Address)}, 2}
+	tx.ID = tx.Hash()
+	assert.Nil(t, tx.Sign(keyPair.PrivateKey, map[string]Transaction{hex.EncodeToString(prevTx.ID): prevTx}))
+
+	parent := NewBlock(nil, nil)
+	cbtxWithFees := NewCoinbaseTX(address.Address, "", 2, 2)
+	cbtxWithoutFees := NewCoinbaseTX(address.Address, "", 2, 0)
+	cbtxTooMuch := NewCoinbaseTX(address.Address, "", 2, 3)
+
+	tests := []struct {
+		name string
+		txs  []*Transaction
+		ok   bool
+	}{
+		{"coinbase claims all fees", []*Transaction{&tx, &cbtxWithFees}, true},
+		{"coinbase claims no fees", []*Transaction{&tx, &cbtxWithoutFees}, true},
+		{"coinbase claims more than the fees", []*Transaction{&tx, &cbtxTooMuch}, false},
+		{"coinbase claims fees of an empty block", []*Transaction{&cbtxWithFees}, false},
+		{"two coinbase transactions", []*Transaction{&tx, &cbtxWithoutFees, &cbtxWithoutFees}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ok, NewBlock(tt.txs, parent).VerifyTransactions(utxoIndex))
+		})
+	}
+}
+
+func TestBlock_VerifyTransactionsDoubleSpend(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	bc := CreateBlockchain(from, db, nil)
+
+	tx1, err := NewUTXOTransaction(db, from, NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F"), common.NewAmount(3), *keyPair, bc, 0)
+	assert.Nil(t, err)
+	tx2, err := NewUTXOTransaction(db, from, NewAddress("1MeSBgufmzwpiJNLemUe1emxAussBnz7a7"), common.NewAmount(3), *keyPair, bc, 0)
+	assert.Nil(t, err)
+
+	tailBlock, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	utxoIndex := LoadUTXOIndex(db)
+	assert.True(t, NewBlock([]*Transaction{&tx1}, tailBlock).VerifyTransactions(utxoIndex))
+	assert.False(t, NewBlock([]*Transaction{&tx1, &tx2}, tailBlock).VerifyTransactions(utxoIndex))
+}
+
 func TestBlock_Rollback(t *testing.T) {
 	b := GenerateMockBlock()
 	tx := MockTransaction()
 	b.transactions = []*Transaction{tx}
 	txPool := NewTransactionPool()
 	b.Rollback(txPool)
-	assert.ElementsMatch(t, tx.ID, txPool.Transactions.Right().(Transaction).ID)
+	assert.ElementsMatch(t, tx.ID, txPool.transactions.Right().(Transaction).ID)
 }
 
 func TestBlock_FindTransaction(t *testing.T) {
@@ -209,8 +277,8 @@ func TestIsParentBlockHeight(t *testing.T) {
 func TestCalculateHashWithNonce(t *testing.T) {
 	block := NewBlock([]*Transaction{&Transaction{}}, blk3)
 	block.header.timestamp = 0
-	expectHash1 := Hash{0xea, 0x41, 0x77, 0xc1, 0x68, 0x5f, 0x34, 0x8c, 0x9d, 0x46, 0xed, 0xbd, 0x8b, 0x89, 0x21, 0xa4, 0x57, 0xf9, 0xec, 0x16, 0xa4, 0x9a, 0xff, 0x33, 0xb4, 0x3d, 0x5e, 0x33, 0x4d, 0x3b, 0xe4, 0x26}
+	expectHash1 := Hash{0x1f, 0xe0, 0x60, 0xe8, 0x9b, 0xf1, 0x52, 0x3e, 0xee, 0xa5, 0x7, 0x2f, 0xdb, 0x7c, 0xa0, 0x42, 0x77, 0xba, 0x8f, 0xf4, 0xc9, 0xb6, 0xe7, 0x46, 0xf3, 0xc9, 0x46, 0xb, 0xc1, 0x3e, 0x21, 0x19}
 	assert.Equal(t, Hash(expectHash1), block.CalculateHashWithNonce(1))
-	expectHash2 := Hash{0xd, 0xb7, 0x2f, 0x29, 0xc8, 0x6b, 0xae, 0x2e, 0x2, 0x4c, 0xb9, 0x39, 0x72, 0x44, 0x6f, 0x44, 0xfe, 0xfc, 0xf6, 0x16, 0x60, 0x38, 0x8, 0xeb, 0x25, 0x34, 0x69, 0xc3, 0x7f, 0x2f, 0x87, 0x7a}
+	expectHash2 := Hash{0x81, 0x15, 0x28, 0x69, 0xad, 0xe2, 0x1f, 0xc, 0x4e, 0x4e, 0x2, 0xa9, 0xc9, 0xcc, 0x1a, 0x40, 0x3a, 0xec, 0xf4, 0xc0, 0x36, 0x91, 0x93, 0x43, 0x29, 0x8, 0x24, 0xbd, 0xf7, 0xb7, 0x5d, 0xc4}
 	assert.Equal(t, Hash(expectHash2), block.CalculateHashWithNonce(2))
 }
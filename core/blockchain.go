@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jinzhu/copier"
 
@@ -41,6 +42,8 @@ var (
 	ErrNotAbleToGetLastBlockHash = errors.New("ERROR: Not able to get last block hash in blockchain")
 	ErrTransactionNotFound       = errors.New("ERROR: Transaction not found")
 	ErrDuplicatedBlock           = errors.New("ERROR: Block already exists in blockchain")
+	ErrInvalidForkTransactions   = errors.New("ERROR: Fork contains transactions that cannot pass verification")
+	ErrRollbackFailed            = errors.New("ERROR: Not able to roll back to the fork parent")
 )
 
 type Blockchain struct {
@@ -53,7 +56,13 @@ type Blockchain struct {
 
 // CreateBlockchain creates a new blockchain db
 func CreateBlockchain(address Address, db storage.Storage, consensus Consensus) *Blockchain {
-	genesis := NewGenesisBlock(address.Address)
+	return CreateBlockchainWithAllocations(address, nil, db, consensus)
+}
+
+// CreateBlockchainWithAllocations creates a blockchain whose genesis block pays the subsidy to address and seeds the
+// balances listed in allocations
+func CreateBlockchainWithAllocations(address Address, allocations []GenesisAllocation, db storage.Storage, consensus Consensus) *Blockchain {
+	genesis := NewGenesisBlock(address.Address, allocations)
 	bc := &Blockchain{
 		genesis.GetHash(),
 		db,
@@ -62,16 +71,27 @@ func CreateBlockchain(address Address, db storage.Storage, consensus Consensus)
 		NewTransactionPool(),
 	}
 	bc.blockPool.SetBlockchain(bc)
+	bc.txPool.SetBlockchain(bc)
 	err := bc.AddBlockToTail(genesis)
 	if err != nil {
 		logger.Panic("Blockchain: Add Genesis Block Failed During Blockchain Creation!")
 	}
+	err = putDbVersion(db, CurrentDbVersion)
+	if err != nil {
+		logger.Panic("Blockchain: Set Database Version Failed During Blockchain Creation!")
+	}
 	return bc
 }
 
+// GetBlockchain loads the blockchain stored in db, migrating db to CurrentDbVersion before reading anything else
 func GetBlockchain(db storage.Storage, consensus Consensus) (*Blockchain, error) {
+	err := MigrateDb(db)
+	if err != nil {
+		return nil, err
+	}
+
 	var tip []byte
-	tip, err := db.Get(tipKey)
+	tip, err = db.Get(tipKey)
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +101,12 @@ func GetBlockchain(db storage.Storage, consensus Consensus) (*Blockchain, error)
 		db,
 		NewBlockPool(BlockPoolMaxSize),
 		consensus,
-		NewTransactionPool(), //TODO: Need to retrieve transaction pool from db
+		LoadTxPoolFromDatabase(db),
 	}
 	bc.blockPool.SetBlockchain(bc)
+	bc.txPool.SetBlockchain(bc)
+	//discard the saved transactions that were mined or became invalid while the node was down
+	bc.txPool.FilterAllTransactions(LoadUTXOIndex(db), bc.GetMaxHeight()+1, time.Now().Unix())
 
 	if err != nil {
 		return nil, err
@@ -176,6 +199,15 @@ func (bc *Blockchain) AddBlockToTail(block *Block) error {
 		return err
 	}
 
+	err = putTxIndex(bcTemp.db, block)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"height": block.GetHeight(),
+			"hash":   hex.EncodeToString(block.GetHash()),
+		}).Error("Blockchain: Update transaction index failed!")
+		return err
+	}
+
 	utxoIndex := LoadUTXOIndex(bcTemp.db)
 	err = utxoIndex.BuildForkUtxoIndex(block, bcTemp.db)
 	if err != nil {
@@ -186,6 +218,18 @@ func (bc *Blockchain) AddBlockToTail(block *Block) error {
 		return err
 	}
 
+	// Save the pending transactions with every block, so that they are kept when the node is not shut down cleanly
+	if bcTemp.txPool != nil {
+		err = bcTemp.txPool.SaveToDatabase(bcTemp.db)
+		if err != nil {
+			logger.WithFields(logger.Fields{
+				"height": block.GetHeight(),
+				"hash":   hex.EncodeToString(block.GetHash()),
+			}).Error("Blockchain: Save transaction pool failed!")
+			return err
+		}
+	}
+
 	// Flush batch changes to storage
 	err = bcTemp.db.Flush()
 	if err != nil {
@@ -199,6 +243,10 @@ func (bc *Blockchain) AddBlockToTail(block *Block) error {
 	// Assign changes to receiver
 	*bc = *bcTemp
 
+	if bc.consensus != nil {
+		bc.consensus.RecordBlock(block)
+	}
+
 	logger.WithFields(logger.Fields{
 		"height": block.GetHeight(),
 		"hash":   hex.EncodeToString(block.GetHash()),
@@ -207,43 +255,81 @@ func (bc *Blockchain) AddBlockToTail(block *Block) error {
 	return nil
 }
 
-//TODO: optimize performance
+// FindTransaction looks up the transaction with the given ID in the transaction index
 func (bc *Blockchain) FindTransaction(ID []byte) (Transaction, error) {
-	bci := bc.Iterator()
+	txIndex, err := GetTxIndex(bc.db, ID)
+	if err != nil {
+		return Transaction{}, ErrTransactionNotFound
+	}
 
-	for {
-		block, err := bci.Next()
+	block, err := bc.GetBlockByHash(txIndex.BlockId)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return getTransactionFromBlock(block, txIndex.BlockIndex, ID)
+}
+
+// FindTransactionFromIndexBlock finds the transaction with the given ID in the blocks from blockId back to genesis
+func (bc *Blockchain) FindTransactionFromIndexBlock(txID []byte, blockId []byte) (Transaction, error) {
+	indexBlock, err := bc.GetBlockByHash(blockId)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	txIndex, err := GetTxIndex(bc.db, txID)
+	if err != nil {
+		return Transaction{}, ErrTransactionNotFound
+	}
+
+	block, err := bc.GetBlockByHash(txIndex.BlockId)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	if block.GetHeight() > indexBlock.GetHeight() {
+		return Transaction{}, ErrTransactionNotFound
+	}
+
+	//the indexed block must be an ancestor of the index block, and not a block of another branch at a lower height
+	ancestor := indexBlock
+	for ancestor.GetHeight() > block.GetHeight() {
+		ancestor, err = bc.GetBlockByHash(ancestor.GetPrevHash())
 		if err != nil {
 			return Transaction{}, err
 		}
+	}
+	if !IsHashEqual(ancestor.GetHash(), block.GetHash()) {
+		return Transaction{}, ErrTransactionNotFound
+	}
 
-		for _, tx := range block.GetTransactions() {
-			if bytes.Compare(tx.ID, ID) == 0 {
-				return *tx, nil
-			}
-		}
+	return getTransactionFromBlock(block, txIndex.BlockIndex, txID)
+}
 
-		if len(block.GetPrevHash()) == 0 {
-			break
-		}
+func getTransactionFromBlock(block *Block, index int, txID []byte) (Transaction, error) {
+	txs := block.GetTransactions()
+	if index < 0 || index >= len(txs) || bytes.Compare(txs[index].ID, txID) != 0 {
+		return Transaction{}, ErrTransactionNotFound
 	}
-
-	return Transaction{}, ErrTransactionNotFound
+	return *txs[index], nil
 }
 
-func (bc *Blockchain) FindTransactionFromIndexBlock(txID []byte, blockId []byte) (Transaction, error) {
-	bci := bc.Iterator()
+// RebuildTxIndex writes the transaction index of every block from the tail back to genesis.
+// It is used to index databases created before the transaction index existed.
+func (bc *Blockchain) RebuildTxIndex() error {
+	bc.db.EnableBatch()
+	defer bc.db.DisableBatch()
 
+	bci := bc.Iterator()
 	for {
-		block, err := bci.NextFromIndex(blockId)
+		block, err := bci.Next()
 		if err != nil {
-			return Transaction{}, err
+			return err
 		}
 
-		for _, tx := range block.GetTransactions() {
-			if bytes.Compare(tx.ID, txID) == 0 {
-				return *tx, nil
-			}
+		err = putTxIndex(bc.db, block)
+		if err != nil {
+			return err
 		}
 
 		if len(block.GetPrevHash()) == 0 {
@@ -251,7 +337,7 @@ func (bc *Blockchain) FindTransactionFromIndexBlock(txID []byte, blockId []byte)
 		}
 	}
 
-	return Transaction{}, ErrTransactionNotFound
+	return bc.db.Flush()
 }
 
 //TODO: optimize performance
@@ -338,21 +424,6 @@ func (bc *Blockchain) Next() (*Block, error) {
 	return block, nil
 }
 
-func (bc *Blockchain) NextFromIndex(indexHash []byte) (*Block, error) {
-	var block *Block
-
-	encodedBlock, err := bc.db.Get(indexHash)
-	if err != nil {
-		return nil, err
-	}
-
-	block = Deserialize(encodedBlock)
-
-	bc.tailBlockHash = block.GetPrevHash()
-	println(bc.tailBlockHash)
-	return block, nil
-}
-
 func (bc *Blockchain) String() string {
 	var buffer bytes.Buffer
 
@@ -412,19 +483,78 @@ func (bc *Blockchain) IsInBlockchain(hash Hash) bool {
 	return err == nil
 }
 
-func (bc *Blockchain) MergeFork(forkBlks []*Block) {
+// GetBlockLocator returns the hashes of blocks of the blockchain from the tail to the genesis block: the 10 latest
+// blocks, then blocks further and further apart. A peer finds the latest block both blockchains have in common with
+// FindCommonBlock.
+func (bc *Blockchain) GetBlockLocator() []Hash {
+	var locator []Hash
+	height := bc.GetMaxHeight()
+	step := uint64(1)
+	for {
+		block, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			return locator
+		}
+		locator = append(locator, block.GetHash())
+		if height == 0 {
+			return locator
+		}
+		if len(locator) >= 10 {
+			step *= 2
+		}
+		if height < step {
+			height = 0
+		} else {
+			height -= step
+		}
+	}
+}
+
+// FindCommonBlock returns the first block of locator that is part of the blockchain, or the genesis block if there is
+// none
+func (bc *Blockchain) FindCommonBlock(locator []Hash) *Block {
+	for _, hash := range locator {
+		block, err := bc.GetBlockByHash(hash)
+		if err != nil {
+			continue
+		}
+		// Blocks of forks that were rolled back are still in the db, but not at their height
+		if blockAtHeight, err := bc.GetBlockByHeight(block.GetHeight()); err == nil && bytes.Equal(blockAtHeight.GetHash(), hash) {
+			return block
+		}
+	}
+	block, _ := bc.GetBlockByHeight(0)
+	return block
+}
+
+// GetBlocksAfter returns up to maxCount blocks of the blockchain following block
+func (bc *Blockchain) GetBlocksAfter(block *Block, maxCount int) []*Block {
+	var blocks []*Block
+	for height := block.GetHeight() + 1; len(blocks) < maxCount; height++ {
+		next, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			break
+		}
+		blocks = append(blocks, next)
+	}
+	return blocks
+}
+
+//MergeFork replaces the blocks following the parent of the fork with forkBlks, which are ordered from tail to head. It
+//returns ErrInvalidForkTransactions if the transactions of the fork cannot pass verification.
+func (bc *Blockchain) MergeFork(forkBlks []*Block) error {
 
 	//find parent block
 	if len(forkBlks) == 0 {
-		return
+		return nil
 	}
 	forkHeadBlock := forkBlks[len(forkBlks)-1]
 	if forkHeadBlock == nil {
-		return
+		return nil
 	}
 	forkParentHash := forkHeadBlock.GetPrevHash()
 	if !bc.IsInBlockchain(forkParentHash) {
-		return
+		return ErrBlockDoesNotExist
 	}
 
 	//verify transactions in the fork
@@ -433,15 +563,19 @@ func (bc *Blockchain) MergeFork(forkBlks []*Block) {
 		logger.Warn(err)
 	}
 	if !bc.GetBlockPool().VerifyTransactions(utxo, forkBlks) {
-		return
+		return ErrInvalidForkTransactions
 	}
 
-	bc.Rollback(forkParentHash)
+	if !bc.Rollback(forkParentHash) {
+		logger.Error("Blockchain: Not Able To Roll Back To Fork Parent!")
+		return ErrRollbackFailed
+	}
 
 	//add all blocks in fork from head to tail
 	bc.concatenateForkToBlockchain(forkBlks)
 
 	logger.Debug("Merged Fork!!")
+	return nil
 }
 
 func (bc *Blockchain) AddBlockToBlockchainTail(blk *Block) {
@@ -449,8 +583,9 @@ func (bc *Blockchain) AddBlockToBlockchainTail(blk *Block) {
 	if err != nil {
 		logger.Error("Blockchain: Not Able To Add Block To Tail While Concatenating Fork To Blockchain!")
 	}
-	//Remove transactions in current transaction pool
+	//Remove transactions in current transaction pool and the ones conflicting with them
 	bc.GetTxPool().RemoveMultipleTransactions(blk.GetTransactions())
+	bc.GetTxPool().RemoveConflictingTransactions(blk.GetTransactions())
 }
 
 func (bc *Blockchain) concatenateForkToBlockchain(forkBlks []*Block) {
@@ -460,45 +595,98 @@ func (bc *Blockchain) concatenateForkToBlockchain(forkBlks []*Block) {
 			if err != nil {
 				logger.Error("Blockchain: Not Able To Add Block To Tail While Concatenating Fork To Blockchain!")
 			}
-			//Remove transactions in current transaction pool
+			//Remove transactions in current transaction pool and the ones conflicting with them
 			bc.GetTxPool().RemoveMultipleTransactions(forkBlks[i].GetTransactions())
+			bc.GetTxPool().RemoveConflictingTransactions(forkBlks[i].GetTransactions())
 		}
 	}
 }
 
-//rollback the blockchain to a block with the targetHash
+//rollback the blockchain to a block with the targetHash. The tail block hash, UTXO index, transaction index and
+//height mappings are rewritten in a single batch, so either all of them are rolled back or none is.
 func (bc *Blockchain) Rollback(targetHash Hash) bool {
 
 	if !bc.IsInBlockchain(targetHash) {
 		return false
 	}
 
-	parentblockHash := bc.GetTailBlockHash()
+	bcTemp := bc.deepCopy()
+
+	bcTemp.db.EnableBatch()
+	defer bcTemp.db.DisableBatch()
+
+	utxoIndex := LoadUTXOIndex(bcTemp.db)
+	var rolledBackBlks []*Block
+	parentblockHash := bcTemp.GetTailBlockHash()
 
 	//keep rolling back blocks until the block with the input hash
-loop:
-	for {
-		if bytes.Compare(parentblockHash, targetHash) == 0 {
-			break loop
+	for bytes.Compare(parentblockHash, targetHash) != 0 {
+		block, err := bcTemp.GetBlockByHash(parentblockHash)
+		if err != nil {
+			return false
 		}
-		block, err := bc.GetBlockByHash(parentblockHash)
 
+		err = bcTemp.undoBlock(block, utxoIndex)
 		if err != nil {
+			logger.WithFields(logger.Fields{
+				"height": block.GetHeight(),
+				"hash":   hex.EncodeToString(block.GetHash()),
+			}).Error("Blockchain: Not Able To Undo Block During RollBack!")
 			return false
 		}
+		rolledBackBlks = append(rolledBackBlks, block)
 		parentblockHash = block.GetPrevHash()
-		block.Rollback(bc.txPool)
 	}
 
-	err := bc.setTailBlockHash(parentblockHash)
+	err := utxoIndex.Save(bcTemp.db)
+	if err != nil {
+		logger.Error("Blockchain: Not Able To Update UTXO Index During RollBack!")
+		return false
+	}
+
+	err = bcTemp.setTailBlockHash(parentblockHash)
 	if err != nil {
 		logger.Error("Blockchain: Not Able To Set Tail Block Hash During RollBack!")
 		return false
 	}
 
+	err = bcTemp.db.Flush()
+	if err != nil {
+		logger.Error("Blockchain: Not Able To Flush RollBack To Database!")
+		return false
+	}
+
+	*bc = *bcTemp
+
+	//return the transactions in the rolled back blocks to the transaction pool
+	for _, block := range rolledBackBlks {
+		block.Rollback(bc.txPool)
+	}
+
 	return true
 }
 
+// undoBlock reverts blk in utxoIndex and removes its transaction index, undo journal and height mapping from db
+func (bc *Blockchain) undoBlock(blk *Block, utxoIndex UTXOIndex) error {
+	journal, err := loadUndoJournal(bc.db, bc, blk)
+	if err != nil {
+		return err
+	}
+	utxoIndex.undoBlock(blk, journal)
+
+	err = delTxIndex(bc.db, blk)
+	if err != nil {
+		return err
+	}
+
+	err = delUndoJournal(bc.db, blk.GetHash())
+	if err != nil {
+		return err
+	}
+
+	return bc.db.Del(util.UintToHex(blk.GetHeight()))
+}
+
 func (bc *Blockchain) setTailBlockHash(hash Hash) error {
 	err := bc.db.Put(tipKey, hash)
 	if err != nil {
@@ -19,11 +19,11 @@
 package core
 
 import (
-	"encoding/hex"
 	"errors"
 	"os"
 	"testing"
 
+	"github.com/dappley/go-dappley/common"
 	"github.com/dappley/go-dappley/storage"
 	"github.com/dappley/go-dappley/storage/mocks"
 	logger "github.com/sirupsen/logrus"
@@ -49,6 +49,37 @@ func TestCreateBlockchain(t *testing.T) {
 	assert.Empty(t, blk.GetPrevHash())
 }
 
+func TestCreateBlockchainWithAllocations(t *testing.T) {
+	s := storage.NewRamStorage()
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	allocations := []GenesisAllocation{
+		{NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F"), common.NewAmount(1000)},
+		{NewAddress("1MeSBgufmzwpiJNLemUe1emxAussBnz7a7"), common.NewAmount(20)},
+	}
+	bc := CreateBlockchainWithAllocations(addr, allocations, s, nil)
+
+	// Allocations change the genesis block and are each paid by a coinbase transaction
+	assert.NotEqual(t, NewGenesisBlock(addr.Address, nil).GetHash(), Hash(bc.GetTailBlockHash()))
+	genesis, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	assert.Len(t, genesis.GetTransactions(), len(allocations)+1)
+	for _, tx := range genesis.GetTransactions() {
+		assert.True(t, tx.IsCoinbase())
+	}
+	// An allocation of the block subsidy passes coinbase verification at the genesis height
+	allocationTx := NewGenesisBlock(addr.Address, []GenesisAllocation{{addr, subsidy}}).GetTransactions()[1]
+	assert.True(t, allocationTx.Verify(UTXOIndex{}, 0, 0))
+
+	utxoIndex := LoadUTXOIndex(s)
+	for _, allocation := range append(allocations, GenesisAllocation{addr, subsidy}) {
+		pubKeyHash, _ := allocation.Address.GetPubKeyHash()
+		utxos := utxoIndex.GetUTXOsByPubKeyHash(pubKeyHash)
+		if assert.Len(t, utxos, 1) {
+			assert.Equal(t, allocation.Amount, utxos[0].Value)
+		}
+	}
+}
+
 func TestBlockchain_HigherThanBlockchainTestHigher(t *testing.T) {
 	//create a new block chain
 	s := storage.NewRamStorage()
@@ -114,18 +145,48 @@ func TestBlockchain_RollbackToABlock(t *testing.T) {
 
 }
 
-func TestBlockchain_AddBlockToTail(t *testing.T) {
+func TestBlockchain_GetBlockLocator(t *testing.T) {
+	bc := GenerateMockBlockchain(30)
+	defer bc.db.Close()
+
+	locator := bc.GetBlockLocator()
+	var heights []uint64
+	for _, hash := range locator {
+		blk, err := bc.GetBlockByHash(hash)
+		assert.Nil(t, err)
+		heights = append(heights, blk.GetHeight())
+	}
+	assert.Equal(t, []uint64{30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 19, 15, 7, 0}, heights)
+}
 
-	// Serialized data of an empty UTXOIndex (generated using `hex.EncodeToString(UTXOIndex{}.serialize())`)
-	serializedUTXOIndex, _ := hex.DecodeString("1aff87040101095554584f496e64657801ff8800010c01ff8600000dff8502010" +
-		"2ff860001ff8200003bff81030102ff82000104010556616c756501ff8400010a5075624b657948617368010a00010454786964010a" +
-		"0001075478496e64657801040000000aff83050102ff8a0000000fff8b05010103496e7401ff8c00000004ff880000")
+func TestBlockchain_FindCommonBlock(t *testing.T) {
+	bc := GenerateMockBlockchain(10)
+	defer bc.db.Close()
+	genesis, _ := bc.GetBlockByHeight(0)
+	blk5, _ := bc.GetBlockByHeight(5)
+	locator := bc.GetBlockLocator()
+
+	assert.Equal(t, bc.GetTailBlockHash(), bc.FindCommonBlock(locator).GetHash())
+	assert.Equal(t, blk5.GetHash(), bc.FindCommonBlock([]Hash{Hash("unknown"), blk5.GetHash()}).GetHash())
+	assert.Equal(t, genesis.GetHash(), bc.FindCommonBlock(nil).GetHash())
+
+	//blocks that were rolled back are not in common
+	bc.Rollback(blk5.GetHash())
+	assert.Equal(t, blk5.GetHash(), bc.FindCommonBlock(locator).GetHash())
+
+	blocks := bc.GetBlocksAfter(genesis, 3)
+	assert.Len(t, blocks, 3)
+	assert.Equal(t, uint64(1), blocks[0].GetHeight())
+	assert.Len(t, bc.GetBlocksAfter(genesis, 10), 5)
+}
+
+func TestBlockchain_AddBlockToTail(t *testing.T) {
 
 	db := new(mocks.Storage)
 
 	// Storage will allow blockchain creation to succeed
 	db.On("Put", mock.Anything, mock.Anything).Return(nil)
-	db.On("Get", []byte("utxo")).Return(serializedUTXOIndex, nil)
+	db.On("Get", mock.Anything).Return(nil, storage.ErrKeyInvalid)
 	db.On("EnableBatch").Return()
 	db.On("DisableBatch").Return()
 	// Flush invoked in AddBlockToTail twice
@@ -136,7 +197,7 @@ func TestBlockchain_AddBlockToTail(t *testing.T) {
 	bc := &Blockchain{Hash{}, db, nil, nil, nil}
 
 	// Add genesis block
-	genesis := NewGenesisBlock(addr.Address)
+	genesis := NewGenesisBlock(addr.Address, nil)
 	err := bc.AddBlockToTail(genesis)
 
 	// Expect batch write was used
@@ -0,0 +1,184 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+
+	"github.com/dappley/go-dappley/storage"
+	logger "github.com/sirupsen/logrus"
+)
+
+const dbVersionKey = "dbVersion"
+
+const (
+	// dbVersionLegacy is the version of databases created before the version was recorded. Blocks are stored in gob.
+	dbVersionLegacy = 0
+	// dbVersionCanonicalEncoding stores blocks in their canonical encoding and UTXOs keyed by outpoint.
+	dbVersionCanonicalEncoding = 1
+
+	// CurrentDbVersion is the version of the databases written by this release
+	CurrentDbVersion = dbVersionCanonicalEncoding
+)
+
+var (
+	ErrDbVersionNotSupported = errors.New("ERROR: Database version is newer than supported")
+)
+
+// dbMigrations[i] upgrades a database from version i to version i+1
+var dbMigrations = []func(db storage.Storage) error{
+	migrateToCanonicalEncoding,
+}
+
+// GetDbVersion returns the version of the data layout in db
+func GetDbVersion(db storage.Storage) (int, error) {
+	rawBytes, err := db.Get([]byte(dbVersionKey))
+	if err == storage.ErrKeyInvalid {
+		return dbVersionLegacy, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(rawBytes)), nil
+}
+
+func putDbVersion(db storage.Storage, version int) error {
+	rawBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(rawBytes, uint32(version))
+	return db.Put([]byte(dbVersionKey), rawBytes)
+}
+
+// MigrateDb upgrades db to CurrentDbVersion one version at a time. Each migration is written in a single batch
+// together with the new version number, so an interrupted migration can simply be run again.
+func MigrateDb(db storage.Storage) error {
+	version, err := GetDbVersion(db)
+	if err != nil {
+		return err
+	}
+	if version > CurrentDbVersion {
+		return ErrDbVersionNotSupported
+	}
+
+	for ; version < CurrentDbVersion; version++ {
+		logger.WithFields(logger.Fields{
+			"from": version,
+			"to":   version + 1,
+		}).Info("Blockchain: Migrating database...")
+
+		err = migrate(db, version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrate(db storage.Storage, version int) error {
+	db.EnableBatch()
+	defer db.DisableBatch()
+
+	err := dbMigrations[version](db)
+	if err != nil {
+		return err
+	}
+
+	err = putDbVersion(db, version+1)
+	if err != nil {
+		return err
+	}
+	return db.Flush()
+}
+
+// legacyBlockHeaderStream and legacyBlockStream are the gob layout of blocks stored by databases older than
+// dbVersionCanonicalEncoding
+type legacyBlockHeaderStream struct {
+	Hash      Hash
+	PrevHash  Hash
+	Nonce     int64
+	Timestamp int64
+	Sign      Hash
+	Height    uint64
+}
+
+type legacyBlockStream struct {
+	Header       *legacyBlockHeaderStream
+	Transactions []*Transaction
+}
+
+// deserializeLegacyBlock decodes a block stored in the gob layout
+func deserializeLegacyBlock(d []byte) (*Block, error) {
+	var bs legacyBlockStream
+	decoder := gob.NewDecoder(bytes.NewReader(d))
+	err := decoder.Decode(&bs)
+	if err != nil {
+		return nil, err
+	}
+	if bs.Header == nil {
+		return nil, errors.New("legacy block has no header")
+	}
+	return normalizeBlock(&Block{
+		header: &BlockHeader{
+			hash:      bs.Header.Hash,
+			prevHash:  bs.Header.PrevHash,
+			nonce:     bs.Header.Nonce,
+			timestamp: bs.Header.Timestamp,
+			sign:      bs.Header.Sign,
+			height:    bs.Header.Height,
+		},
+		transactions: bs.Transactions,
+	}), nil
+}
+
+// migrateToCanonicalEncoding splits a legacy UTXO blob into outpoint-keyed entries and re-encodes every stored block,
+// whether on the main chain or on a fork, in the canonical encoding. Blocks are stored under their hash, so a value is
+// only re-encoded if it decodes as a legacy block stored under the hash it carries.
+func migrateToCanonicalEncoding(db storage.Storage) error {
+	err := migrateLegacyUTXOIndex(db)
+	if err != nil {
+		return err
+	}
+
+	values, err := db.GetByPrefix(nil)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, rawBytes := range values {
+		block, err := deserializeLegacyBlock(rawBytes)
+		if err != nil || len(block.GetHash()) == 0 {
+			continue
+		}
+		stored, err := db.Get(block.GetHash())
+		if err != nil || !bytes.Equal(stored, rawBytes) {
+			continue
+		}
+
+		err = db.Put(block.GetHash(), block.Serialize())
+		if err != nil {
+			return err
+		}
+		migrated++
+	}
+	logger.Infof("Blockchain: migrated %d blocks to the canonical encoding", migrated)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/dappley/go-dappley/common"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// serializeLegacyBlock encodes a block in the gob layout used before dbVersionCanonicalEncoding
+func serializeLegacyBlock(b *Block) []byte {
+	var result bytes.Buffer
+	bs := &legacyBlockStream{
+		Header: &legacyBlockHeaderStream{
+			Hash:      b.header.hash,
+			PrevHash:  b.header.prevHash,
+			Nonce:     b.header.nonce,
+			Timestamp: b.header.timestamp,
+			Sign:      b.header.sign,
+			Height:    b.header.height,
+		},
+		Transactions: b.transactions,
+	}
+	gob.NewEncoder(&result).Encode(bs)
+	return result.Bytes()
+}
+
+func TestCreateBlockchain_SetsDbVersion(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	CreateBlockchain(addr, s, nil)
+
+	version, err := GetDbVersion(s)
+	assert.Nil(t, err)
+	assert.Equal(t, CurrentDbVersion, version)
+}
+
+func TestMigrateDb_CanonicalEncoding(t *testing.T) {
+	bc := GenerateMockBlockchainWithCoinbaseTxOnly(3)
+	s := bc.GetDb()
+	defer s.Close()
+
+	// Simulate a database written before the canonical encoding, with a fork block and a legacy UTXO blob
+	var blocks []*Block
+	bci := bc.Iterator()
+	for {
+		block, err := bci.Next()
+		assert.Nil(t, err)
+		blocks = append(blocks, block)
+		if len(block.GetPrevHash()) == 0 {
+			break
+		}
+	}
+	forkBlk := NewBlock(nil, blocks[len(blocks)-1])
+	forkBlk.SetHash(forkBlk.CalculateHash())
+	blocks = append(blocks, forkBlk)
+	for _, block := range blocks {
+		s.Put(block.GetHash(), serializeLegacyBlock(block))
+	}
+
+	legacyIndex := map[string][]*UTXO{
+		string(address1Hash): {{common.NewAmount(5), address1Hash, []byte{1}, 0, nil, 0, 0}},
+	}
+	var encoded bytes.Buffer
+	gob.NewEncoder(&encoded).Encode(legacyIndex)
+	s.Put([]byte(legacyUtxoMapKey), encoded.Bytes())
+	s.Del([]byte(dbVersionKey))
+
+	bc, err := GetBlockchain(s, nil)
+	assert.Nil(t, err)
+
+	version, err := GetDbVersion(s)
+	assert.Nil(t, err)
+	assert.Equal(t, CurrentDbVersion, version)
+
+	for _, block := range blocks {
+		migrated, err := bc.GetBlockByHash(block.GetHash())
+		assert.Nil(t, err)
+		assert.Equal(t, block, migrated)
+		assert.Equal(t, block.Serialize(), migrated.Serialize())
+	}
+
+	_, err = s.Get([]byte(legacyUtxoMapKey))
+	assert.Equal(t, storage.ErrKeyInvalid, err)
+	assert.Equal(t, 1, len(LoadUTXOIndex(s).GetUTXOsByPubKeyHash(address1Hash)))
+}
+
+func TestMigrateDb_NewerVersion(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	putDbVersion(s, CurrentDbVersion+1)
+	assert.Equal(t, ErrDbVersionNotSupported, MigrateDb(s))
+}
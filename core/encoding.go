@@ -15,18 +15,22 @@
 // You should have received a copy of the GNU General Public License
 // along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
 //
+
 package core
 
-type BlockHeaderStream struct {
-	Hash      Hash
-	PrevHash  Hash
-	Nonce     int64
-	Timestamp int64
-	Sign Hash
-	Height       uint64
-}
+import (
+	"github.com/gogo/protobuf/proto"
+)
 
-type BlockStream struct {
-	Header       *BlockHeaderStream
-	Transactions []*Transaction
+// marshalCanonical returns the canonical encoding of a message: its deterministic protobuf encoding as defined in
+// core/pb. Transactions, transaction inputs and outputs, and block headers are hashed, signed and stored in this
+// encoding, so that clients written in any language can reproduce it.
+func marshalCanonical(pb proto.Message) ([]byte, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	err := buf.Marshal(pb)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
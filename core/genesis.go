@@ -17,19 +17,49 @@
 //
 package core
 
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dappley/go-dappley/common"
+)
+
 const genesisCoinbaseData = "Hello world"
 
+// GenesisAllocation is a balance paid to an address by the genesis block
+type GenesisAllocation struct {
+	Address Address
+	Amount  *common.Amount
+}
 
-func NewGenesisBlock(address string) *Block {
+// NewGenesisBlock returns the genesis block paying the block subsidy to address and each allocation to its address.
+// Every node of a chain must use the same allocations, as they change the hash of the genesis block.
+func NewGenesisBlock(address string, allocations []GenesisAllocation) *Block {
 	//return consensus.ProduceBlock(address, genesisCoinbaseData,[]byte{})
 
-	txin := TXInput{nil, -1, nil, []byte(genesisCoinbaseData)}
+	txin := TXInput{nil, -1, nil, []byte(genesisCoinbaseData), nil}
 	txout := NewTXOutput(subsidy, address)
 	txs := []*Transaction{}
 	tx := Transaction{nil, []TXInput{txin}, []TXOutput{*txout}, 0}
 	tx.ID = tx.Hash()
 	txs = append(txs,&tx)
 
+	// Each allocation is paid by its own coinbase transaction, distinguished by its position in the list and signed
+	// with the genesis height like any coinbase transaction
+	bh := make([]byte, 8)
+	binary.BigEndian.PutUint64(bh, 0)
+	for i, allocation := range allocations {
+		data := fmt.Sprintf("Genesis allocation %d to '%s'", i, allocation.Address.Address)
+		allocationTx := Transaction{
+			nil,
+			[]TXInput{{nil, -1, bh, []byte(data), nil}},
+			[]TXOutput{*NewTXOutput(allocation.Amount, allocation.Address.Address)},
+			0,
+		}
+		allocationTx.ID = allocationTx.Hash()
+		txs = append(txs, &allocationTx)
+	}
+
 	header := &BlockHeader{
 		hash: []byte{0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0},
 		prevHash: []byte{},
@@ -41,6 +71,7 @@ func NewGenesisBlock(address string) *Block {
 		header: header,
 		transactions: txs,
 	}
+	b.header.merkleRoot = b.HashTransactions()
 
 	b.SetHash(b.CalculateHash())
 	return b
@@ -23,7 +23,11 @@ import (
 )
 
 type Consensus interface {
+	//Validate checks block against the consensus rules without changing the state of the consensus, so that a block
+	//can be validated again when it is received more than once
 	Validate(block *Block) bool
+	//RecordBlock updates the state of the consensus with a block added to the blockchain
+	RecordBlock(block *Block)
 	VerifyBlock(block *Block) bool
 	Start()
 	Stop()
@@ -47,5 +51,5 @@ type BlockPoolInterface interface {
 	BlockRequestCh() chan BlockRequestPars
 	GetBlockchain() *Blockchain
 	VerifyTransactions(utxo UTXOIndex, forkBlks []*Block) bool
-	Push(block *Block, pid peer.ID)
+	Push(block *Block, pid peer.ID) error
 }
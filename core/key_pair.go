@@ -43,6 +43,20 @@ func NewKeyPair() *KeyPair {
 	return &KeyPair{private, public}
 }
 
+// NewKeyPairFromHex returns the key pair of a secp256k1 private key encoded in hex
+func NewKeyPairFromHex(privKey string) (*KeyPair, error) {
+	private, err := secp256k1.HexToECDSAPrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := secp256k1.FromECDSAPublicKey(&private.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	//remove the uncompressed point at pubKey[0]
+	return &KeyPair{*private, pubKey[1:]}, nil
+}
+
 func (w KeyPair) GenerateAddress() Address {
 	return GenerateAddressByPublicKey(w.PublicKey)
 }
@@ -59,6 +59,16 @@ func TestNewKeyPair(t *testing.T) {
 
 }
 
+func TestNewKeyPairFromHex(t *testing.T) {
+	keyPair, err := NewKeyPairFromHex("bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e")
+	assert.Nil(t, err)
+	assert.Equal(t, 64, len(keyPair.PublicKey))
+	assert.Equal(t, Address{"1BpXBb3uunLa9PL8MmkMtKNd3jzb5DHFkG"}, keyPair.GenerateAddress())
+
+	_, err = NewKeyPairFromHex("not a key")
+	assert.NotNil(t, err)
+}
+
 func TestHashPubKey_fail(t *testing.T) {
 	content, err := HashPubKey(nil)
 	assert.Nil(t, content)
@@ -0,0 +1,215 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dappley/go-dappley/core/pb"
+	"github.com/dappley/go-dappley/crypto/hash"
+	"github.com/dappley/go-dappley/crypto/keystore/secp256k1"
+	"github.com/dappley/go-dappley/util"
+	"github.com/gogo/protobuf/proto"
+	logger "github.com/sirupsen/logrus"
+)
+
+// conditionVersion is the version byte of addresses encoding a LockCondition
+const conditionVersion = byte(0x05)
+
+// MaxLockConditionKeys is the maximum number of public key hashes a LockCondition can list
+const MaxLockConditionKeys = 16
+
+var (
+	ErrInvalidLockCondition = errors.New("ERROR: Lock condition is invalid")
+	ErrNotConditionAddress  = errors.New("ERROR: Address does not encode a lock condition")
+)
+
+// LockCondition locks a TXOutput to Required signatures out of the owners listed in PubKeyHashes. A single owner
+// with Required set to 1 is pay-to-pubkey-hash and more owners make an m-of-n multisig. The output can't be spent in a
+// block below LockHeight or timestamped before LockTime, nor until RelativeLockHeight blocks and RelativeLockTime
+// seconds have passed since the block that created it.
+type LockCondition struct {
+	PubKeyHashes       [][]byte
+	Required           uint32
+	LockHeight         uint64
+	LockTime           int64
+	RelativeLockHeight uint64
+	RelativeLockTime   int64
+}
+
+// Witness is a signature made by one of the owners of a TXOutput locked with a LockCondition
+type Witness struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// NewMultiSigCondition returns a LockCondition requiring required signatures from the owners of addresses
+func NewMultiSigCondition(required uint32, addresses []Address) (*LockCondition, error) {
+	condition := &LockCondition{Required: required}
+	for _, address := range addresses {
+		pubKeyHash, ok := address.GetPubKeyHash()
+		if !ok {
+			return nil, ErrInvalidLockCondition
+		}
+		condition.PubKeyHashes = append(condition.PubKeyHashes, pubKeyHash)
+	}
+	if err := condition.validate(); err != nil {
+		return nil, err
+	}
+	return condition, nil
+}
+
+// Hash returns the hash identifying the condition. Outputs locked with the condition are indexed under this hash in
+// place of a public key hash.
+func (c *LockCondition) Hash() []byte {
+	encoded, err := marshalCanonical(c.ToProto())
+	if err != nil {
+		logger.Panic(err)
+	}
+	return hash.Ripemd160(hash.Sha3256(encoded))
+}
+
+// GenerateAddress returns the address encoding the condition, so that outputs locked with it can be created by anyone
+// who knows the address
+func (c *LockCondition) GenerateAddress() Address {
+	encoded, err := marshalCanonical(c.ToProto())
+	if err != nil {
+		logger.Panic(err)
+	}
+	versionedPayload := append([]byte{conditionVersion}, encoded...)
+	fullPayload := append(versionedPayload, Checksum(versionedPayload)...)
+	return NewAddress(fmt.Sprintf("%s", util.Base58Encode(fullPayload)))
+}
+
+// validate checks that the condition can be satisfied
+func (c *LockCondition) validate() error {
+	if len(c.PubKeyHashes) == 0 || len(c.PubKeyHashes) > MaxLockConditionKeys {
+		return ErrInvalidLockCondition
+	}
+	if c.Required == 0 || int(c.Required) > len(c.PubKeyHashes) {
+		return ErrInvalidLockCondition
+	}
+	if c.LockTime < 0 || c.RelativeLockTime < 0 {
+		return ErrInvalidLockCondition
+	}
+	for i, pubKeyHash := range c.PubKeyHashes {
+		if len(pubKeyHash) == 0 {
+			return ErrInvalidLockCondition
+		}
+		for _, other := range c.PubKeyHashes[:i] {
+			if bytes.Equal(pubKeyHash, other) {
+				return ErrInvalidLockCondition
+			}
+		}
+	}
+	return nil
+}
+
+// isOwner returns true if pubKeyHash is one of the owners listed in the condition
+func (c *LockCondition) isOwner(pubKeyHash []byte) bool {
+	for _, owner := range c.PubKeyHashes {
+		if bytes.Equal(owner, pubKeyHash) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnlocked returns true if all timelocks of the condition have expired for an output created at createdHeight and
+// createdTime and spent in a block at blockHeight and blockTime
+func (c *LockCondition) isUnlocked(createdHeight uint64, createdTime int64, blockHeight uint64, blockTime int64) bool {
+	if blockHeight < c.LockHeight || blockTime < c.LockTime {
+		return false
+	}
+	if blockHeight < createdHeight+c.RelativeLockHeight || blockTime < createdTime+c.RelativeLockTime {
+		return false
+	}
+	return true
+}
+
+// verifyWitnesses returns true if the witnesses hold valid signatures of digest from at least Required distinct owners
+func (c *LockCondition) verifyWitnesses(digest []byte, witnesses []Witness) bool {
+	signed := make(map[string]bool)
+	for _, witness := range witnesses {
+		pubKeyHash, err := HashPubKey(witness.PubKey)
+		if err != nil || !c.isOwner(pubKeyHash) || signed[string(pubKeyHash)] {
+			return false
+		}
+
+		originPub := make([]byte, 1+len(witness.PubKey))
+		originPub[0] = 4 // uncompressed point
+		copy(originPub[1:], witness.PubKey)
+
+		verifyResult, err := secp256k1.Verify(digest, witness.Signature, originPub)
+		if err != nil || verifyResult == false {
+			logger.Errorf("Error: Verify witness failed %v", err)
+			return false
+		}
+		signed[string(pubKeyHash)] = true
+	}
+	return len(signed) >= int(c.Required)
+}
+
+func (c *LockCondition) ToProto() proto.Message {
+	return &corepb.LockCondition{
+		PubKeyHashes:       c.PubKeyHashes,
+		Required:           c.Required,
+		LockHeight:         c.LockHeight,
+		LockTime:           c.LockTime,
+		RelativeLockHeight: c.RelativeLockHeight,
+		RelativeLockTime:   c.RelativeLockTime,
+	}
+}
+
+func (c *LockCondition) FromProto(pb proto.Message) {
+	c.PubKeyHashes = pb.(*corepb.LockCondition).PubKeyHashes
+	c.Required = pb.(*corepb.LockCondition).Required
+	c.LockHeight = pb.(*corepb.LockCondition).LockHeight
+	c.LockTime = pb.(*corepb.LockCondition).LockTime
+	c.RelativeLockHeight = pb.(*corepb.LockCondition).RelativeLockHeight
+	c.RelativeLockTime = pb.(*corepb.LockCondition).RelativeLockTime
+}
+
+func (w *Witness) ToProto() proto.Message {
+	return &corepb.Witness{
+		PubKey:    w.PubKey,
+		Signature: w.Signature,
+	}
+}
+
+func (w *Witness) FromProto(pb proto.Message) {
+	w.PubKey = pb.(*corepb.Witness).PubKey
+	w.Signature = pb.(*corepb.Witness).Signature
+}
+
+// decodeConditionPayload decodes the LockCondition carried in the payload of a condition address
+func decodeConditionPayload(payload []byte) (*LockCondition, error) {
+	conditionpb := &corepb.LockCondition{}
+	if err := proto.Unmarshal(payload, conditionpb); err != nil {
+		return nil, ErrNotConditionAddress
+	}
+	condition := &LockCondition{}
+	condition.FromProto(conditionpb)
+	if err := condition.validate(); err != nil {
+		return nil, err
+	}
+	return condition, nil
+}
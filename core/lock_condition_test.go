@@ -0,0 +1,193 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dappley/go-dappley/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// prepareConditionSpend returns a transaction spending an output locked with condition that was created at height,
+// together with the index holding that output and the transaction that created it
+func prepareConditionSpend(condition *LockCondition, height uint64) (Transaction, UTXOIndex, map[string]Transaction) {
+	prevTx := Transaction{[]byte{1}, nil, []TXOutput{*NewConditionTXOutput(common.NewAmount(10), condition)}, 0}
+
+	utxoIndex := NewUTXOIndex()
+	u := newUTXO(prevTx.Vout[0], prevTx.ID, 0)
+	u.Height = height
+	utxoIndex.restoreUTXO(u)
+
+	tx := Transaction{
+		nil,
+		[]TXInput{{prevTx.ID, 0, nil, nil, nil}},
+		[]TXOutput{*NewTXOutput(common.NewAmount(10), "13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")},
+		0,
+	}
+	return tx, utxoIndex, map[string]Transaction{hex.EncodeToString(prevTx.ID): prevTx}
+}
+
+func TestLockCondition_Address(t *testing.T) {
+	keyPairs := []*KeyPair{NewKeyPair(), NewKeyPair(), NewKeyPair()}
+	var addresses []Address
+	for _, keyPair := range keyPairs {
+		addresses = append(addresses, keyPair.GenerateAddress())
+	}
+
+	condition, err := NewMultiSigCondition(2, addresses)
+	assert.Nil(t, err)
+	condition.LockHeight = 100
+
+	address := condition.GenerateAddress()
+	assert.True(t, address.ValidateAddress())
+
+	decoded, err := address.GetLockCondition()
+	assert.Nil(t, err)
+	assert.Equal(t, condition, decoded)
+
+	pubKeyHash, ok := address.GetPubKeyHash()
+	assert.True(t, ok)
+	assert.Equal(t, condition.Hash(), pubKeyHash)
+
+	// Paying to the address locks the output with the condition
+	txout := NewTXOutput(common.NewAmount(1), address.Address)
+	assert.Equal(t, condition, txout.Condition)
+	assert.Equal(t, condition.Hash(), txout.PubKeyHash)
+
+	_, err = addresses[0].GetLockCondition()
+	assert.Equal(t, ErrNotConditionAddress, err)
+}
+
+func TestNewMultiSigCondition_Invalid(t *testing.T) {
+	addresses := []Address{NewKeyPair().GenerateAddress(), NewKeyPair().GenerateAddress()}
+
+	_, err := NewMultiSigCondition(0, addresses)
+	assert.Equal(t, ErrInvalidLockCondition, err)
+	_, err = NewMultiSigCondition(3, addresses)
+	assert.Equal(t, ErrInvalidLockCondition, err)
+	_, err = NewMultiSigCondition(1, []Address{addresses[0], addresses[0]})
+	assert.Equal(t, ErrInvalidLockCondition, err)
+	_, err = NewMultiSigCondition(1, []Address{NewAddress("invalid")})
+	assert.Equal(t, ErrInvalidLockCondition, err)
+}
+
+func TestTransaction_VerifyMultiSig(t *testing.T) {
+	keyPairs := []*KeyPair{NewKeyPair(), NewKeyPair(), NewKeyPair()}
+	condition, err := NewMultiSigCondition(2, []Address{
+		keyPairs[0].GenerateAddress(),
+		keyPairs[1].GenerateAddress(),
+		keyPairs[2].GenerateAddress(),
+	})
+	assert.Nil(t, err)
+
+	tx, utxoIndex, prevTXs := prepareConditionSpend(condition, 0)
+
+	// A key that does not own the output adds no witness
+	assert.Nil(t, tx.Sign(NewKeyPair().PrivateKey, prevTXs))
+	assert.Len(t, tx.Vin[0].Witnesses, 0)
+
+	assert.Nil(t, tx.Sign(keyPairs[0].PrivateKey, prevTXs))
+	assert.False(t, tx.Verify(utxoIndex, 1, 0))
+
+	// Signing twice with the same key does not count twice
+	assert.Nil(t, tx.Sign(keyPairs[0].PrivateKey, prevTXs))
+	assert.Len(t, tx.Vin[0].Witnesses, 1)
+
+	assert.Nil(t, tx.Sign(keyPairs[2].PrivateKey, prevTXs))
+	assert.Len(t, tx.Vin[0].Witnesses, 2)
+	assert.True(t, tx.Verify(utxoIndex, 1, 0))
+
+	// Witnesses don't cover a modified transaction
+	tx.Vout[0].Value = common.NewAmount(9)
+	assert.False(t, tx.Verify(utxoIndex, 1, 0))
+}
+
+func TestTransaction_VerifyTimelocks(t *testing.T) {
+	keyPair := NewKeyPair()
+	pubKeyHash, _ := HashPubKey(keyPair.PublicKey)
+
+	tests := []struct {
+		name        string
+		condition   LockCondition
+		createdAt   uint64
+		blockHeight uint64
+		blockTime   int64
+		ok          bool
+	}{
+		{"pay to pubkey hash", LockCondition{Required: 1}, 0, 1, 0, true},
+		{"before lock height", LockCondition{Required: 1, LockHeight: 10}, 0, 9, 0, false},
+		{"at lock height", LockCondition{Required: 1, LockHeight: 10}, 0, 10, 0, true},
+		{"before lock time", LockCondition{Required: 1, LockTime: 1000}, 0, 1, 999, false},
+		{"at lock time", LockCondition{Required: 1, LockTime: 1000}, 0, 1, 1000, true},
+		{"before relative lock height", LockCondition{Required: 1, RelativeLockHeight: 5}, 3, 7, 0, false},
+		{"at relative lock height", LockCondition{Required: 1, RelativeLockHeight: 5}, 3, 8, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.condition
+			condition.PubKeyHashes = [][]byte{pubKeyHash}
+			tx, utxoIndex, prevTXs := prepareConditionSpend(&condition, tt.createdAt)
+
+			assert.Nil(t, tx.Sign(keyPair.PrivateKey, prevTXs))
+			assert.Equal(t, tt.ok, tx.Verify(utxoIndex, tt.blockHeight, tt.blockTime))
+		})
+	}
+}
+
+func TestTransaction_VerifyConditionOutput(t *testing.T) {
+	keyPair := NewKeyPair()
+	pubKeyHash, _ := HashPubKey(keyPair.PublicKey)
+	condition := &LockCondition{PubKeyHashes: [][]byte{pubKeyHash}, Required: 1}
+
+	tx, utxoIndex, prevTXs := prepareConditionSpend(condition, 0)
+	tx.Vout[0] = *NewConditionTXOutput(common.NewAmount(10), condition)
+	assert.Nil(t, tx.Sign(keyPair.PrivateKey, prevTXs))
+	assert.True(t, tx.Verify(utxoIndex, 1, 0))
+
+	// Outputs must be indexed under the hash of their condition
+	tx.Vout[0].PubKeyHash = pubKeyHash
+	tx.Vin[0].Witnesses = nil
+	assert.Nil(t, tx.Sign(keyPair.PrivateKey, prevTXs))
+	assert.False(t, tx.Verify(utxoIndex, 1, 0))
+
+	// Conditions that can never be satisfied are rejected
+	tx.Vout[0] = *NewConditionTXOutput(common.NewAmount(10), &LockCondition{PubKeyHashes: [][]byte{pubKeyHash}, Required: 2})
+	tx.Vin[0].Witnesses = nil
+	assert.Nil(t, tx.Sign(keyPair.PrivateKey, prevTXs))
+	assert.False(t, tx.Verify(utxoIndex, 1, 0))
+}
+
+func TestUTXOIndex_ApplyBlockRecordsCreationHeight(t *testing.T) {
+	keyPair := NewKeyPair()
+	pubKeyHash, _ := HashPubKey(keyPair.PublicKey)
+	condition := &LockCondition{PubKeyHashes: [][]byte{pubKeyHash}, Required: 1}
+	tx := Transaction{[]byte{1}, nil, []TXOutput{*NewConditionTXOutput(common.NewAmount(10), condition)}, 0}
+
+	blk := NewBlock([]*Transaction{&tx}, GenerateMockBlock())
+	utxoIndex := NewUTXOIndex()
+	utxoIndex.applyBlock(blk)
+
+	u := utxoIndex.FindUTXO(tx.ID, 0)
+	assert.NotNil(t, u)
+	assert.Equal(t, condition, u.Condition)
+	assert.Equal(t, blk.GetHeight(), u.Height)
+	assert.Equal(t, blk.GetTimestamp(), u.Timestamp)
+}
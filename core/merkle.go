@@ -0,0 +1,100 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+var (
+	ErrMerkleLeafNotFound = errors.New("ERROR: Merkle leaf index out of range")
+)
+
+// MerkleRoot returns the root of the Merkle tree built over leaves. Each level of the tree is built by hashing the
+// concatenation of adjacent nodes with sha256, pairing the last node with itself when a level has an odd number of
+// nodes. The root of a single leaf is the leaf itself.
+func MerkleRoot(leaves [][]byte) Hash {
+	if len(leaves) == 0 {
+		root := sha256.Sum256(nil)
+		return root[:]
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		level = nextMerkleLevel(level)
+	}
+	return level[0]
+}
+
+// MerkleBranch returns the sibling hashes linking the leaf at index to the root, starting from the bottom of the tree
+func MerkleBranch(leaves [][]byte, index int) ([][]byte, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, ErrMerkleLeafNotFound
+	}
+
+	var branch [][]byte
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling >= len(level) {
+			sibling = index
+		}
+		branch = append(branch, level[sibling])
+		level = nextMerkleLevel(level)
+		index /= 2
+	}
+	return branch, nil
+}
+
+// VerifyMerkleBranch returns true if the leaf at index is linked to root by branch
+func VerifyMerkleBranch(leaf []byte, index int, branch [][]byte, root Hash) bool {
+	if index < 0 {
+		return false
+	}
+
+	node := leaf
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			node = hashMerkleNodes(node, sibling)
+		} else {
+			node = hashMerkleNodes(sibling, node)
+		}
+		index /= 2
+	}
+	return index == 0 && bytes.Equal(node, root)
+}
+
+func nextMerkleLevel(level [][]byte) [][]byte {
+	var next [][]byte
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		next = append(next, hashMerkleNodes(level[i], right))
+	}
+	return next
+}
+
+func hashMerkleNodes(left, right []byte) []byte {
+	hash := sha256.Sum256(append(append([]byte{}, left...), right...))
+	return hash[:]
+}
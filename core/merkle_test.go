@@ -0,0 +1,120 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateMerkleLeaves(n int) [][]byte {
+	var leaves [][]byte
+	for i := 0; i < n; i++ {
+		leaf := sha256.Sum256([]byte{byte(i)})
+		leaves = append(leaves, leaf[:])
+	}
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	empty := sha256.Sum256(nil)
+	assert.Equal(t, Hash(empty[:]), MerkleRoot(nil))
+
+	leaves := generateMerkleLeaves(3)
+	assert.Equal(t, Hash(leaves[0]), MerkleRoot(leaves[:1]))
+
+	ab := hashMerkleNodes(leaves[0], leaves[1])
+	assert.Equal(t, Hash(ab), MerkleRoot(leaves[:2]))
+
+	cc := hashMerkleNodes(leaves[2], leaves[2])
+	assert.Equal(t, Hash(hashMerkleNodes(ab, cc)), MerkleRoot(leaves))
+}
+
+func TestMerkleBranch(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := generateMerkleLeaves(n)
+		root := MerkleRoot(leaves)
+		for i := 0; i < n; i++ {
+			branch, err := MerkleBranch(leaves, i)
+			assert.Nil(t, err)
+			assert.True(t, VerifyMerkleBranch(leaves[i], i, branch, root))
+
+			// the branch must not prove another leaf or position
+			if n > 1 {
+				assert.False(t, VerifyMerkleBranch(leaves[(i+1)%n], i, branch, root))
+			}
+			assert.False(t, VerifyMerkleBranch(leaves[i], i+len(leaves), branch, root))
+		}
+	}
+}
+
+func TestMerkleBranch_IndexOutOfRange(t *testing.T) {
+	leaves := generateMerkleLeaves(4)
+	_, err := MerkleBranch(leaves, 4)
+	assert.Equal(t, ErrMerkleLeafNotFound, err)
+	_, err = MerkleBranch(leaves, -1)
+	assert.Equal(t, ErrMerkleLeafNotFound, err)
+}
+
+func TestBlock_GetTransactionProof(t *testing.T) {
+	block := GenerateMockBlock()
+	block.transactions = append(block.transactions, MockTransaction())
+	for i, tx := range block.transactions {
+		tx.ID = []byte{byte(i)}
+	}
+	block.header.merkleRoot = block.HashTransactions()
+
+	for i, tx := range block.GetTransactions() {
+		index, branch, err := block.GetTransactionProof(tx.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, i, index)
+		assert.True(t, VerifyMerkleBranch(tx.Hash(), index, branch, block.GetMerkleRoot()))
+	}
+
+	_, _, err := block.GetTransactionProof([]byte("unknown"))
+	assert.Equal(t, ErrTransactionNotFound, err)
+}
+
+func TestBlock_VerifyHashChecksMerkleRoot(t *testing.T) {
+	block := NewBlock([]*Transaction{MockTransaction()}, nil)
+	block.SetHash(block.CalculateHash())
+	assert.True(t, block.VerifyHash())
+
+	block.transactions = append(block.transactions, MockTransaction())
+	assert.False(t, block.VerifyHash())
+}
+
+func TestBlock_VerifyHashRejectsDuplicateTransactions(t *testing.T) {
+	tx1, tx2, tx3 := MockTransaction(), MockTransaction(), MockTransaction()
+	tx1.ID, tx2.ID, tx3.ID = []byte{1}, []byte{2}, []byte{3}
+	block := NewBlock([]*Transaction{tx1, tx2, tx3}, nil)
+	block.SetHash(block.CalculateHash())
+	assert.True(t, block.VerifyHash())
+
+	// Repeating the last transaction of an odd level keeps the Merkle root unchanged
+	mutated := NewBlock([]*Transaction{tx1, tx2, tx3, tx3}, nil)
+	assert.Equal(t, block.GetMerkleRoot(), mutated.GetMerkleRoot())
+	mutated.header.timestamp = block.GetTimestamp()
+	mutated.SetHash(mutated.CalculateHash())
+	assert.Equal(t, block.GetHash(), mutated.GetHash())
+	assert.False(t, mutated.VerifyHash())
+	assert.False(t, mutated.VerifyTransactions(NewUTXOIndex()))
+}
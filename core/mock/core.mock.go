@@ -34,6 +34,16 @@ func (m *MockConsensus) EXPECT() *MockConsensusMockRecorder {
 	return m.recorder
 }
 
+// RecordBlock mocks base method
+func (m *MockConsensus) RecordBlock(arg0 *core.Block) {
+	m.ctrl.Call(m, "RecordBlock", arg0)
+}
+
+// RecordBlock indicates an expected call of RecordBlock
+func (mr *MockConsensusMockRecorder) RecordBlock(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBlock", reflect.TypeOf((*MockConsensus)(nil).RecordBlock), arg0)
+}
+
 // SetKey mocks base method
 func (m *MockConsensus) SetKey(arg0 string) {
 	m.ctrl.Call(m, "SetKey", arg0)
@@ -225,8 +235,10 @@ func (mr *MockBlockPoolInterfaceMockRecorder) GetBlockchain() *gomock.Call {
 }
 
 // Push mocks base method
-func (m *MockBlockPoolInterface) Push(arg0 *core.Block, arg1 go_libp2p_peer.ID) {
-	m.ctrl.Call(m, "Push", arg0, arg1)
+func (m *MockBlockPoolInterface) Push(arg0 *core.Block, arg1 go_libp2p_peer.ID) error {
+	ret := m.ctrl.Call(m, "Push", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Push indicates an expected call of Push
@@ -33,7 +33,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_c75dda9c93a7693f, []int{0}
+	return fileDescriptor_block_d48efbdb17ff616e, []int{0}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -81,6 +81,7 @@ type BlockHeader struct {
 	Timestamp            int64    `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,5,opt,name=Sign,proto3" json:"Sign,omitempty"`
 	Height               uint64   `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	MerkleRoot           []byte   `protobuf:"bytes,7,opt,name=MerkleRoot,proto3" json:"MerkleRoot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -90,7 +91,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_block_c75dda9c93a7693f, []int{1}
+	return fileDescriptor_block_d48efbdb17ff616e, []int{1}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -152,31 +153,40 @@ func (m *BlockHeader) GetHeight() uint64 {
 	return 0
 }
 
+func (m *BlockHeader) GetMerkleRoot() []byte {
+	if m != nil {
+		return m.MerkleRoot
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Block)(nil), "corepb.Block")
 	proto.RegisterType((*BlockHeader)(nil), "corepb.BlockHeader")
 }
 
 func init() {
-	proto.RegisterFile("github.com/dappley/go-dappley/core/pb/block.proto", fileDescriptor_block_c75dda9c93a7693f)
-}
-
-var fileDescriptor_block_c75dda9c93a7693f = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x41, 0x4b, 0xc3, 0x30,
-	0x14, 0xc7, 0xc9, 0xd6, 0x16, 0x7d, 0xdb, 0xe9, 0x29, 0x12, 0x86, 0x48, 0xd9, 0xa9, 0x20, 0xb6,
-	0xa8, 0x87, 0xdd, 0x3d, 0xed, 0x24, 0x12, 0xf6, 0x05, 0x92, 0xec, 0xd1, 0x14, 0xd7, 0x26, 0xa4,
-	0x51, 0xf0, 0x23, 0x78, 0xf0, 0x3b, 0x4b, 0xd3, 0xe9, 0xea, 0xcd, 0xdb, 0x7b, 0xbf, 0xff, 0xff,
-	0xfd, 0x02, 0x81, 0xfb, 0xba, 0x09, 0xe6, 0x4d, 0x95, 0xda, 0xb6, 0xd5, 0x5e, 0x3a, 0x77, 0xa0,
-	0x8f, 0xaa, 0xb6, 0x77, 0x3f, 0xa3, 0xb6, 0x9e, 0x2a, 0xa7, 0x2a, 0x75, 0xb0, 0xfa, 0xb5, 0x74,
-	0xde, 0x06, 0x8b, 0xd9, 0x00, 0x9d, 0x5a, 0x6d, 0xfe, 0x77, 0x1a, 0xbc, 0xec, 0x7a, 0xa9, 0x43,
-	0x63, 0xbb, 0x51, 0xb0, 0xfe, 0x62, 0x90, 0x3e, 0x0d, 0x42, 0xbc, 0x85, 0x6c, 0x4b, 0x72, 0x4f,
-	0x9e, 0xb3, 0x9c, 0x15, 0x8b, 0x87, 0x8b, 0x72, 0x74, 0x97, 0x31, 0x1e, 0x23, 0x71, 0xac, 0xe0,
-	0x06, 0x96, 0xbb, 0x93, 0xab, 0xe7, 0xb3, 0x7c, 0x3e, 0x3d, 0x99, 0x64, 0xe2, 0x4f, 0x11, 0x6f,
-	0x00, 0x9c, 0xf4, 0xd4, 0x85, 0xad, 0xec, 0x0d, 0x9f, 0xe7, 0xac, 0x58, 0x8a, 0x09, 0x59, 0x7f,
-	0x32, 0x58, 0x4c, 0x1e, 0x44, 0x84, 0x24, 0x36, 0x59, 0x6c, 0xc6, 0x19, 0x57, 0x70, 0xf6, 0xe2,
-	0xe9, 0xdd, 0x0c, 0x7c, 0x16, 0xf9, 0xef, 0x8e, 0x97, 0x90, 0x3e, 0xdb, 0x4e, 0x53, 0x54, 0xcf,
-	0xc5, 0xb8, 0xe0, 0x35, 0x9c, 0xef, 0x9a, 0x96, 0xfa, 0x20, 0x5b, 0xc7, 0x93, 0x98, 0x9c, 0x00,
-	0x5e, 0x41, 0x66, 0xa8, 0xa9, 0x4d, 0xe0, 0x69, 0xce, 0x8a, 0x44, 0x1c, 0x37, 0x95, 0xc5, 0x2f,
-	0x7a, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x52, 0xf5, 0x56, 0x5e, 0x98, 0x01, 0x00, 0x00,
+	proto.RegisterFile("github.com/dappley/go-dappley/core/pb/block.proto", fileDescriptor_block_d48efbdb17ff616e)
+}
+
+var fileDescriptor_block_d48efbdb17ff616e = []byte{
+	// 277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xc1, 0x4a, 0xc4, 0x30,
+	0x10, 0x86, 0xc9, 0x6e, 0x5b, 0x75, 0x76, 0x4f, 0x51, 0x24, 0x2c, 0x22, 0x65, 0x4f, 0x05, 0xb1,
+	0x45, 0x3d, 0xec, 0xdd, 0xd3, 0x5e, 0x14, 0x89, 0xfb, 0x02, 0x69, 0x37, 0xb4, 0x65, 0xdb, 0x4c,
+	0x48, 0xa3, 0xe0, 0x43, 0xf8, 0x48, 0xbe, 0x9b, 0x24, 0x59, 0x6d, 0xbd, 0x79, 0x9b, 0xf9, 0xfe,
+	0x7f, 0xfe, 0x19, 0x06, 0xee, 0xea, 0xd6, 0x36, 0x6f, 0x65, 0x5e, 0x61, 0x5f, 0xec, 0x85, 0xd6,
+	0x9d, 0xfc, 0x28, 0x6a, 0xbc, 0xfd, 0x29, 0x2b, 0x34, 0xb2, 0xd0, 0x65, 0x51, 0x76, 0x58, 0x1d,
+	0x72, 0x6d, 0xd0, 0x22, 0x4d, 0x1c, 0xd4, 0xe5, 0x6a, 0xf3, 0xbf, 0x51, 0x6b, 0x84, 0x1a, 0x44,
+	0x65, 0x5b, 0x54, 0x21, 0x60, 0xfd, 0x49, 0x20, 0x7e, 0x74, 0x81, 0xf4, 0x06, 0x92, 0xad, 0x14,
+	0x7b, 0x69, 0x18, 0x49, 0x49, 0xb6, 0xb8, 0x3f, 0xcf, 0x43, 0x76, 0xee, 0xe5, 0x20, 0xf1, 0xa3,
+	0x85, 0x6e, 0x60, 0xb9, 0x1b, 0xb3, 0x06, 0x36, 0x4b, 0xe7, 0xd3, 0x91, 0x89, 0xc6, 0xff, 0x18,
+	0xe9, 0x35, 0x80, 0x16, 0x46, 0x2a, 0xbb, 0x15, 0x43, 0xc3, 0xe6, 0x29, 0xc9, 0x96, 0x7c, 0x42,
+	0xd6, 0x5f, 0x04, 0x16, 0x93, 0x85, 0x94, 0x42, 0xe4, 0x9d, 0xc4, 0x3b, 0x7d, 0x4d, 0x57, 0x70,
+	0xfa, 0x62, 0xe4, 0x7b, 0xe3, 0xf8, 0xcc, 0xf3, 0xdf, 0x9e, 0x5e, 0x40, 0xfc, 0x8c, 0xaa, 0x92,
+	0x3e, 0x7a, 0xce, 0x43, 0x43, 0xaf, 0xe0, 0x6c, 0xd7, 0xf6, 0x72, 0xb0, 0xa2, 0xd7, 0x2c, 0xf2,
+	0xca, 0x08, 0xdc, 0x8e, 0xd7, 0xb6, 0x56, 0x2c, 0x0e, 0x3b, 0x5c, 0x4d, 0x2f, 0xdd, 0x37, 0xda,
+	0xba, 0xb1, 0x2c, 0x49, 0x49, 0x16, 0xf1, 0x63, 0xe7, 0xee, 0x7f, 0x92, 0xe6, 0xd0, 0x49, 0x8e,
+	0x68, 0xd9, 0x49, 0xb8, 0x7f, 0x24, 0x65, 0xe2, 0xdf, 0xfa, 0xf0, 0x3d, 0x00, 0xd9, 0x63, 0x53,
+	0xc5, 0xcc, 0x01, 0x00, 0x00,
 }
@@ -32,7 +32,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_1b8f31b70f4339c1, []int{0}
+	return fileDescriptor_transaction_2f5278607b5ce9b9, []int{0}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -81,20 +81,21 @@ func (m *Transaction) GetTip() uint64 {
 }
 
 type TXInput struct {
-	Txid                 []byte   `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
-	Vout                 int32    `protobuf:"varint,2,opt,name=Vout,proto3" json:"Vout,omitempty"`
-	Signature            []byte   `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
-	PubKey               []byte   `protobuf:"bytes,4,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Txid                 []byte     `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+	Vout                 int32      `protobuf:"varint,2,opt,name=Vout,proto3" json:"Vout,omitempty"`
+	Signature            []byte     `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	PubKey               []byte     `protobuf:"bytes,4,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	Witnesses            []*Witness `protobuf:"bytes,5,rep,name=Witnesses,proto3" json:"Witnesses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *TXInput) Reset()         { *m = TXInput{} }
 func (m *TXInput) String() string { return proto.CompactTextString(m) }
 func (*TXInput) ProtoMessage()    {}
 func (*TXInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_1b8f31b70f4339c1, []int{1}
+	return fileDescriptor_transaction_2f5278607b5ce9b9, []int{1}
 }
 func (m *TXInput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TXInput.Unmarshal(m, b)
@@ -142,19 +143,27 @@ func (m *TXInput) GetPubKey() []byte {
 	return nil
 }
 
+func (m *TXInput) GetWitnesses() []*Witness {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
+}
+
 type TXOutput struct {
-	Value                []byte   `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
-	PubKeyHash           []byte   `protobuf:"bytes,2,opt,name=PubKeyHash,proto3" json:"PubKeyHash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Value                []byte         `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	PubKeyHash           []byte         `protobuf:"bytes,2,opt,name=PubKeyHash,proto3" json:"PubKeyHash,omitempty"`
+	Condition            *LockCondition `protobuf:"bytes,3,opt,name=Condition,proto3" json:"Condition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *TXOutput) Reset()         { *m = TXOutput{} }
 func (m *TXOutput) String() string { return proto.CompactTextString(m) }
 func (*TXOutput) ProtoMessage()    {}
 func (*TXOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_1b8f31b70f4339c1, []int{2}
+	return fileDescriptor_transaction_2f5278607b5ce9b9, []int{2}
 }
 func (m *TXOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TXOutput.Unmarshal(m, b)
@@ -188,29 +197,172 @@ func (m *TXOutput) GetPubKeyHash() []byte {
 	return nil
 }
 
+func (m *TXOutput) GetCondition() *LockCondition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+type Witness struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Witness) Reset()         { *m = Witness{} }
+func (m *Witness) String() string { return proto.CompactTextString(m) }
+func (*Witness) ProtoMessage()    {}
+func (*Witness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_2f5278607b5ce9b9, []int{3}
+}
+func (m *Witness) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Witness.Unmarshal(m, b)
+}
+func (m *Witness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Witness.Marshal(b, m, deterministic)
+}
+func (dst *Witness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Witness.Merge(dst, src)
+}
+func (m *Witness) XXX_Size() int {
+	return xxx_messageInfo_Witness.Size(m)
+}
+func (m *Witness) XXX_DiscardUnknown() {
+	xxx_messageInfo_Witness.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Witness proto.InternalMessageInfo
+
+func (m *Witness) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *Witness) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type LockCondition struct {
+	PubKeyHashes         [][]byte `protobuf:"bytes,1,rep,name=PubKeyHashes,proto3" json:"PubKeyHashes,omitempty"`
+	Required             uint32   `protobuf:"varint,2,opt,name=Required,proto3" json:"Required,omitempty"`
+	LockHeight           uint64   `protobuf:"varint,3,opt,name=LockHeight,proto3" json:"LockHeight,omitempty"`
+	LockTime             int64    `protobuf:"varint,4,opt,name=LockTime,proto3" json:"LockTime,omitempty"`
+	RelativeLockHeight   uint64   `protobuf:"varint,5,opt,name=RelativeLockHeight,proto3" json:"RelativeLockHeight,omitempty"`
+	RelativeLockTime     int64    `protobuf:"varint,6,opt,name=RelativeLockTime,proto3" json:"RelativeLockTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockCondition) Reset()         { *m = LockCondition{} }
+func (m *LockCondition) String() string { return proto.CompactTextString(m) }
+func (*LockCondition) ProtoMessage()    {}
+func (*LockCondition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_2f5278607b5ce9b9, []int{4}
+}
+func (m *LockCondition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockCondition.Unmarshal(m, b)
+}
+func (m *LockCondition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockCondition.Marshal(b, m, deterministic)
+}
+func (dst *LockCondition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockCondition.Merge(dst, src)
+}
+func (m *LockCondition) XXX_Size() int {
+	return xxx_messageInfo_LockCondition.Size(m)
+}
+func (m *LockCondition) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockCondition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockCondition proto.InternalMessageInfo
+
+func (m *LockCondition) GetPubKeyHashes() [][]byte {
+	if m != nil {
+		return m.PubKeyHashes
+	}
+	return nil
+}
+
+func (m *LockCondition) GetRequired() uint32 {
+	if m != nil {
+		return m.Required
+	}
+	return 0
+}
+
+func (m *LockCondition) GetLockHeight() uint64 {
+	if m != nil {
+		return m.LockHeight
+	}
+	return 0
+}
+
+func (m *LockCondition) GetLockTime() int64 {
+	if m != nil {
+		return m.LockTime
+	}
+	return 0
+}
+
+func (m *LockCondition) GetRelativeLockHeight() uint64 {
+	if m != nil {
+		return m.RelativeLockHeight
+	}
+	return 0
+}
+
+func (m *LockCondition) GetRelativeLockTime() int64 {
+	if m != nil {
+		return m.RelativeLockTime
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Transaction)(nil), "corepb.Transaction")
 	proto.RegisterType((*TXInput)(nil), "corepb.TXInput")
 	proto.RegisterType((*TXOutput)(nil), "corepb.TXOutput")
+	proto.RegisterType((*Witness)(nil), "corepb.Witness")
+	proto.RegisterType((*LockCondition)(nil), "corepb.LockCondition")
 }
 
-func init() { proto.RegisterFile("transaction.proto", fileDescriptor_transaction_1b8f31b70f4339c1) }
-
-var fileDescriptor_transaction_1b8f31b70f4339c1 = []byte{
-	// 240 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x41, 0x4b, 0xc4, 0x30,
-	0x10, 0x85, 0x69, 0xd3, 0x56, 0x9d, 0x2d, 0xba, 0x0e, 0x22, 0x39, 0x88, 0xd4, 0xe2, 0xa1, 0xa7,
-	0x1e, 0xf4, 0x0f, 0x78, 0xd8, 0x83, 0xc5, 0x83, 0x12, 0x4b, 0xf1, 0x9a, 0xae, 0x65, 0x0d, 0x48,
-	0x52, 0xba, 0x09, 0xd4, 0x7f, 0x2f, 0x99, 0x46, 0xea, 0x6d, 0xe6, 0xbd, 0x2f, 0xef, 0x85, 0x81,
-	0x4b, 0x3b, 0x49, 0x7d, 0x94, 0x7b, 0xab, 0x8c, 0xae, 0xc7, 0xc9, 0x58, 0x83, 0xd9, 0xde, 0x4c,
-	0xc3, 0xd8, 0x97, 0x33, 0x6c, 0xda, 0xd5, 0xc4, 0x73, 0x88, 0x9b, 0x1d, 0x8f, 0x8a, 0xa8, 0xca,
-	0x45, 0xdc, 0xec, 0xf0, 0x0e, 0x58, 0xa7, 0x34, 0x8f, 0x0b, 0x56, 0x6d, 0x1e, 0x2e, 0xea, 0xe5,
-	0x51, 0xdd, 0x7e, 0x34, 0x7a, 0x74, 0x56, 0x78, 0x0f, 0xef, 0x21, 0xe9, 0x8c, 0xb3, 0x9c, 0x11,
-	0xb3, 0x5d, 0x99, 0x57, 0x67, 0x3d, 0x44, 0x2e, 0x6e, 0x81, 0xb5, 0x6a, 0xe4, 0x49, 0x11, 0x55,
-	0x89, 0xf0, 0x63, 0x79, 0x80, 0x93, 0x90, 0x83, 0x08, 0x49, 0x3b, 0xab, 0xcf, 0xd0, 0x4b, 0xb3,
-	0xd7, 0x28, 0x36, 0x2e, 0xa2, 0x2a, 0x0d, 0x21, 0x37, 0x70, 0xf6, 0xae, 0x0e, 0x5a, 0x5a, 0x37,
-	0x0d, 0x9c, 0x11, 0xbc, 0x0a, 0x78, 0x0d, 0xd9, 0x9b, 0xeb, 0x5f, 0x86, 0x1f, 0x6a, 0xc9, 0x45,
-	0xd8, 0xca, 0x27, 0x38, 0xfd, 0xfb, 0x0c, 0x5e, 0x41, 0xda, 0xc9, 0x6f, 0x37, 0x50, 0x55, 0x2a,
-	0x96, 0x05, 0x6f, 0x01, 0x16, 0xf6, 0x59, 0x1e, 0xbf, 0xa8, 0x31, 0x17, 0xff, 0x94, 0x3e, 0xa3,
-	0x9b, 0x3d, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff, 0x7a, 0xb4, 0x57, 0x3d, 0x48, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("transaction.proto", fileDescriptor_transaction_2f5278607b5ce9b9) }
+
+var fileDescriptor_transaction_2f5278607b5ce9b9 = []byte{
+	// 387 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xcd, 0x4e, 0xe3, 0x30,
+	0x18, 0x94, 0xf3, 0xd7, 0xf6, 0x6b, 0xba, 0xdb, 0xfd, 0xb4, 0xbb, 0x8a, 0x56, 0xab, 0x55, 0x36,
+	0xe2, 0x10, 0x21, 0x91, 0x43, 0xfb, 0x00, 0x1c, 0xe8, 0xa1, 0x15, 0x48, 0x20, 0x13, 0x15, 0xae,
+	0x69, 0x6b, 0xb5, 0x16, 0x25, 0x09, 0x89, 0x8d, 0xca, 0x8b, 0xf0, 0x8a, 0xbc, 0x06, 0xb2, 0x9b,
+	0x36, 0x09, 0x70, 0xf3, 0x37, 0x33, 0x9e, 0x6f, 0xc6, 0x32, 0xfc, 0x10, 0x45, 0x92, 0x96, 0xc9,
+	0x52, 0xf0, 0x2c, 0x8d, 0xf2, 0x22, 0x13, 0x19, 0x3a, 0xcb, 0xac, 0x60, 0xf9, 0x22, 0xd8, 0x41,
+	0x3f, 0xae, 0x49, 0xfc, 0x06, 0xc6, 0x6c, 0xe2, 0x11, 0x9f, 0x84, 0x2e, 0x35, 0x66, 0x13, 0xfc,
+	0x0f, 0xe6, 0x9c, 0xa7, 0x9e, 0xe1, 0x9b, 0x61, 0x7f, 0xf4, 0x3d, 0xda, 0x5f, 0x8a, 0xe2, 0xfb,
+	0x59, 0x9a, 0x4b, 0x41, 0x15, 0x87, 0x27, 0x60, 0xcd, 0x33, 0x29, 0x3c, 0x53, 0x6b, 0x86, 0xb5,
+	0xe6, 0x5a, 0x0a, 0x25, 0xd2, 0x2c, 0x0e, 0xc1, 0x8c, 0x79, 0xee, 0x59, 0x3e, 0x09, 0x2d, 0xaa,
+	0x8e, 0xc1, 0x2b, 0x81, 0x4e, 0x65, 0x84, 0x08, 0x56, 0xbc, 0xe3, 0xab, 0x6a, 0xb1, 0x3e, 0x2b,
+	0x4c, 0xfb, 0x1a, 0x3e, 0x09, 0xed, 0xca, 0xe5, 0x2f, 0xf4, 0x6e, 0xf9, 0x3a, 0x4d, 0x84, 0x2c,
+	0x98, 0x67, 0x6a, 0x71, 0x0d, 0xe0, 0x6f, 0x70, 0x6e, 0xe4, 0xe2, 0x92, 0xbd, 0xe8, 0x35, 0x2e,
+	0xad, 0x26, 0x3c, 0x83, 0xde, 0x1d, 0x17, 0x29, 0x2b, 0x4b, 0x56, 0x7a, 0x76, 0xbb, 0x4a, 0x45,
+	0xd0, 0x5a, 0x11, 0x48, 0xe8, 0x1e, 0xc2, 0xe3, 0x4f, 0xb0, 0xe7, 0xc9, 0x56, 0xb2, 0x2a, 0xd9,
+	0x7e, 0xc0, 0x7f, 0x00, 0x7b, 0xeb, 0x69, 0x52, 0x6e, 0x74, 0x40, 0x97, 0x36, 0x10, 0x1c, 0x43,
+	0xef, 0x22, 0x4b, 0x57, 0x5c, 0x3d, 0xa9, 0x8e, 0xd9, 0x1f, 0xfd, 0x3a, 0x2c, 0xbc, 0xca, 0x96,
+	0x0f, 0x47, 0x92, 0xd6, 0xba, 0xe0, 0x1c, 0x3a, 0x55, 0x86, 0x46, 0x11, 0xd2, 0x2a, 0xd2, 0xaa,
+	0x6f, 0x7c, 0xa8, 0x1f, 0xbc, 0x11, 0x18, 0xb4, 0xdc, 0x31, 0x00, 0xb7, 0x4e, 0xc5, 0x4a, 0x8f,
+	0xf8, 0x66, 0xe8, 0xd2, 0x16, 0x86, 0x7f, 0xa0, 0x4b, 0xd9, 0x93, 0xe4, 0x05, 0x5b, 0x69, 0xcb,
+	0x01, 0x3d, 0xce, 0xaa, 0xa7, 0x32, 0x9c, 0x32, 0xbe, 0xde, 0x08, 0x5d, 0xc4, 0xa2, 0x0d, 0x44,
+	0xdd, 0x55, 0x53, 0xcc, 0x1f, 0x99, 0x7e, 0x72, 0x93, 0x1e, 0x67, 0x8c, 0x00, 0x29, 0xdb, 0x26,
+	0x82, 0x3f, 0xb3, 0x86, 0x87, 0xad, 0x3d, 0xbe, 0x60, 0xf0, 0x14, 0x86, 0x4d, 0x54, 0x7b, 0x3a,
+	0xda, 0xf3, 0x13, 0xbe, 0x70, 0xf4, 0x1f, 0x1e, 0xbf, 0x0f, 0x00, 0x33, 0xe1, 0xbd, 0x29, 0xd8,
+	0x02, 0x00, 0x00,
 }
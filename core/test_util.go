@@ -35,15 +35,18 @@ func GenerateMockBlock() *Block {
 		time.Now().Unix(),
 		nil,
 		0,
+		nil,
 	}
 
 	t1 := MockTransaction()
 	t2 := MockTransaction()
 
-	return &Block{
+	b := &Block{
 		header:       bh1,
 		transactions: []*Transaction{t1, t2},
 	}
+	b.header.merkleRoot = b.HashTransactions()
+	return b
 }
 
 func FakeNewBlockWithTimestamp(t int64, transactions []*Transaction, parent *Block) *Block {
@@ -58,7 +61,7 @@ func FakeNewBlockWithTimestamp(t int64, transactions []*Transaction, parent *Blo
 	if transactions == nil {
 		transactions = []*Transaction{}
 	}
-	return &Block{
+	b := &Block{
 		header: &BlockHeader{
 			hash:      []byte{},
 			prevHash:  prevHash,
@@ -69,6 +72,8 @@ func FakeNewBlockWithTimestamp(t int64, transactions []*Transaction, parent *Blo
 		},
 		transactions: transactions,
 	}
+	b.header.merkleRoot = b.HashTransactions()
+	return b
 }
 
 func GenerateMockBlockchain(size int) *Blockchain {
@@ -94,7 +99,7 @@ func GenerateMockBlockchainWithCoinbaseTxOnlyWithConsensus(size int, consensus C
 
 	for i := 0; i < size; i++ {
 		tailBlk, _ := bc.GetTailBlock()
-		cbtx := NewCoinbaseTX(addr.Address, "", bc.GetMaxHeight())
+		cbtx := NewCoinbaseTX(addr.Address, "", bc.GetMaxHeight(), 0)
 		b := NewBlock([]*Transaction{&cbtx}, tailBlk)
 		b.SetHash(b.CalculateHash())
 		bc.AddBlockToTail(b)
@@ -110,7 +115,7 @@ func GenerateMockBlockchainWithCoinbaseTxOnly(size int) *Blockchain {
 
 	for i := 0; i < size; i++ {
 		tailBlk, _ := bc.GetTailBlock()
-		cbtx := NewCoinbaseTX(addr.Address, "", bc.GetMaxHeight())
+		cbtx := NewCoinbaseTX(addr.Address, "", bc.GetMaxHeight(), 0)
 		b := NewBlock([]*Transaction{&cbtx}, tailBlk)
 		b.SetHash(b.CalculateHash())
 		bc.AddBlockToTail(b)
@@ -151,11 +156,13 @@ func MockTxInputs() []TXInput {
 		{util.GenerateRandomAoB(2),
 			6,
 			util.GenerateRandomAoB(2),
-			util.GenerateRandomAoB(2)},
+			util.GenerateRandomAoB(2),
+			nil},
 		{util.GenerateRandomAoB(2),
 			2,
 			util.GenerateRandomAoB(2),
-			util.GenerateRandomAoB(2)},
+			util.GenerateRandomAoB(2),
+			nil},
 	}
 }
 
@@ -164,23 +171,25 @@ func MockTxInputsWithPubkey(pubkey []byte) []TXInput {
 		{util.GenerateRandomAoB(2),
 			6,
 			util.GenerateRandomAoB(2),
-			pubkey},
+			pubkey,
+			nil},
 		{util.GenerateRandomAoB(2),
 			2,
 			util.GenerateRandomAoB(2),
-			pubkey},
+			pubkey,
+			nil},
 	}
 }
 
 func MockTxOutputs() []TXOutput {
 	return []TXOutput{
-		{common.NewAmount(5), util.GenerateRandomAoB(2)},
-		{common.NewAmount(7), util.GenerateRandomAoB(2)},
+		{common.NewAmount(5), util.GenerateRandomAoB(2), nil},
+		{common.NewAmount(7), util.GenerateRandomAoB(2), nil},
 	}
 }
 
 func GenerateMockTransactionPool(numOfTxs int) *TransactionPool {
-	txPool := &TransactionPool{}
+	txPool := NewTransactionPool()
 	for i := 0; i < numOfTxs; i++ {
 		txPool.Push(*MockTransaction())
 	}
@@ -23,7 +23,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/binary"
-	"encoding/gob"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -34,15 +33,16 @@ import (
 	"github.com/gogo/protobuf/proto"
 	logger "github.com/sirupsen/logrus"
 	"strings"
+	"time"
 )
 
 var subsidy = common.NewAmount(10)
-var enableAddBalanceTest = true
 
 var (
 	ErrInsufficientFund = errors.New("transaction: the balance is insufficient")
 	ErrInvalidAmount    = errors.New("transaction: amount is invalid (must be > 0)")
 	ErrTXInputNotFound  = errors.New("transaction: transaction input not found")
+	ErrFeeOverflow      = errors.New("transaction: total fee overflows")
 )
 
 type Transaction struct {
@@ -52,26 +52,35 @@ type Transaction struct {
 	Tip  uint64
 }
 
-type TxIndex struct {
-	BlockId    []byte
-	BlockIndex int
-}
-
 func (tx Transaction) IsCoinbase() bool {
 	return len(tx.Vin) == 1 && len(tx.Vin[0].Txid) == 0 && tx.Vin[0].Vout == -1 && len(tx.Vout) == 1
 }
 
-// Serialize returns a serialized Transaction
+// Serialize returns the canonical encoding of a Transaction
 func (tx Transaction) Serialize() []byte {
-	var encoded bytes.Buffer
-
-	enc := gob.NewEncoder(&encoded)
-	err := enc.Encode(tx)
+	encoded, err := marshalCanonical(tx.ToProto())
 	if err != nil {
 		logger.Panic(err)
 	}
 
-	return encoded.Bytes()
+	return encoded
+}
+
+// GetSize returns the size in bytes of the canonical encoding of a Transaction
+func (tx Transaction) GetSize() int {
+	return len(tx.Serialize())
+}
+
+// DeserializeTransaction decodes a Transaction from its canonical encoding
+func DeserializeTransaction(d []byte) (Transaction, error) {
+	txpb := &corepb.Transaction{}
+	err := proto.Unmarshal(d, txpb)
+	if err != nil {
+		return Transaction{}, err
+	}
+	tx := Transaction{}
+	tx.FromProto(txpb)
+	return tx, nil
 }
 
 // Hash returns the hash of the Transaction
@@ -86,7 +95,8 @@ func (tx *Transaction) Hash() []byte {
 	return hash[:]
 }
 
-// Sign signs each input of a Transaction
+// Sign signs each input of a Transaction. Inputs spending outputs locked with a LockCondition receive a Witness from
+// privKey if it belongs to one of the owners of the output, so each owner of a multisig output signs in turn.
 func (tx *Transaction) Sign(privKey ecdsa.PrivateKey, prevTXs map[string]Transaction) error {
 	if tx.IsCoinbase() {
 		logger.Warning("Coinbase transaction could not be signed")
@@ -104,43 +114,64 @@ func (tx *Transaction) Sign(privKey ecdsa.PrivateKey, prevTXs map[string]Transac
 		}
 	}
 
+	privData, err := secp256k1.FromECDSAPrivateKey(&privKey)
+	if err != nil {
+		logger.Error("ERROR: Get private key failed", err)
+		return err
+	}
+	pubKey, err := secp256k1.FromECDSAPublicKey(&privKey.PublicKey)
+	if err != nil {
+		logger.Error("ERROR: Get public key failed", err)
+		return err
+	}
+	//remove the uncompressed point at pubKey[0]
+	pubKey = pubKey[1:]
+	pubKeyHash, _ := HashPubKey(pubKey)
+
 	txCopy := tx.TrimmedCopy()
 
 	for inID, vin := range txCopy.Vin {
-		prevTx := prevTXs[hex.EncodeToString(vin.Txid)]
-		txCopy.Vin[inID].Signature = nil
-		txCopy.Vin[inID].PubKey = prevTx.Vout[vin.Vout].PubKeyHash
-		txCopy.ID = txCopy.Hash()
-		txCopy.Vin[inID].PubKey = nil
-
-		privData, err := secp256k1.FromECDSAPrivateKey(&privKey)
-		if err != nil {
-			logger.Error("ERROR: Get private key failed", err)
-			return err
+		prevOut := prevTXs[hex.EncodeToString(vin.Txid)].Vout[vin.Vout]
+		if prevOut.Condition != nil && (!prevOut.Condition.isOwner(pubKeyHash) || tx.Vin[inID].hasWitness(pubKeyHash)) {
+			continue
 		}
 
-		signature, err := secp256k1.Sign(txCopy.ID, privData)
+		signature, err := secp256k1.Sign(txCopy.signatureHash(inID, prevOut.PubKeyHash), privData)
 		if err != nil {
 			logger.Error("ERROR: Sign transaction.Id failed", err)
 			return err
 		}
 
-		tx.Vin[inID].Signature = signature
+		if prevOut.Condition != nil {
+			tx.Vin[inID].Witnesses = append(tx.Vin[inID].Witnesses, Witness{pubKey, signature})
+		} else {
+			tx.Vin[inID].Signature = signature
+		}
 	}
 	return nil
 }
 
+// signatureHash returns the hash signed by the owners of the output spent by the input at inID of a trimmed copy of
+// a Transaction
+func (tx *Transaction) signatureHash(inID int, prevPubKeyHash []byte) []byte {
+	tx.Vin[inID].Signature = nil
+	tx.Vin[inID].PubKey = prevPubKeyHash
+	tx.ID = tx.Hash()
+	tx.Vin[inID].PubKey = nil
+	return tx.ID
+}
+
 // TrimmedCopy creates a trimmed copy of Transaction to be used in signing
 func (tx *Transaction) TrimmedCopy() Transaction {
 	var inputs []TXInput
 	var outputs []TXOutput
 
 	for _, vin := range tx.Vin {
-		inputs = append(inputs, TXInput{vin.Txid, vin.Vout, nil, nil})
+		inputs = append(inputs, TXInput{vin.Txid, vin.Vout, nil, nil, nil})
 	}
 
 	for _, vout := range tx.Vout {
-		outputs = append(outputs, TXOutput{vout.Value, vout.PubKeyHash})
+		outputs = append(outputs, TXOutput{vout.Value, vout.PubKeyHash, vout.Condition})
 	}
 
 	txCopy := Transaction{tx.ID, inputs, outputs, tx.Tip}
@@ -148,11 +179,18 @@ func (tx *Transaction) TrimmedCopy() Transaction {
 	return txCopy
 }
 
-// Verify ensures signature of transactions is correct or verifies against blockHeight if it's a coinbase transactions
-func (tx *Transaction) Verify(utxo UTXOIndex, blockHeight uint64) bool {
+// Verify ensures signature of transactions is correct or verifies against blockHeight if it's a coinbase transactions.
+// The lock conditions of the outputs spent are evaluated against a block at blockHeight timestamped blockTime. A
+// non-coinbase transaction must pay exactly its Tip as fee, and a coinbase transaction must pay out the subsidy plus
+// the fees it claims in its Tip.
+func (tx *Transaction) Verify(utxo UTXOIndex, blockHeight uint64, blockTime int64) bool {
 
 	if tx.IsCoinbase() {
-		if tx.Vout[0].Value.Cmp(subsidy) != 0 {
+		if tx.Vout[0].Value.Cmp(subsidy.Add(common.NewAmount(tx.Tip))) != 0 {
+			return false
+		}
+		// The height is encoded in the first 8 bytes of the coinbase signature
+		if len(tx.Vin[0].Signature) < 8 {
 			return false
 		}
 		bh := binary.BigEndian.Uint64(tx.Vin[0].Signature)
@@ -162,24 +200,41 @@ func (tx *Transaction) Verify(utxo UTXOIndex, blockHeight uint64) bool {
 		return true
 	}
 
+	if !tx.verifyOutputs() {
+		logger.Error("ERROR: Transaction output lock condition is invalid")
+		return false
+	}
+
 	prevUtxos, err := tx.FindAllTxinsInUtxoPool(utxo)
 	if err != nil {
 		logger.Errorf("ERROR: %v", err)
 		return false
 	}
 
-	//TODO  Remove the enableAddBalanceTest flag
-	if !enableAddBalanceTest && tx.verifyAmount(prevUtxos) == false {
+	if tx.verifyAmount(prevUtxos) == false {
 		logger.Error("ERROR: Transaction amount is invalid")
 		return false
 	}
 
-	return tx.verifySignatures(prevUtxos)
+	return tx.verifySignatures(prevUtxos, blockHeight, blockTime)
+}
+
+// verifyOutputs checks that the outputs locked with a LockCondition are satisfiable and indexed under its hash
+func (tx *Transaction) verifyOutputs() bool {
+	for _, vout := range tx.Vout {
+		if vout.Condition == nil {
+			continue
+		}
+		if vout.Condition.validate() != nil || !bytes.Equal(vout.PubKeyHash, vout.Condition.Hash()) {
+			return false
+		}
+	}
+	return true
 }
 
-func (tx *Transaction) verifySignatures(prevUtxos map[string]TXOutput) bool {
+func (tx *Transaction) verifySignatures(prevUtxos map[string]*UTXO, blockHeight uint64, blockTime int64) bool {
 	for _, vin := range tx.Vin {
-		if prevUtxos[hex.EncodeToString(vin.Txid)].PubKeyHash == nil {
+		if prevUtxos[getOutpointKey(vin.Txid, vin.Vout)].PubKeyHash == nil {
 			logger.Error("ERROR: Previous transaction is not correct")
 			return false
 		}
@@ -188,18 +243,26 @@ func (tx *Transaction) verifySignatures(prevUtxos map[string]TXOutput) bool {
 	txCopy := tx.TrimmedCopy()
 
 	for inID, vin := range tx.Vin {
-		prevTxOut := prevUtxos[hex.EncodeToString(vin.Txid)]
-
-		txCopy.Vin[inID].Signature = nil
-		txCopy.Vin[inID].PubKey = prevTxOut.PubKeyHash
-		txCopy.ID = txCopy.Hash()
-		txCopy.Vin[inID].PubKey = nil
+		prevUtxo := prevUtxos[getOutpointKey(vin.Txid, vin.Vout)]
+		digest := txCopy.signatureHash(inID, prevUtxo.PubKeyHash)
+
+		if prevUtxo.Condition != nil {
+			if !prevUtxo.Condition.isUnlocked(prevUtxo.Height, prevUtxo.Timestamp, blockHeight, blockTime) {
+				logger.Error("Error: Previous transaction output is still time locked")
+				return false
+			}
+			if !prevUtxo.Condition.verifyWitnesses(digest, vin.Witnesses) {
+				logger.Error("Error: Lock condition of previous transaction output is not satisfied")
+				return false
+			}
+			continue
+		}
 
 		originPub := make([]byte, 1+len(vin.PubKey))
 		originPub[0] = 4 // uncompressed point
 		copy(originPub[1:], vin.PubKey)
 
-		verifyResult, error1 := secp256k1.Verify(txCopy.ID, vin.Signature, originPub)
+		verifyResult, error1 := secp256k1.Verify(digest, vin.Signature, originPub)
 
 		if error1 != nil || verifyResult == false {
 			logger.Errorf("Error: Verify sign failed %v", error1)
@@ -210,31 +273,58 @@ func (tx *Transaction) verifySignatures(prevUtxos map[string]TXOutput) bool {
 	return true
 }
 
-func (tx *Transaction) verifyAmount(prevTXs map[string]TXOutput) bool {
-	var totalVin, totalVout common.Amount
+// verifyAmount checks that the inputs cover the outputs and that the fee left over equals the Tip of the transaction
+func (tx *Transaction) verifyAmount(prevTXs map[string]*UTXO) bool {
+	fee, err := tx.calculateFee(prevTXs)
+	if err != nil {
+		return false
+	}
+	return fee.Cmp(common.NewAmount(tx.Tip)) == 0
+}
+
+// calculateFee returns the fee of the transaction, which is the value of the inputs minus the value of the outputs
+func (tx *Transaction) calculateFee(prevTXs map[string]*UTXO) (*common.Amount, error) {
+	totalVin := common.NewAmount(0)
 	for _, utxo := range prevTXs {
-		totalVin = *totalVin.Add(utxo.Value)
+		totalVin = totalVin.Add(utxo.Value)
 	}
 
+	totalVout := common.NewAmount(0)
 	for _, vout := range tx.Vout {
-		totalVout = *totalVout.Add(vout.Value)
+		totalVout = totalVout.Add(vout.Value)
 	}
 
 	//TotalVin amount must equal or greater than total vout
-	return totalVin.Cmp(&totalVout) >= 0
+	return totalVin.Sub(totalVout)
 }
 
-// NewCoinbaseTX creates a new coinbase transaction
-func NewCoinbaseTX(to, data string, blockHeight uint64) Transaction {
+// CalculateTotalTips returns the sum of the tips of the non-coinbase transactions in txs, which is the total fee a
+// coinbase transaction may claim
+func CalculateTotalTips(txs []*Transaction) (uint64, error) {
+	var total uint64
+	for _, tx := range txs {
+		if tx.IsCoinbase() {
+			continue
+		}
+		if total+tx.Tip < total {
+			return 0, ErrFeeOverflow
+		}
+		total += tx.Tip
+	}
+	return total, nil
+}
+
+// NewCoinbaseTX creates a new coinbase transaction paying the subsidy and the fees of the block to to
+func NewCoinbaseTX(to, data string, blockHeight uint64, fees uint64) Transaction {
 	if data == "" {
 		data = fmt.Sprintf("Reward to '%s'", to)
 	}
 	bh := make([]byte, 8)
 	binary.BigEndian.PutUint64(bh, uint64(blockHeight))
 
-	txin := TXInput{nil, -1, bh, []byte(data)}
-	txout := NewTXOutput(subsidy, to)
-	tx := Transaction{nil, []TXInput{txin}, []TXOutput{*txout}, 0}
+	txin := TXInput{nil, -1, bh, []byte(data), nil}
+	txout := NewTXOutput(subsidy.Add(common.NewAmount(fees)), to)
+	tx := Transaction{nil, []TXInput{txin}, []TXOutput{*txout}, fees}
 	tx.ID = tx.Hash()
 
 	return tx
@@ -248,7 +338,11 @@ func NewUTXOTransaction(db storage.Storage, from, to Address, amount *common.Amo
 
 	pubKeyHash, _ := HashPubKey(senderKeyPair.PublicKey)
 	sum := common.NewAmount(0)
-	senderUTXOs := LoadUTXOIndex(db).GetUTXOsByPubKeyHash(pubKeyHash)
+	// The inputs must cover the amount and the tip, which is left to the block producer as fee
+	total := amount.Add(common.NewAmount(tip))
+	// The outputs of pooled transactions can be spent before they are included in a block
+	utxoIndex := bc.GetTxPool().GetPendingUTXOIndex(LoadUTXOIndex(db), bc.GetMaxHeight()+1, time.Now().Unix())
+	senderUTXOs := utxoIndex.GetUTXOsByPubKeyHash(pubKeyHash)
 
 	if len(senderUTXOs) < 1 {
 		return Transaction{}, ErrInsufficientFund
@@ -256,25 +350,25 @@ func NewUTXOTransaction(db storage.Storage, from, to Address, amount *common.Amo
 	for _, v := range senderUTXOs {
 		sum = sum.Add(v.Value)
 		validOutputs = append(validOutputs, v)
-		if sum.Cmp(amount) >= 0 {
+		if sum.Cmp(total) >= 0 {
 			break
 		}
 	}
 
-	if sum.Cmp(amount) < 0 { // TODO: add tips
+	if sum.Cmp(total) < 0 {
 		return Transaction{}, ErrInsufficientFund
 	}
 
 	// Build a list of inputs
 	for _, out := range validOutputs {
-		input := TXInput{out.Txid, out.TxIndex, nil, senderKeyPair.PublicKey}
+		input := TXInput{out.Txid, out.TxIndex, nil, senderKeyPair.PublicKey, nil}
 		inputs = append(inputs, input)
 
 	}
 	// Build a list of outputs
 	outputs = append(outputs, *NewTXOutput(amount, to.Address))
-	if sum.Cmp(amount) > 0 {
-		change, err := sum.Sub(amount)
+	if sum.Cmp(total) > 0 {
+		change, err := sum.Sub(total)
 		if err != nil {
 			logger.Panic(err)
 		}
@@ -299,46 +393,34 @@ func (tx *Transaction) GetPrevTransactions(bc *Blockchain) map[string]Transactio
 	for _, vin := range tx.Vin {
 		prevTX, err := bc.FindTransaction(vin.Txid)
 		if err != nil {
-			logger.Panic(err)
+			pooledTX := bc.GetTxPool().GetTransaction(vin.Txid)
+			if pooledTX == nil {
+				logger.Panic(err)
+			}
+			prevTX = *pooledTX
 		}
 		prevTXs[hex.EncodeToString(prevTX.ID)] = prevTX
 	}
 	return prevTXs
 }
 
-//for add balance
-func NewUTXOTransactionforAddBalance(to Address, amount *common.Amount) (Transaction, error) {
-	var inputs []TXInput
-	var outputs []TXOutput
-
-	// Validate amount
-	if amount.Validate() != nil || amount.IsZero() {
-		return Transaction{}, ErrInvalidAmount
-	}
-
-	// Build a list of outputs
-	outputs = append(outputs, *NewTXOutput(amount, to.Address))
-
-	tx := Transaction{nil, inputs, outputs, 0}
-	tx.ID = tx.Hash()
-
-	return tx, nil
-}
-
-//FindAllTxinsInUtxoPool Find the transaction in a utxo pool. Returns true only if all Vins are found in the utxo pool
-func (tx *Transaction) FindAllTxinsInUtxoPool(utxoPool UTXOIndex) (map[string]TXOutput, error) {
-	res := make(map[string]TXOutput)
+//FindAllTxinsInUtxoPool Find the transaction in a utxo pool. Returns true only if all Vins are found in the utxo pool.
+//The UTXOs found are keyed by outpoint.
+func (tx *Transaction) FindAllTxinsInUtxoPool(utxoPool UTXOIndex) (map[string]*UTXO, error) {
+	res := make(map[string]*UTXO)
 	for _, vin := range tx.Vin {
-		pubKeyHash, err := HashPubKey(vin.PubKey)
-		if err != nil {
-			return nil, ErrTXInputNotFound
-		}
-		utxo := utxoPool.FindUTXOByVin(pubKeyHash, vin.Txid, vin.Vout)
+		utxo := utxoPool.FindUTXO(vin.Txid, vin.Vout)
 		if utxo == nil {
 			return nil, ErrTXInputNotFound
 		}
-		txout := TXOutput{utxo.Value, utxo.PubKeyHash}
-		res[hex.EncodeToString(vin.Txid)] = txout
+		// Outputs locked with a condition are claimed by witnesses rather than by the public key of the input
+		if utxo.Condition == nil {
+			pubKeyHash, err := HashPubKey(vin.PubKey)
+			if err != nil || !bytes.Equal(utxo.PubKeyHash, pubKeyHash) {
+				return nil, ErrTXInputNotFound
+			}
+		}
+		res[getOutpointKey(vin.Txid, vin.Vout)] = utxo
 	}
 	return res, nil
 }
@@ -356,12 +438,18 @@ func (tx Transaction) String() string {
 		lines = append(lines, fmt.Sprintf("       Out:       %d", input.Vout))
 		lines = append(lines, fmt.Sprintf("       Signature: %x", input.Signature))
 		lines = append(lines, fmt.Sprintf("       PubKey:    %x", input.PubKey))
+		for _, witness := range input.Witnesses {
+			lines = append(lines, fmt.Sprintf("       Witness:   %x %x", witness.PubKey, witness.Signature))
+		}
 	}
 
 	for i, output := range tx.Vout {
 		lines = append(lines, fmt.Sprintf("     Output %d:", i))
 		lines = append(lines, fmt.Sprintf("       Value:  %d", output.Value))
 		lines = append(lines, fmt.Sprintf("       Script: %x", output.PubKeyHash))
+		if output.Condition != nil {
+			lines = append(lines, fmt.Sprintf("       Condition: %d of %x", output.Condition.Required, output.Condition.PubKeyHashes))
+		}
 	}
 	lines = append(lines, "\n")
 
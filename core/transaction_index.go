@@ -0,0 +1,88 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/dappley/go-dappley/storage"
+	logger "github.com/sirupsen/logrus"
+)
+
+const txIndexKeyPrefix = "txIndex"
+
+// TxIndex records where a transaction is stored in the blockchain.
+type TxIndex struct {
+	BlockId    []byte
+	BlockIndex int
+}
+
+func getTxIndexKey(txid []byte) []byte {
+	return append([]byte(txIndexKeyPrefix), txid...)
+}
+
+func (txIndex TxIndex) serialize() []byte {
+	var encoded bytes.Buffer
+	enc := gob.NewEncoder(&encoded)
+	err := enc.Encode(txIndex)
+	if err != nil {
+		logger.Panic(err)
+	}
+	return encoded.Bytes()
+}
+
+func deserializeTxIndex(d []byte) (TxIndex, error) {
+	var txIndex TxIndex
+	decoder := gob.NewDecoder(bytes.NewReader(d))
+	err := decoder.Decode(&txIndex)
+	return txIndex, err
+}
+
+// GetTxIndex returns the location of the transaction identified by txid in the blockchain stored in db
+func GetTxIndex(db storage.Storage, txid []byte) (TxIndex, error) {
+	rawBytes, err := db.Get(getTxIndexKey(txid))
+	if err != nil {
+		return TxIndex{}, ErrTransactionNotFound
+	}
+	return deserializeTxIndex(rawBytes)
+}
+
+// putTxIndex records the location of every transaction in the block
+func putTxIndex(db storage.Storage, block *Block) error {
+	for i, tx := range block.GetTransactions() {
+		txIndex := TxIndex{block.GetHash(), i}
+		err := db.Put(getTxIndexKey(tx.ID), txIndex.serialize())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delTxIndex removes the location of every transaction in the block
+func delTxIndex(db storage.Storage, block *Block) error {
+	for _, tx := range block.GetTransactions() {
+		err := db.Del(getTxIndexKey(tx.ID))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/dappley/go-dappley/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchain_FindTransaction(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := CreateBlockchain(addr, s, nil)
+
+	blk1 := GenerateUtxoMockBlockWithoutInputs()
+	bc.AddBlockToTail(blk1)
+	blk2 := GenerateUtxoMockBlockWithInputs()
+	bc.AddBlockToTail(blk2)
+
+	txIndex, err := GetTxIndex(s, blk1.GetTransactions()[0].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(blk1.GetHash()), txIndex.BlockId)
+	assert.Equal(t, 0, txIndex.BlockIndex)
+
+	tx, err := bc.FindTransaction(blk2.GetTransactions()[0].ID)
+	assert.Nil(t, err)
+	assert.Equal(t, blk2.GetTransactions()[0].ID, tx.ID)
+
+	_, err = bc.FindTransaction([]byte("unknown"))
+	assert.Equal(t, ErrTransactionNotFound, err)
+}
+
+func TestBlockchain_FindTransactionFromIndexBlock(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := CreateBlockchain(addr, s, nil)
+
+	blk1 := GenerateUtxoMockBlockWithoutInputs()
+	bc.AddBlockToTail(blk1)
+	blk2 := GenerateUtxoMockBlockWithInputs()
+	bc.AddBlockToTail(blk2)
+
+	tx, err := bc.FindTransactionFromIndexBlock(blk1.GetTransactions()[0].ID, blk2.GetHash())
+	assert.Nil(t, err)
+	assert.Equal(t, blk1.GetTransactions()[0].ID, tx.ID)
+
+	// The transaction in blk2 is not in the blocks from blk1 back to genesis
+	_, err = bc.FindTransactionFromIndexBlock(blk2.GetTransactions()[0].ID, blk1.GetHash())
+	assert.Equal(t, ErrTransactionNotFound, err)
+
+	// The transaction in blk1 is not in a fork branching off below blk1, even though the fork is higher
+	forkParent := NewBlock(nil, nil)
+	forkParent.header.height = blk1.GetHeight()
+	forkParent.SetHash([]byte("forkParent"))
+	forkBlk := NewBlock(nil, forkParent)
+	forkBlk.SetHash([]byte("forkBlk"))
+	s.Put(forkParent.GetHash(), forkParent.Serialize())
+	s.Put(forkBlk.GetHash(), forkBlk.Serialize())
+	_, err = bc.FindTransactionFromIndexBlock(blk1.GetTransactions()[0].ID, forkBlk.GetHash())
+	assert.Equal(t, ErrTransactionNotFound, err)
+}
+
+func TestBlockchain_RollbackRemovesTxIndex(t *testing.T) {
+	bc := GenerateMockBlockchain(3)
+	defer bc.db.Close()
+
+	tailBlk, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	txid := tailBlk.GetTransactions()[0].ID
+
+	_, err = bc.FindTransaction(txid)
+	assert.Nil(t, err)
+
+	assert.True(t, bc.Rollback(tailBlk.GetPrevHash()))
+
+	_, err = GetTxIndex(bc.db, txid)
+	assert.Equal(t, ErrTransactionNotFound, err)
+}
+
+func TestBlockchain_RebuildTxIndex(t *testing.T) {
+	bc := GenerateMockBlockchainWithCoinbaseTxOnly(3)
+	defer bc.db.Close()
+
+	// Simulate a database created before the transaction index existed
+	var txids [][]byte
+	bci := bc.Iterator()
+	for {
+		block, err := bci.Next()
+		assert.Nil(t, err)
+		for _, tx := range block.GetTransactions() {
+			txids = append(txids, tx.ID)
+			bc.db.Del(getTxIndexKey(tx.ID))
+		}
+		if len(block.GetPrevHash()) == 0 {
+			break
+		}
+	}
+
+	_, err := bc.FindTransaction(txids[0])
+	assert.Equal(t, ErrTransactionNotFound, err)
+
+	assert.Nil(t, bc.RebuildTxIndex())
+
+	for _, txid := range txids {
+		tx, err := bc.FindTransaction(txid)
+		assert.Nil(t, err)
+		assert.Equal(t, txid, tx.ID)
+	}
+}
@@ -30,6 +30,7 @@ type TXInput struct {
 	Vout      int
 	Signature []byte
 	PubKey    []byte
+	Witnesses []Witness
 }
 
 // UsesKey checks whether the address initiated the transaction
@@ -39,12 +40,28 @@ func (in *TXInput) UsesKey(pubKeyHash []byte) bool {
 	return bytes.Compare(lockingHash, pubKeyHash) == 0
 }
 
+// hasWitness checks whether the owner of pubKeyHash has already signed the input
+func (in *TXInput) hasWitness(pubKeyHash []byte) bool {
+	for _, witness := range in.Witnesses {
+		witnessHash, err := HashPubKey(witness.PubKey)
+		if err == nil && bytes.Equal(witnessHash, pubKeyHash) {
+			return true
+		}
+	}
+	return false
+}
+
 func (in *TXInput) ToProto() proto.Message {
+	var witnesses []*corepb.Witness
+	for _, witness := range in.Witnesses {
+		witnesses = append(witnesses, witness.ToProto().(*corepb.Witness))
+	}
 	return &corepb.TXInput{
 		Txid:      in.Txid,
 		Vout:      int32(in.Vout),
 		Signature: in.Signature,
 		PubKey:    in.PubKey,
+		Witnesses: witnesses,
 	}
 }
 
@@ -53,4 +70,12 @@ func (in *TXInput) FromProto(pb proto.Message) {
 	in.Vout = int(pb.(*corepb.TXInput).Vout)
 	in.Signature = pb.(*corepb.TXInput).Signature
 	in.PubKey = pb.(*corepb.TXInput).PubKey
+
+	var witnesses []Witness
+	for _, witnesspb := range pb.(*corepb.TXInput).Witnesses {
+		witness := Witness{}
+		witness.FromProto(witnesspb)
+		witnesses = append(witnesses, witness)
+	}
+	in.Witnesses = witnesses
 }
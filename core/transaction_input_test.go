@@ -32,6 +32,7 @@ func TestTXInput_Proto(t *testing.T) {
 		1,
 		[]byte("signature"),
 		[]byte("PubKey"),
+		[]Witness{{[]byte("PubKey"), []byte("signature")}},
 	}
 
 	pb := vin.ToProto()
@@ -30,12 +30,24 @@ import (
 type TXOutput struct {
 	Value      *common.Amount
 	PubKeyHash []byte
+	Condition  *LockCondition
 }
 
 func (out *TXOutput) Lock(address []byte) {
+	condition, err := NewAddress(string(address)).GetLockCondition()
+	if err == nil {
+		out.LockWithCondition(condition)
+		return
+	}
 	out.PubKeyHash = HashAddress(address)
 }
 
+// LockWithCondition locks the output with condition. The output is indexed under the hash of the condition.
+func (out *TXOutput) LockWithCondition(condition *LockCondition) {
+	out.Condition = condition
+	out.PubKeyHash = condition.Hash()
+}
+
 func HashAddress(address []byte) []byte{
 	pubKeyHash := util.Base58Decode(address)
 	return pubKeyHash[1 : len(pubKeyHash)-4]
@@ -46,19 +58,36 @@ func (out *TXOutput) IsLockedWithKey(pubKeyHash []byte) bool {
 }
 
 func NewTXOutput(value *common.Amount, address string) *TXOutput {
-	txo := &TXOutput{value, nil}
+	txo := &TXOutput{value, nil, nil}
 	txo.Lock([]byte(address))
 	return txo
 }
 
+// NewConditionTXOutput returns a TXOutput of value locked with condition
+func NewConditionTXOutput(value *common.Amount, condition *LockCondition) *TXOutput {
+	txo := &TXOutput{value, nil, nil}
+	txo.LockWithCondition(condition)
+	return txo
+}
+
 func (out *TXOutput) ToProto() (proto.Message){
+	var condition *corepb.LockCondition
+	if out.Condition != nil {
+		condition = out.Condition.ToProto().(*corepb.LockCondition)
+	}
 	return &corepb.TXOutput{
 		Value:		out.Value.Bytes(),
 		PubKeyHash:	out.PubKeyHash,
+		Condition:	condition,
 	}
 }
 
 func (out *TXOutput) FromProto(pb proto.Message){
 	out.Value = common.NewAmountFromBytes(pb.(*corepb.TXOutput).Value)
 	out.PubKeyHash = pb.(*corepb.TXOutput).PubKeyHash
+	out.Condition = nil
+	if pb.(*corepb.TXOutput).Condition != nil {
+		out.Condition = &LockCondition{}
+		out.Condition.FromProto(pb.(*corepb.TXOutput).Condition)
+	}
 }
\ No newline at end of file
@@ -31,6 +31,7 @@ func TestTXOutput_Proto(t *testing.T) {
 	vout := TXOutput{
 		common.NewAmount(1),
 		[]byte("PubKeyHash"),
+		&LockCondition{[][]byte{[]byte("PubKeyHash")}, 1, 2, 3, 4, 5},
 	}
 
 	pb := vout.ToProto()
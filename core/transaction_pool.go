@@ -20,39 +20,200 @@ package core
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dappley/go-dappley/common/sorted"
+	"github.com/dappley/go-dappley/core/pb"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-peer"
 	logger "github.com/sirupsen/logrus"
 )
 
 const TransactionPoolLimit = 128
 
+const (
+	// DefaultTransactionTTL is how long a transaction stays in the pool before it expires
+	DefaultTransactionTTL = 24 * time.Hour
+	// DefaultMaxTransactionsPerAddress is the number of pooled transactions an address can have pending
+	DefaultMaxTransactionsPerAddress = 16
+)
+
+const (
+	// ingestQueueSize is the number of transactions PushTransaction queues for verification before dropping new ones
+	ingestQueueSize = 1024
+	// subscriberQueueSize is the number of admitted transactions queued for a subscriber before it misses new ones
+	subscriberQueueSize = 128
+	// invalidTxQueueSize is the number of invalid transactions queued for InvalidTxCh before new ones are not reported
+	invalidTxQueueSize = 128
+)
+
+// txPoolKey is the key under which the pending transactions are saved across restarts
+const txPoolKey = "txPool"
+
+var (
+	ErrDuplicateTransaction = errors.New("ERROR: Transaction is already in the pool")
+	ErrTransactionConflict  = errors.New("ERROR: Transaction spends an output already spent in the pool")
+	ErrTransactionPoolFull  = errors.New("ERROR: Transaction pool is full")
+	ErrTipTooLow            = errors.New("ERROR: Transaction tip is below the minimum relay tip")
+	ErrTooManyTransactions  = errors.New("ERROR: Address has too many pending transactions in the pool")
+	ErrInvalidTransaction   = errors.New("ERROR: Transaction cannot pass verification")
+)
+
+// RcvedTx is a transaction received from a peer
+type RcvedTx struct {
+	Tx  Transaction
+	Pid peer.ID
+}
+
+// TransactionPoolStats counts the transactions offered to a TransactionPool since it was created
+type TransactionPoolStats struct {
+	Admitted uint64
+	Rejected uint64
+	Expired  uint64
+}
+
+// TransactionPool holds the transactions waiting to be included in a block. It is safe for concurrent use.
+// Transactions received from the network are queued with PushTransaction and verified by the loop started with Start,
+// while Push admits a transaction immediately. Subscribers are notified of every transaction admitted.
 type TransactionPool struct {
-	messageCh    chan string
+	ingestCh     chan RcvedTx
+	invalidTxCh  chan RcvedTx
 	exitCh       chan bool
+	stopOnce     *sync.Once
 	size         int
-	Transactions sorted.Slice
+	transactions sorted.Slice
+	// txs holds the pooled transactions keyed by ID
+	txs map[string]Transaction
+	// spenders maps the outpoint of every output spent by a pooled transaction to the ID of that transaction
+	spenders map[string]string
+	// addedTimes holds the unix time each pooled transaction entered the pool, keyed by ID
+	addedTimes map[string]int64
+	// senders counts the pooled transactions of every sender, keyed by public key hash
+	senders map[string]int
+
+	ttl              time.Duration
+	maxTxsPerAddress int
+	minTip           uint64
+	stats            TransactionPoolStats
+
+	bc          *Blockchain
+	subscribers map[chan Transaction]bool
+	mutex       *sync.RWMutex
 }
 
 func NewTransactionPool() *TransactionPool {
 	txPool := &TransactionPool{
-		messageCh: make(chan string, 128),
-		size:      128,
+		ingestCh:    make(chan RcvedTx, ingestQueueSize),
+		invalidTxCh: make(chan RcvedTx, invalidTxQueueSize),
+		exitCh:      make(chan bool, 1),
+		stopOnce:    &sync.Once{},
+		size:        TransactionPoolLimit,
+		txs:         make(map[string]Transaction),
+		spenders:    make(map[string]string),
+
+		addedTimes:       make(map[string]int64),
+		senders:          make(map[string]int),
+		ttl:              DefaultTransactionTTL,
+		maxTxsPerAddress: DefaultMaxTransactionsPerAddress,
+
+		subscribers: make(map[chan Transaction]bool),
+		mutex:       &sync.RWMutex{},
+	}
+	txPool.transactions = *sorted.NewSlice(CompareTransactionFeeRates, match)
+	return txPool
+}
+
+// SetTTL sets how long transactions stay in the pool before they expire
+func (txPool *TransactionPool) SetTTL(ttl time.Duration) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	txPool.ttl = ttl
+}
+
+// SetMaxTransactionsPerAddress sets the number of pooled transactions an address can have pending
+func (txPool *TransactionPool) SetMaxTransactionsPerAddress(maxTxs int) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	txPool.maxTxsPerAddress = maxTxs
+}
+
+// SetMinTip sets the minimum tip of the transactions admitted to the pool
+func (txPool *TransactionPool) SetMinTip(minTip uint64) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	txPool.minTip = minTip
+}
+
+// GetMinTip returns the minimum tip of the transactions admitted to the pool
+func (txPool *TransactionPool) GetMinTip() uint64 {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	return txPool.minTip
+}
+
+// SetBlockchain sets the blockchain the transactions queued with PushTransaction are verified against
+func (txPool *TransactionPool) SetBlockchain(bc *Blockchain) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	txPool.bc = bc
+}
+
+// Len returns the number of transactions in the pool
+func (txPool *TransactionPool) Len() int {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	return txPool.transactions.Len()
+}
+
+// GetStats returns the number of transactions admitted to, rejected by and expired from the pool
+func (txPool *TransactionPool) GetStats() TransactionPoolStats {
+	return TransactionPoolStats{
+		Admitted: atomic.LoadUint64(&txPool.stats.Admitted),
+		Rejected: atomic.LoadUint64(&txPool.stats.Rejected),
+		Expired:  atomic.LoadUint64(&txPool.stats.Expired),
+	}
+}
+
+// LoadTxPoolFromDatabase returns a pool holding the transactions saved in db by SaveToDatabase. The transactions are not
+// verified, so FilterAllTransactions should be called before they are used.
+func LoadTxPoolFromDatabase(db storage.Storage) *TransactionPool {
+	txPool := NewTransactionPool()
+	rawBytes, err := db.Get([]byte(txPoolKey))
+	if err != nil {
+		return txPool
+	}
+	txPoolProto := &corepb.TransactionPool{}
+	if err := proto.Unmarshal(rawBytes, txPoolProto); err != nil {
+		logger.Warn("TransactionPool: Saved transactions can not be decoded. Starting with an empty pool.")
+		return txPool
 	}
-	txPool.Transactions = *sorted.NewSlice(CompareTransactionTips, match)
+	txPool.FromProto(txPoolProto)
 	return txPool
 }
 
-func CompareTransactionTips(a interface{}, b interface{}) int {
+// SaveToDatabase writes the pending transactions to db so that they can be reloaded after a restart
+func (txPool *TransactionPool) SaveToDatabase(db storage.Storage) error {
+	rawBytes, err := proto.Marshal(txPool.ToProto())
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(txPoolKey), rawBytes)
+}
+
+// CompareTransactionFeeRates compares the tips per byte of the canonical encodings of two Transactions
+func CompareTransactionFeeRates(a interface{}, b interface{}) int {
 	ai := a.(Transaction)
 	bi := b.(Transaction)
-	if ai.Tip < bi.Tip {
-		return -1
-	} else if ai.Tip > bi.Tip {
-		return 1
-	} else {
-		return 0
-	}
+	// ai.Tip / sizeA < bi.Tip / sizeB <=> ai.Tip * sizeB < bi.Tip * sizeA
+	left := new(big.Int).Mul(new(big.Int).SetUint64(ai.Tip), big.NewInt(int64(bi.GetSize())))
+	right := new(big.Int).Mul(new(big.Int).SetUint64(bi.Tip), big.NewInt(int64(ai.GetSize())))
+	return left.Cmp(right)
 }
 
 // match returns true if a and b are Transactions and they have the same ID, false otherwise
@@ -60,69 +221,486 @@ func match(a interface{}, b interface{}) bool {
 	return bytes.Compare(a.(Transaction).ID, b.(Transaction).ID) == 0
 }
 
+// RemoveMultipleTransactions removes txs from the pool. The transactions spending their outputs are kept.
 func (txPool *TransactionPool) RemoveMultipleTransactions(txs []*Transaction) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	for _, tx := range txs {
+		txPool.removeTransaction(*tx)
+	}
+}
+
+// RemoveConflictingTransactions evicts the pooled transactions spending any output spent by txs, together with their
+// descendants. It is called when txs are included in a block, as those transactions can no longer be included in any
+// block of the chain.
+func (txPool *TransactionPool) RemoveConflictingTransactions(txs []*Transaction) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
 	for _, tx := range txs {
-		txPool.Transactions.Del(*tx)
+		for _, conflict := range txPool.getConflictingTransactions(tx) {
+			txPool.evictTransaction(*conflict)
+		}
 	}
 }
 
-//function f should return true if the transaction needs to be pushed back to the pool
+//function f should return true if the transaction needs to be pushed back to the pool. The descendants of the
+//transactions that are not pushed back are removed as well. The pool is locked while f runs, so f must not call it.
 func (txPool *TransactionPool) Traverse(txHandler func(tx Transaction) bool) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
 
-	for _, v := range txPool.Transactions.Get() {
+	for _, v := range txPool.transactions.Get() {
 		tx := v.(Transaction)
+		if _, ok := txPool.txs[string(tx.ID)]; !ok {
+			// evicted as the descendant of a previous transaction
+			continue
+		}
 		if !txHandler(tx) {
-			txPool.Transactions.Del(tx)
+			txPool.evictTransaction(tx)
 		}
 	}
 }
 
-func (txPool *TransactionPool) FilterAllTransactions(utxoPool UTXOIndex) {
-	txPool.Traverse(func(tx Transaction) bool {
-		return tx.Verify(utxoPool, 0) // all transactions in transaction pool have no blockHeight
-		// TODO: also check if amount is valid
-	})
+// FilterAllTransactions removes the transactions that can't be included in a block at blockHeight timestamped blockTime.
+// Transactions are verified parents first against utxoPool updated with the transactions verified before them, so
+// that transactions spending the outputs of pooled transactions are kept. Signatures are verified without holding
+// the lock of the pool.
+func (txPool *TransactionPool) FilterAllTransactions(utxoPool UTXOIndex, blockHeight uint64, blockTime int64) {
+	txPool.RemoveExpiredTransactions()
+
+	txPool.mutex.RLock()
+	txs := sortParentsFirst(txPool.getTransactions())
+	txPool.mutex.RUnlock()
+
+	var invalidTxs []*Transaction
+	utxos := utxoPool.deepCopy()
+	for _, tx := range txs {
+		// the descendants of invalid transactions fail as the outputs they spend are missing
+		if !tx.Verify(utxos, blockHeight, blockTime) {
+			invalidTxs = append(invalidTxs, tx)
+			continue
+		}
+		utxos.applyTransaction(tx, blockHeight, blockTime)
+	}
+
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	for _, tx := range invalidTxs {
+		txPool.evictTransaction(*tx)
+	}
+}
+
+// GetPendingUTXOIndex returns a copy of utxoIndex updated with the transactions in the pool as if they were included
+// in a block at blockHeight timestamped blockTime. The outputs of pooled transactions can then be spent by new ones.
+func (txPool *TransactionPool) GetPendingUTXOIndex(utxoIndex UTXOIndex, blockHeight uint64, blockTime int64) UTXOIndex {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	return txPool.getPendingUTXOIndex(utxoIndex, nil, blockHeight, blockTime)
+}
+
+// VerifyTransaction returns true if tx can be included in the next block of the blockchain of the pool after the
+// pooled transactions it doesn't replace. The pool may change before tx is pushed, so VerifyAndPush should be used to
+// admit it.
+func (txPool *TransactionPool) VerifyTransaction(tx *Transaction) bool {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	return txPool.verifyTransaction(tx)
+}
+
+func (txPool *TransactionPool) verifyTransaction(tx *Transaction) bool {
+	bc := txPool.bc
+	if bc == nil || tx.IsCoinbase() {
+		return false
+	}
+	blockHeight := bc.GetMaxHeight() + 1
+	blockTime := time.Now().Unix()
+	replaced := make(map[string]bool)
+	for _, conflict := range txPool.getConflictingTransactions(tx) {
+		txPool.skipDescendants(*conflict, replaced)
+	}
+	utxos := txPool.getPendingUTXOIndex(LoadUTXOIndex(bc.GetDb()), replaced, blockHeight, blockTime)
+	return tx.Verify(utxos, blockHeight, blockTime)
+}
+
+// getPendingUTXOIndex returns a copy of utxoIndex updated with the pooled transactions that are not excluded
+func (txPool *TransactionPool) getPendingUTXOIndex(utxoIndex UTXOIndex, excluded map[string]bool, blockHeight uint64, blockTime int64) UTXOIndex {
+	utxos := utxoIndex.deepCopy()
+	for _, tx := range sortParentsFirst(txPool.getTransactions()) {
+		if !excluded[string(tx.ID)] {
+			utxos.applyTransaction(tx, blockHeight, blockTime)
+		}
+	}
+	return utxos
+}
+
+// GetTransaction returns the pooled transaction with the given ID or nil if there is none
+func (txPool *TransactionPool) GetTransaction(txid []byte) *Transaction {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	tx, ok := txPool.txs[string(txid)]
+	if !ok {
+		return nil
+	}
+	return &tx
 }
 
-//need to optimize
-func (txPool *TransactionPool) PopSortedTransactions() []*Transaction {
+// PopSortedTransactions removes from the pool and returns the transactions with the highest tips per byte whose
+// encodings add up to at most maxSize bytes, and at most maxCount of them. Transactions always follow the pooled
+// transactions whose outputs they spend, and are left in the pool when their parents are. A limit of 0 is no limit.
+func (txPool *TransactionPool) PopSortedTransactions(maxSize int, maxCount int) []*Transaction {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+
 	sortedTransactions := []*Transaction{}
-	for txPool.Transactions.Len() > 0 {
-		tx := txPool.Transactions.PopRight().(Transaction)
-		sortedTransactions = append(sortedTransactions, &tx)
+	skipped := make(map[string]bool)
+	size := 0
+	for _, tx := range sortParentsFirst(txPool.getSortedTransactions()) {
+		if skipped[string(tx.ID)] {
+			continue
+		}
+		if maxCount > 0 && len(sortedTransactions) >= maxCount {
+			break
+		}
+		txSize := GetEncodedSizeInBlock(tx)
+		if maxSize > 0 && size+txSize > maxSize {
+			// Smaller transactions may still fit, but the descendants of tx can't be included without it
+			txPool.skipDescendants(*tx, skipped)
+			continue
+		}
+		size += txSize
+		sortedTransactions = append(sortedTransactions, tx)
+	}
+	for _, tx := range sortedTransactions {
+		txPool.removeTransaction(*tx)
 	}
 	return sortedTransactions
 }
 
-func (txPool *TransactionPool) Push(tx Transaction) {
-	//get smallest tip tx
+// GetEncodedSizeInBlock returns the number of bytes tx adds to the canonical encoding of a block
+func GetEncodedSizeInBlock(tx *Transaction) int {
+	size := tx.GetSize()
+	// field tag and length prefix of the embedded message
+	return 1 + proto.SizeVarint(uint64(size)) + size
+}
+
+// RemoveExpiredTransactions evicts the transactions that have been in the pool for longer than its time-to-live,
+// together with their descendants
+func (txPool *TransactionPool) RemoveExpiredTransactions() {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	txPool.removeExpiredTransactions()
+}
+
+func (txPool *TransactionPool) removeExpiredTransactions() {
+	if txPool.ttl <= 0 {
+		return
+	}
+	deadline := time.Now().Add(-txPool.ttl).Unix()
+	for _, tx := range txPool.getTransactions() {
+		addedTime, ok := txPool.addedTimes[string(tx.ID)]
+		if !ok || addedTime > deadline {
+			// evicted as the descendant of a previous transaction or not expired yet
+			continue
+		}
+		logger.WithFields(logger.Fields{
+			"txid": hex.EncodeToString(tx.ID),
+		}).Debug("TransactionPool: Transaction expired")
+		atomic.AddUint64(&txPool.stats.Expired, 1)
+		txPool.evictTransaction(*tx)
+	}
+}
+
+// Push adds tx to the pool. A transaction spending an output already spent by pooled transactions replaces them if its
+// tip is higher than their total tip and is rejected otherwise. The descendants of replaced transactions are evicted.
+// Transactions tipping less than the minimum relay tip or whose sender already has too many pending transactions are
+// rejected.
+func (txPool *TransactionPool) Push(tx Transaction) error {
+	txPool.mutex.Lock()
+	err := txPool.push(tx)
+	txPool.mutex.Unlock()
+
+	if err != nil {
+		atomic.AddUint64(&txPool.stats.Rejected, 1)
+		return err
+	}
+	atomic.AddUint64(&txPool.stats.Admitted, 1)
+	txPool.publish(tx)
+	return nil
+}
+
+// VerifyAndPush verifies tx and adds it to the pool like Push. Both are done holding the lock of the pool, so that the
+// pooled transactions tx spends the outputs of can't be evicted in between. ErrInvalidTransaction is returned if tx
+// can't be included in the next block of the blockchain of the pool.
+func (txPool *TransactionPool) VerifyAndPush(tx Transaction) error {
+	txPool.mutex.Lock()
+	err := ErrInvalidTransaction
+	if txPool.verifyTransaction(&tx) {
+		err = txPool.push(tx)
+	}
+	txPool.mutex.Unlock()
+
+	if err != nil {
+		atomic.AddUint64(&txPool.stats.Rejected, 1)
+		return err
+	}
+	atomic.AddUint64(&txPool.stats.Admitted, 1)
+	txPool.publish(tx)
+	return nil
+}
+
+func (txPool *TransactionPool) push(tx Transaction) error {
+	if _, ok := txPool.txs[string(tx.ID)]; ok {
+		return ErrDuplicateTransaction
+	}
+	if tx.Tip < txPool.minTip {
+		return ErrTipTooLow
+	}
+	txPool.removeExpiredTransactions()
+
+	conflicts := txPool.getConflictingTransactions(&tx)
+	if len(conflicts) > 0 {
+		conflictTips, err := CalculateTotalTips(conflicts)
+		if err != nil || tx.Tip <= conflictTips {
+			return ErrTransactionConflict
+		}
+	}
+	if sender := getSender(&tx); sender != "" && txPool.maxTxsPerAddress > 0 {
+		pending := txPool.senders[sender]
+		for _, conflict := range conflicts {
+			// replaced transactions don't count
+			if getSender(conflict) == sender {
+				pending--
+			}
+		}
+		if pending >= txPool.maxTxsPerAddress {
+			return ErrTooManyTransactions
+		}
+	}
+
+	for _, conflict := range conflicts {
+		logger.WithFields(logger.Fields{
+			"replaced": hex.EncodeToString(conflict.ID),
+			"by":       hex.EncodeToString(tx.ID),
+		}).Info("TransactionPool: Transaction replaced by fee")
+		txPool.evictTransaction(*conflict)
+	}
+
+	//get the tx with the smallest tip per byte that tx doesn't spend the outputs of, directly or not
+	if txPool.transactions.Len() >= txPool.size {
+		ancestors := make(map[string]bool)
+		txPool.markAncestors(tx, ancestors)
+		var compareTx *Transaction
+		for _, pooledTx := range txPool.getTransactions() {
+			if !ancestors[string(pooledTx.ID)] {
+				compareTx = pooledTx
+				break
+			}
+		}
+		if compareTx == nil || CompareTransactionFeeRates(tx, *compareTx) <= 0 {
+			return ErrTransactionPoolFull
+		}
+		txPool.evictTransaction(*compareTx)
+	}
+	txPool.addTransaction(tx)
+	return nil
+}
+
+// getTransactions returns the pooled transactions ordered by increasing tip per byte
+func (txPool *TransactionPool) getTransactions() []*Transaction {
+	var txs []*Transaction
+	for _, v := range txPool.transactions.Get() {
+		tx := v.(Transaction)
+		txs = append(txs, &tx)
+	}
+	return txs
+}
+
+// getSortedTransactions returns the pooled transactions ordered by decreasing tip per byte
+func (txPool *TransactionPool) getSortedTransactions() []*Transaction {
+	txs := txPool.getTransactions()
+	for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+		txs[i], txs[j] = txs[j], txs[i]
+	}
+	return txs
+}
+
+// getConflictingTransactions returns the pooled transactions spending any output spent by tx
+func (txPool *TransactionPool) getConflictingTransactions(tx *Transaction) []*Transaction {
+	if tx.IsCoinbase() {
+		return nil
+	}
+	var conflicts []*Transaction
+	found := make(map[string]bool)
+	for _, vin := range tx.Vin {
+		txid, ok := txPool.spenders[getOutpointKey(vin.Txid, vin.Vout)]
+		if !ok || found[txid] {
+			continue
+		}
+		found[txid] = true
+		if conflict, ok := txPool.txs[txid]; ok {
+			conflicts = append(conflicts, &conflict)
+		}
+	}
+	return conflicts
+}
+
+// getChildren returns the pooled transactions spending the outputs of tx
+func (txPool *TransactionPool) getChildren(tx Transaction) []Transaction {
+	var children []Transaction
+	found := make(map[string]bool)
+	for i := range tx.Vout {
+		txid, ok := txPool.spenders[getOutpointKey(tx.ID, i)]
+		if !ok || found[txid] {
+			continue
+		}
+		found[txid] = true
+		if child, ok := txPool.txs[txid]; ok {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (txPool *TransactionPool) addTransaction(tx Transaction) {
+	txPool.transactions.Push(tx)
+	txPool.txs[string(tx.ID)] = tx
+	txPool.addedTimes[string(tx.ID)] = time.Now().Unix()
+	if sender := getSender(&tx); sender != "" {
+		txPool.senders[sender]++
+	}
+	if tx.IsCoinbase() {
+		return
+	}
+	for _, vin := range tx.Vin {
+		txPool.spenders[getOutpointKey(vin.Txid, vin.Vout)] = string(tx.ID)
+	}
+}
+
+func (txPool *TransactionPool) removeTransaction(tx Transaction) {
+	if _, ok := txPool.txs[string(tx.ID)]; !ok {
+		return
+	}
+	txPool.transactions.Del(tx)
+	delete(txPool.txs, string(tx.ID))
+	delete(txPool.addedTimes, string(tx.ID))
+	if sender := getSender(&tx); sender != "" {
+		txPool.senders[sender]--
+		if txPool.senders[sender] <= 0 {
+			delete(txPool.senders, sender)
+		}
+	}
+	txPool.removeSpenders(tx)
+}
+
+// getSender returns the public key hash of the owner signing the first input of tx, or an empty string if it can't be
+// told
+func getSender(tx *Transaction) string {
+	if tx.IsCoinbase() || len(tx.Vin) == 0 {
+		return ""
+	}
+	pubKey := tx.Vin[0].PubKey
+	if len(pubKey) == 0 && len(tx.Vin[0].Witnesses) > 0 {
+		pubKey = tx.Vin[0].Witnesses[0].PubKey
+	}
+	pubKeyHash, err := HashPubKey(pubKey)
+	if err != nil {
+		return ""
+	}
+	return string(pubKeyHash)
+}
+
+// markAncestors marks all pooled transactions whose outputs tx spends, directly or through other pooled transactions
+func (txPool *TransactionPool) markAncestors(tx Transaction, ancestors map[string]bool) {
+	for _, vin := range tx.Vin {
+		parent, ok := txPool.txs[string(vin.Txid)]
+		if !ok || ancestors[string(parent.ID)] {
+			continue
+		}
+		ancestors[string(parent.ID)] = true
+		txPool.markAncestors(parent, ancestors)
+	}
+}
+
+// skipDescendants marks all pooled descendants of tx as skipped
+func (txPool *TransactionPool) skipDescendants(tx Transaction, skipped map[string]bool) {
+	skipped[string(tx.ID)] = true
+	for _, child := range txPool.getChildren(tx) {
+		if !skipped[string(child.ID)] {
+			txPool.skipDescendants(child, skipped)
+		}
+	}
+}
+
+// evictTransaction removes tx and all its descendants from the pool, as they can't be included in a block without it
+func (txPool *TransactionPool) evictTransaction(tx Transaction) {
+	children := txPool.getChildren(tx)
+	txPool.removeTransaction(tx)
+	for _, child := range children {
+		txPool.evictTransaction(child)
+	}
+}
+
+func (txPool *TransactionPool) removeSpenders(tx Transaction) {
+	for _, vin := range tx.Vin {
+		key := getOutpointKey(vin.Txid, vin.Vout)
+		if txPool.spenders[key] == string(tx.ID) {
+			delete(txPool.spenders, key)
+		}
+	}
+}
+
+// sortParentsFirst reorders txs so that every transaction follows the ones in txs whose outputs it spends. The order of
+// txs is otherwise kept.
+func sortParentsFirst(txs []*Transaction) []*Transaction {
+	byID := make(map[string]*Transaction)
+	for _, tx := range txs {
+		byID[string(tx.ID)] = tx
+	}
 
-	if txPool.Transactions.Len() >= TransactionPoolLimit {
-		compareTx := txPool.Transactions.PopLeft().(Transaction)
-		greaterThanLeastTip := tx.Tip > compareTx.Tip
-		if greaterThanLeastTip {
-			txPool.Transactions.Push(tx)
-		} else { // do nothing, push back popped tx
-			txPool.Transactions.Push(compareTx)
+	sorted := make([]*Transaction, 0, len(txs))
+	visited := make(map[string]bool)
+	var visit func(tx *Transaction)
+	visit = func(tx *Transaction) {
+		if visited[string(tx.ID)] {
+			return
+		}
+		visited[string(tx.ID)] = true
+		for _, vin := range tx.Vin {
+			if parent, ok := byID[string(vin.Txid)]; ok {
+				visit(parent)
+			}
 		}
-	} else {
-		txPool.Transactions.Push(tx)
+		sorted = append(sorted, tx)
 	}
+	for _, tx := range txs {
+		visit(tx)
+	}
+	return sorted
 }
 
+// Start starts verifying and admitting the transactions queued with PushTransaction
 func (txPool *TransactionPool) Start() {
 	go txPool.messageLoop()
 }
 
+// Stop stops the loop started by Start. It can be called more than once.
 func (txPool *TransactionPool) Stop() {
-	txPool.exitCh <- true
+	txPool.stopOnce.Do(func() {
+		close(txPool.exitCh)
+	})
 }
 
-//todo: will change the input from string to transaction
-func (txPool *TransactionPool) PushTransaction(msg string) {
-	//func (txPool *TransactionPool) PushTransaction(tx *Transaction){
-	//	txPool.Push(tx)
-	logger.Info(msg)
+// PushTransaction queues tx received from the peer pid to be verified and admitted to the pool without waiting for it.
+// Transactions are dropped when the queue is full.
+func (txPool *TransactionPool) PushTransaction(tx Transaction, pid peer.ID) {
+	select {
+	case txPool.ingestCh <- RcvedTx{tx, pid}:
+	default:
+		atomic.AddUint64(&txPool.stats.Rejected, 1)
+		logger.WithFields(logger.Fields{
+			"txid": hex.EncodeToString(tx.ID),
+		}).Warn("TransactionPool: Ingestion queue is full. Transaction is dropped")
+	}
 }
 
 func (txPool *TransactionPool) messageLoop() {
@@ -131,8 +709,113 @@ func (txPool *TransactionPool) messageLoop() {
 		case <-txPool.exitCh:
 			logger.Info("Quit Transaction Pool")
 			return
-		case msg := <-txPool.messageCh:
-			txPool.PushTransaction(msg)
+		case rcvedTx := <-txPool.ingestCh:
+			txPool.ingest(rcvedTx)
+		}
+	}
+}
+
+// ingest verifies a received transaction and admits it to the pool if it is valid. Invalid transactions are reported
+// on InvalidTxCh.
+func (txPool *TransactionPool) ingest(rcvedTx RcvedTx) {
+	tx := rcvedTx.Tx
+	err := txPool.VerifyAndPush(tx)
+	if err == ErrInvalidTransaction {
+		logger.WithFields(logger.Fields{
+			"txid": hex.EncodeToString(tx.ID),
+		}).Debug("TransactionPool: Invalid transaction is dropped")
+		txPool.reportInvalidTx(rcvedTx)
+		return
+	}
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"txid":  hex.EncodeToString(tx.ID),
+			"error": err,
+		}).Debug("TransactionPool: Transaction is not admitted")
+	}
+}
+
+// InvalidTxCh returns the channel receiving the transactions queued with PushTransaction that failed verification, along
+// with the peers that sent them
+func (txPool *TransactionPool) InvalidTxCh() chan RcvedTx {
+	return txPool.invalidTxCh
+}
+
+func (txPool *TransactionPool) reportInvalidTx(rcvedTx RcvedTx) {
+	if rcvedTx.Pid == "" {
+		return
+	}
+	select {
+	case txPool.invalidTxCh <- rcvedTx:
+	default:
+	}
+}
+
+// Subscribe returns a channel receiving every transaction admitted to the pool from now on. Transactions are dropped
+// for subscribers that fall behind.
+func (txPool *TransactionPool) Subscribe() chan Transaction {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	ch := make(chan Transaction, subscriberQueueSize)
+	txPool.subscribers[ch] = true
+	return ch
+}
+
+// Unsubscribe stops sending admitted transactions to ch and closes it
+func (txPool *TransactionPool) Unsubscribe(ch chan Transaction) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	if txPool.subscribers[ch] {
+		delete(txPool.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (txPool *TransactionPool) publish(tx Transaction) {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+	for ch := range txPool.subscribers {
+		select {
+		case ch <- tx:
+		default:
+			logger.WithFields(logger.Fields{
+				"txid": hex.EncodeToString(tx.ID),
+			}).Warn("TransactionPool: Subscriber is full. Transaction is not delivered")
+		}
+	}
+}
+
+func (txPool *TransactionPool) ToProto() proto.Message {
+	txPool.mutex.RLock()
+	defer txPool.mutex.RUnlock()
+
+	var txs []*corepb.Transaction
+	var addedTimes []int64
+	// parents first, so that the pool can be rebuilt in order
+	for _, tx := range sortParentsFirst(txPool.getTransactions()) {
+		txs = append(txs, tx.ToProto().(*corepb.Transaction))
+		addedTimes = append(addedTimes, txPool.addedTimes[string(tx.ID)])
+	}
+	return &corepb.TransactionPool{Transactions: txs, AddedTimes: addedTimes}
+}
+
+func (txPool *TransactionPool) FromProto(pb proto.Message) {
+	txPool.mutex.Lock()
+	defer txPool.mutex.Unlock()
+	addedTimes := pb.(*corepb.TransactionPool).AddedTimes
+	for i, txpb := range pb.(*corepb.TransactionPool).Transactions {
+		tx := Transaction{}
+		tx.FromProto(txpb)
+		if err := txPool.push(tx); err != nil {
+			logger.WithFields(logger.Fields{
+				"txid":  hex.EncodeToString(tx.ID),
+				"error": err,
+			}).Warn("TransactionPool: Saved transaction is dropped")
+			continue
+		}
+		if i < len(addedTimes) {
+			// transactions keep expiring from the time they were first received
+			txPool.addedTimes[string(tx.ID)] = addedTimes[i]
 		}
 	}
 }
@@ -20,31 +20,34 @@ package core
 
 import (
 	"testing"
+	"time"
 
-	"github.com/dappley/go-dappley/util"
+	"github.com/dappley/go-dappley/common"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/libp2p/go-libp2p-peer"
 	"github.com/stretchr/testify/assert"
 )
 
 var t1 = Transaction{
-	ID:   util.GenerateRandomAoB(1),
+	ID:   []byte{1},
 	Vin:  GenerateFakeTxInputs(),
 	Vout: GenerateFakeTxOutputs(),
 	Tip:  2,
 }
 var t2 = Transaction{
-	ID:   util.GenerateRandomAoB(1),
+	ID:   []byte{2},
 	Vin:  GenerateFakeTxInputs(),
 	Vout: GenerateFakeTxOutputs(),
 	Tip:  5,
 }
 var t3 = Transaction{
-	ID:   util.GenerateRandomAoB(1),
+	ID:   []byte{3},
 	Vin:  GenerateFakeTxInputs(),
 	Vout: GenerateFakeTxOutputs(),
 	Tip:  10,
 }
 var t4 = Transaction{
-	ID:   util.GenerateRandomAoB(1),
+	ID:   []byte{4},
 	Vin:  GenerateFakeTxInputs(),
 	Vout: GenerateFakeTxOutputs(),
 	Tip:  20,
@@ -65,12 +68,12 @@ var popInputOrder = []struct {
 func TestTxPoolPush(t *testing.T) {
 	txPool := NewTransactionPool()
 	txPool.Push(t1)
-	assert.Equal(t, 1, txPool.Transactions.Len())
+	assert.Equal(t, 1, txPool.transactions.Len())
 	txPool.Push(t2)
-	assert.Equal(t, 2, txPool.Transactions.Len())
+	assert.Equal(t, 2, txPool.transactions.Len())
 	txPool.Push(t3)
 	txPool.Push(t4)
-	assert.Equal(t, 4, txPool.Transactions.Len())
+	assert.Equal(t, 4, txPool.transactions.Len())
 }
 
 func TestTranstionPoolPop(t *testing.T) {
@@ -78,10 +81,10 @@ func TestTranstionPoolPop(t *testing.T) {
 		var popOrder = []uint64{}
 		txPool := NewTransactionPool()
 		for _, tx := range tt.order {
-			txPool.Transactions.Push(tx)
+			txPool.transactions.Push(tx)
 		}
-		for txPool.Transactions.Len() > 0 {
-			popOrder = append(popOrder, txPool.Transactions.PopRight().(Transaction).Tip)
+		for txPool.transactions.Len() > 0 {
+			popOrder = append(popOrder, txPool.transactions.PopRight().(Transaction).Tip)
 		}
 		assert.Equal(t, expectPopOrder, popOrder)
 	}
@@ -98,6 +101,533 @@ func TestTransactionPool_RemoveMultipleTransactions(t *testing.T) {
 	}
 	txPool.RemoveMultipleTransactions(txs)
 
-	assert.Equal(t,0, txPool.Transactions.Len())
+	assert.Equal(t,0, txPool.transactions.Len())
 
-}
\ No newline at end of file
+}
+func TestTransactionPool_PushConflict(t *testing.T) {
+	txPool := NewTransactionPool()
+	tx := MockTransaction()
+	assert.Nil(t, txPool.Push(*tx))
+	assert.Equal(t, ErrDuplicateTransaction, txPool.Push(*tx))
+
+	// A transaction spending one of the same outputs is rejected unless it pays a higher tip
+	conflict := Transaction{[]byte("conflict"), []TXInput{tx.Vin[1]}, MockTxOutputs(), tx.Tip}
+	assert.Equal(t, ErrTransactionConflict, txPool.Push(conflict))
+	assert.Equal(t, 1, txPool.transactions.Len())
+
+	conflict.Tip = tx.Tip + 1
+	assert.Nil(t, txPool.Push(conflict))
+	assert.Equal(t, 1, txPool.transactions.Len())
+	assert.Equal(t, conflict.ID, txPool.transactions.Get()[0].(Transaction).ID)
+
+	// The outputs only spent by the replaced transaction are free again
+	other := Transaction{[]byte("other"), []TXInput{tx.Vin[0]}, MockTxOutputs(), 0}
+	assert.Nil(t, txPool.Push(other))
+	assert.Equal(t, 2, txPool.transactions.Len())
+}
+
+func TestTransactionPool_ReplaceMultipleConflicts(t *testing.T) {
+	txPool := NewTransactionPool()
+	tx1 := MockTransaction()
+	tx2 := MockTransaction()
+	tx1.ID, tx2.ID = []byte{1}, []byte{2}
+	assert.Nil(t, txPool.Push(*tx1))
+	assert.Nil(t, txPool.Push(*tx2))
+
+	// The replacement must pay more than the total tip of all transactions it replaces
+	replacement := Transaction{[]byte("replacement"), []TXInput{tx1.Vin[0], tx2.Vin[0]}, MockTxOutputs(), tx1.Tip + tx2.Tip}
+	assert.Equal(t, ErrTransactionConflict, txPool.Push(replacement))
+
+	replacement.Tip++
+	assert.Nil(t, txPool.Push(replacement))
+	assert.Equal(t, 1, txPool.transactions.Len())
+	assert.Len(t, txPool.spenders, 2)
+}
+
+func TestTransactionPool_RemoveConflictingTransactions(t *testing.T) {
+	txPool := NewTransactionPool()
+	tx1 := MockTransaction()
+	tx2 := MockTransaction()
+	tx1.ID, tx2.ID = []byte{1}, []byte{2}
+	assert.Nil(t, txPool.Push(*tx1))
+	assert.Nil(t, txPool.Push(*tx2))
+
+	// A block includes another transaction spending an output of tx1
+	mined := &Transaction{[]byte("mined"), []TXInput{tx1.Vin[1]}, MockTxOutputs(), 0}
+	txPool.RemoveConflictingTransactions([]*Transaction{mined})
+
+	assert.Equal(t, 1, txPool.transactions.Len())
+	assert.Equal(t, tx2.ID, txPool.transactions.Get()[0].(Transaction).ID)
+	assert.Len(t, txPool.spenders, len(tx2.Vin))
+}
+
+func TestTransactionPool_PopSortedTransactionsClearsSpenders(t *testing.T) {
+	txPool := GenerateMockTransactionPool(3)
+	assert.Len(t, txPool.PopSortedTransactions(0, 0), 3)
+	assert.Len(t, txPool.spenders, 0)
+}
+
+// generateChildTransaction returns a transaction with the given tip spending all outputs of parent
+func generateChildTransaction(id string, parent *Transaction, tip uint64) *Transaction {
+	var vin []TXInput
+	for i := range parent.Vout {
+		vin = append(vin, TXInput{parent.ID, i, nil, nil, nil})
+	}
+	return &Transaction{[]byte(id), vin, MockTxOutputs(), tip}
+}
+
+func TestTransactionPool_PopSortedTransactionsParentsFirst(t *testing.T) {
+	txPool := NewTransactionPool()
+	parent := MockTransaction()
+	parent.ID, parent.Tip = []byte("parent"), 1
+	child := generateChildTransaction("child", parent, 10)
+	other := MockTransaction()
+	other.ID, other.Tip = []byte("other"), 5
+
+	assert.Nil(t, txPool.Push(*child))
+	assert.Nil(t, txPool.Push(*other))
+	assert.Nil(t, txPool.Push(*parent))
+
+	var popOrder [][]byte
+	for _, tx := range txPool.PopSortedTransactions(0, 0) {
+		popOrder = append(popOrder, tx.ID)
+	}
+	assert.Equal(t, [][]byte{parent.ID, child.ID, other.ID}, popOrder)
+}
+
+func TestTransactionPool_EvictDescendants(t *testing.T) {
+	txPool := NewTransactionPool()
+	parent := MockTransaction()
+	parent.ID = []byte("parent")
+	child := generateChildTransaction("child", parent, 1)
+	grandchild := generateChildTransaction("grandchild", child, 1)
+	for _, tx := range []*Transaction{parent, child, grandchild} {
+		assert.Nil(t, txPool.Push(*tx))
+	}
+
+	// Children stay in the pool when their parent is included in a block
+	txPool.RemoveMultipleTransactions([]*Transaction{parent})
+	assert.Equal(t, 2, txPool.transactions.Len())
+
+	// Descendants are evicted with a transaction replaced by fee
+	assert.Nil(t, txPool.Push(*parent))
+	replacement := Transaction{[]byte("replacement"), parent.Vin, MockTxOutputs(), parent.Tip + 1}
+	assert.Nil(t, txPool.Push(replacement))
+	assert.Equal(t, 1, txPool.transactions.Len())
+	assert.Nil(t, txPool.GetTransaction(child.ID))
+	assert.Nil(t, txPool.GetTransaction(grandchild.ID))
+
+	// Descendants are evicted with a transaction conflicting with a block
+	assert.Nil(t, txPool.Push(*generateChildTransaction("child2", &replacement, 1)))
+	txPool.RemoveConflictingTransactions([]*Transaction{parent})
+	assert.Equal(t, 0, txPool.transactions.Len())
+	assert.Len(t, txPool.spenders, 0)
+}
+
+func TestTransactionPool_ChainedTransactions(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+	txPool := bc.GetTxPool()
+
+	// The child spends the change of its parent before the parent is included in a block
+	parent, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, txPool.Push(parent))
+	child, err := NewUTXOTransaction(db, from, to, common.NewAmount(4), *keyPair, bc, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.ID, child.Vin[0].Txid)
+
+	utxoIndex := LoadUTXOIndex(db)
+	assert.False(t, child.Verify(utxoIndex, 1, 0))
+	assert.True(t, child.Verify(txPool.GetPendingUTXOIndex(utxoIndex, 1, 0), 1, 0))
+	assert.Nil(t, txPool.Push(child))
+
+	// Both are kept by the miner and can be included in the same block, parent first
+	txPool.FilterAllTransactions(utxoIndex, 1, 0)
+	assert.Equal(t, 2, txPool.transactions.Len())
+	txs := txPool.PopSortedTransactions(0, 0)
+	assert.Equal(t, parent.ID, txs[0].ID)
+	assert.Equal(t, child.ID, txs[1].ID)
+
+	tailBlock, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	cbtx := NewCoinbaseTX(from.Address, "", 1, 3)
+	assert.True(t, NewBlock(append(txs, &cbtx), tailBlock).VerifyTransactions(utxoIndex))
+	assert.False(t, NewBlock([]*Transaction{txs[1], txs[0], &cbtx}, tailBlock).VerifyTransactions(utxoIndex))
+
+	// The child is evicted with its parent
+	assert.Nil(t, txPool.Push(parent))
+	assert.Nil(t, txPool.Push(child))
+	txPool.FilterAllTransactions(NewUTXOIndex(), 1, 0)
+	assert.Equal(t, 0, txPool.transactions.Len())
+}
+
+func TestCompareTransactionFeeRates(t *testing.T) {
+	small := MockTransaction()
+	small.Tip = 10
+	large := *small
+	large.Vout = append(MockTxOutputs(), MockTxOutputs()...)
+
+	// The same tip pays less per byte in a larger transaction
+	assert.Equal(t, 1, CompareTransactionFeeRates(*small, large))
+	assert.Equal(t, -1, CompareTransactionFeeRates(large, *small))
+	assert.Equal(t, 0, CompareTransactionFeeRates(*small, *small))
+
+	large.Tip = uint64(large.GetSize())*small.Tip/uint64(small.GetSize()) + 1
+	assert.Equal(t, 1, CompareTransactionFeeRates(large, *small))
+}
+
+func TestTransactionPool_PopSortedTransactionsLimits(t *testing.T) {
+	txPool := NewTransactionPool()
+	high := MockTransaction()
+	high.ID, high.Tip = []byte("high"), 100
+	low := MockTransaction()
+	low.ID, low.Tip = []byte("low"), 1
+	parent := MockTransaction()
+	parent.ID, parent.Tip = []byte("parent"), 50
+	parent.Vout = append(parent.Vout, MockTxOutputs()...)
+	child := generateChildTransaction("child", parent, 1)
+	for _, tx := range []*Transaction{high, low, parent, child} {
+		assert.Nil(t, txPool.Push(*tx))
+	}
+
+	// The count limit keeps the transactions with the lowest tips per byte in the pool
+	txs := txPool.PopSortedTransactions(0, 1)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, high.ID, txs[0].ID)
+	assert.Equal(t, 3, txPool.transactions.Len())
+
+	// The parent doesn't fit, so its child is left in the pool with it while the smaller transaction is included
+	txs = txPool.PopSortedTransactions(GetEncodedSizeInBlock(low), 0)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, low.ID, txs[0].ID)
+	assert.NotNil(t, txPool.GetTransaction(parent.ID))
+	assert.NotNil(t, txPool.GetTransaction(child.ID))
+
+	txs = txPool.PopSortedTransactions(GetEncodedSizeInBlock(parent)+GetEncodedSizeInBlock(child), 0)
+	assert.Len(t, txs, 2)
+	assert.Equal(t, parent.ID, txs[0].ID)
+	assert.Equal(t, child.ID, txs[1].ID)
+	assert.Equal(t, 0, txPool.transactions.Len())
+}
+
+func TestTransactionPool_EvictLowestFeeRate(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.size = 2
+	tx1 := MockTransaction()
+	tx1.ID, tx1.Tip = []byte{1}, 10
+	tx2 := MockTransaction()
+	tx2.ID, tx2.Tip = []byte{2}, 20
+	assert.Nil(t, txPool.Push(*tx1))
+	assert.Nil(t, txPool.Push(*tx2))
+
+	// A transaction paying less per byte than all pooled ones is rejected
+	tx3 := MockTransaction()
+	tx3.ID, tx3.Tip = []byte{3}, 1
+	assert.Equal(t, ErrTransactionPoolFull, txPool.Push(*tx3))
+
+	// Otherwise it replaces the one paying the least per byte
+	tx3.Tip = 15
+	assert.Nil(t, txPool.Push(*tx3))
+	assert.Equal(t, 2, txPool.transactions.Len())
+	assert.Nil(t, txPool.GetTransaction(tx1.ID))
+	assert.NotNil(t, txPool.GetTransaction(tx3.ID))
+}
+
+func TestTransactionPool_EvictLowestFeeRateKeepsAncestors(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.size = 2
+	parent := MockTransaction()
+	parent.ID, parent.Tip = []byte("parent"), 1
+	other := MockTransaction()
+	other.ID, other.Tip = []byte("other"), 10
+	assert.Nil(t, txPool.Push(*parent))
+	assert.Nil(t, txPool.Push(*other))
+
+	// The parent pays the least per byte, but evicting it would leave its child without the outputs it spends
+	child := generateChildTransaction("child", parent, 100)
+	assert.Nil(t, txPool.Push(*child))
+	assert.Equal(t, 2, txPool.transactions.Len())
+	assert.NotNil(t, txPool.GetTransaction(parent.ID))
+	assert.NotNil(t, txPool.GetTransaction(child.ID))
+	assert.Nil(t, txPool.GetTransaction(other.ID))
+
+	// A transaction is rejected when only its ancestors could make room for it
+	grandchild := generateChildTransaction("grandchild", child, 1000)
+	assert.Equal(t, ErrTransactionPoolFull, txPool.Push(*grandchild))
+	assert.NotNil(t, txPool.GetTransaction(parent.ID))
+	assert.NotNil(t, txPool.GetTransaction(child.ID))
+}
+
+func TestTransactionPool_SaveToDatabase(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+	txPool := bc.GetTxPool()
+
+	parent, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, txPool.Push(parent))
+	child, err := NewUTXOTransaction(db, from, to, common.NewAmount(4), *keyPair, bc, 2)
+	assert.Nil(t, err)
+	assert.Nil(t, txPool.Push(child))
+	invalid := MockTransaction()
+	invalid.ID = []byte("invalid")
+	assert.Nil(t, txPool.Push(*invalid))
+	assert.Nil(t, txPool.SaveToDatabase(db))
+
+	loaded := LoadTxPoolFromDatabase(db)
+	assert.Equal(t, 3, loaded.Len())
+	assert.Equal(t, child, *loaded.GetTransaction(child.ID))
+
+	// Saved transactions are verified again when the blockchain is loaded
+	bc, err = GetBlockchain(db, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, bc.GetTxPool().Len())
+	assert.NotNil(t, bc.GetTxPool().GetTransaction(parent.ID))
+	assert.NotNil(t, bc.GetTxPool().GetTransaction(child.ID))
+
+	// A database without saved transactions starts with an empty pool
+	assert.Equal(t, 0, LoadTxPoolFromDatabase(storage.NewRamStorage()).Len())
+}
+
+func TestTransactionPool_SavedWithEveryBlock(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+
+	tx, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, bc.GetTxPool().Push(tx))
+
+	// The pool is reloaded after adding a block even though SaveToDatabase was never called
+	tailBlock, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	cbtx := NewCoinbaseTX(from.Address, "", 1, 0)
+	blk := NewBlock([]*Transaction{&cbtx}, tailBlock)
+	blk.SetHash(blk.CalculateHash())
+	assert.Nil(t, bc.AddBlockToTail(blk))
+
+	bc, err = GetBlockchain(db, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, bc.GetTxPool().Len())
+	assert.NotNil(t, bc.GetTxPool().GetTransaction(tx.ID))
+}
+
+func TestTransactionPool_RemoveExpiredTransactions(t *testing.T) {
+	txPool := NewTransactionPool()
+	parent := MockTransaction()
+	parent.ID = []byte("parent")
+	child := generateChildTransaction("child", parent, 1)
+	other := MockTransaction()
+	other.ID = []byte("other")
+	for _, tx := range []*Transaction{parent, child, other} {
+		assert.Nil(t, txPool.Push(*tx))
+	}
+
+	// The parent expires and its child can't be included without it
+	txPool.SetTTL(time.Hour)
+	txPool.addedTimes[string(parent.ID)] = time.Now().Add(-2 * time.Hour).Unix()
+	txPool.RemoveExpiredTransactions()
+	assert.Equal(t, 1, txPool.transactions.Len())
+	assert.NotNil(t, txPool.GetTransaction(other.ID))
+	assert.Equal(t, uint64(1), txPool.GetStats().Expired)
+
+	// Expiry times are kept across restarts
+	txPool.addedTimes[string(other.ID)] = time.Now().Add(-2 * time.Hour).Unix()
+	db := storage.NewRamStorage()
+	defer db.Close()
+	assert.Nil(t, txPool.SaveToDatabase(db))
+	loaded := LoadTxPoolFromDatabase(db)
+	assert.Equal(t, 1, loaded.Len())
+	loaded.SetTTL(time.Hour)
+	loaded.RemoveExpiredTransactions()
+	assert.Equal(t, 0, loaded.Len())
+}
+
+func TestTransactionPool_MaxTransactionsPerAddress(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.SetMaxTransactionsPerAddress(2)
+	pubKey := NewKeyPair().PublicKey
+
+	var txs []*Transaction
+	for i := 0; i < 3; i++ {
+		tx := &Transaction{[]byte{byte(i)}, MockTxInputsWithPubkey(pubKey), MockTxOutputs(), 5}
+		txs = append(txs, tx)
+	}
+	assert.Nil(t, txPool.Push(*txs[0]))
+	assert.Nil(t, txPool.Push(*txs[1]))
+	assert.Equal(t, ErrTooManyTransactions, txPool.Push(*txs[2]))
+
+	// Other senders are not affected
+	assert.Nil(t, txPool.Push(Transaction{[]byte("other"), MockTxInputsWithPubkey(NewKeyPair().PublicKey), MockTxOutputs(), 5}))
+
+	// A sender can replace its own transactions
+	replacement := Transaction{[]byte("replacement"), txs[1].Vin, MockTxOutputs(), txs[1].Tip + 1}
+	assert.Nil(t, txPool.Push(replacement))
+
+	// Slots are freed once the transactions leave the pool
+	txPool.RemoveMultipleTransactions([]*Transaction{txs[0]})
+	assert.Nil(t, txPool.Push(*txs[2]))
+	assert.Equal(t, 3, txPool.transactions.Len())
+}
+
+func TestTransactionPool_Stats(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.SetMinTip(5)
+
+	tx := MockTransaction()
+	tx.Tip = 4
+	assert.Equal(t, ErrTipTooLow, txPool.Push(*tx))
+	tx.Tip = 5
+	assert.Nil(t, txPool.Push(*tx))
+	assert.Equal(t, ErrDuplicateTransaction, txPool.Push(*tx))
+
+	assert.Equal(t, TransactionPoolStats{Admitted: 1, Rejected: 2}, txPool.GetStats())
+}
+
+func TestTransactionPool_Subscribe(t *testing.T) {
+	txPool := NewTransactionPool()
+	txCh := txPool.Subscribe()
+
+	tx := MockTransaction()
+	assert.Nil(t, txPool.Push(*tx))
+	assert.Equal(t, ErrDuplicateTransaction, txPool.Push(*tx))
+
+	// Only admitted transactions are published
+	assert.Equal(t, tx.ID, (<-txCh).ID)
+	assert.Len(t, txCh, 0)
+
+	txPool.Unsubscribe(txCh)
+	_, ok := <-txCh
+	assert.False(t, ok)
+	assert.Nil(t, txPool.Push(*MockTransaction()))
+}
+
+func TestTransactionPool_PushTransaction(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+	txPool := bc.GetTxPool()
+	txCh := txPool.Subscribe()
+	txPool.Start()
+	defer txPool.Stop()
+
+	// Queued transactions are verified before they are admitted
+	invalid := MockTransaction()
+	txPool.PushTransaction(*invalid, peer.ID("peer1"))
+	tx, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	txPool.PushTransaction(tx, peer.ID("peer2"))
+
+	select {
+	case admitted := <-txCh:
+		assert.Equal(t, tx.ID, admitted.ID)
+	case <-time.After(time.Second):
+		t.Fatal("transaction is not admitted")
+	}
+	assert.Equal(t, 1, txPool.Len())
+	assert.Equal(t, TransactionPoolStats{Admitted: 1, Rejected: 1}, txPool.GetStats())
+
+	// The peers sending invalid transactions are reported
+	select {
+	case rejected := <-txPool.InvalidTxCh():
+		assert.Equal(t, invalid.ID, rejected.Tx.ID)
+		assert.Equal(t, peer.ID("peer1"), rejected.Pid)
+	default:
+		t.Fatal("invalid transaction is not reported")
+	}
+}
+
+func TestTransactionPool_VerifyTransactionReplacement(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+	txPool := bc.GetTxPool()
+
+	tx, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	assert.True(t, txPool.VerifyTransaction(&tx))
+
+	// A transaction spending the same outputs is verified as if it replaced the pooled one
+	replacement, err := NewUTXOTransaction(db, from, to, common.NewAmount(2), *keyPair, bc, 2)
+	assert.Nil(t, err)
+	assert.Nil(t, txPool.Push(tx))
+	assert.True(t, txPool.VerifyTransaction(&replacement))
+	assert.Nil(t, txPool.Push(replacement))
+	assert.Nil(t, txPool.GetTransaction(tx.ID))
+
+	// Pools without a blockchain can't verify transactions
+	assert.False(t, NewTransactionPool().VerifyTransaction(&replacement))
+}
+
+func TestTransactionPool_VerifyAndPush(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	keyPair := NewKeyPair()
+	from := keyPair.GenerateAddress()
+	to := NewAddress("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	bc := CreateBlockchain(from, db, nil)
+	txPool := bc.GetTxPool()
+
+	parent, err := NewUTXOTransaction(db, from, to, common.NewAmount(3), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, txPool.VerifyAndPush(parent))
+	child, err := NewUTXOTransaction(db, from, to, common.NewAmount(4), *keyPair, bc, 2)
+	assert.Nil(t, err)
+
+	// The child can't be admitted once the parent whose change it spends has left the pool
+	txPool.RemoveConflictingTransactions([]*Transaction{&parent})
+	assert.Equal(t, ErrInvalidTransaction, txPool.VerifyAndPush(child))
+	assert.Equal(t, 0, txPool.Len())
+	assert.Equal(t, TransactionPoolStats{Admitted: 1, Rejected: 1}, txPool.GetStats())
+}
+
+func TestTransactionPool_StopTwice(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.Start()
+	txPool.Stop()
+	txPool.Stop()
+}
+
+func TestTransactionPool_ConcurrentAccess(t *testing.T) {
+	txPool := NewTransactionPool()
+	txPool.Start()
+	defer txPool.Stop()
+
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func(i int) {
+			for j := 0; j < 50; j++ {
+				tx := MockTransaction()
+				tx.ID = []byte{byte(i), byte(j)}
+				txPool.Push(*tx)
+				txPool.PushTransaction(*MockTransaction(), "")
+				txPool.GetTransaction(tx.ID)
+				txPool.PopSortedTransactions(0, 1)
+				txPool.FilterAllTransactions(NewUTXOIndex(), 1, 0)
+			}
+			done <- true
+		}(i)
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	assert.Equal(t, 0, txPool.Len())
+}
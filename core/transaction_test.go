@@ -21,6 +21,7 @@ package core
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"testing"
 
 	"encoding/binary"
@@ -39,15 +40,15 @@ func getAoB(length int64) []byte {
 
 func GenerateFakeTxInputs() []TXInput {
 	return []TXInput{
-		{getAoB(2), 10, getAoB(2), getAoB(2)},
-		{getAoB(2), 5, getAoB(2), getAoB(2)},
+		{getAoB(2), 10, getAoB(2), getAoB(2), nil},
+		{getAoB(2), 5, getAoB(2), getAoB(2), nil},
 	}
 }
 
 func GenerateFakeTxOutputs() []TXOutput {
 	return []TXOutput{
-		{common.NewAmount(1), getAoB(2)},
-		{common.NewAmount(2), getAoB(2)},
+		{common.NewAmount(1), getAoB(2), nil},
+		{common.NewAmount(2), getAoB(2), nil},
 	}
 }
 
@@ -61,7 +62,7 @@ func TestTrimmedCopy(t *testing.T) {
 
 	t2 := t1.TrimmedCopy()
 
-	t3 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0)
+	t3 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0, 0)
 	t4 := t3.TrimmedCopy()
 	assert.Equal(t, t1.ID, t2.ID)
 	assert.Equal(t, t1.Tip, t2.Tip)
@@ -94,8 +95,8 @@ func TestSign(t *testing.T) {
 
 	// Previous transactions containing UTXO of the address
 	prevTXs := map[string]Transaction{
-		"01": NewCoinbaseTX(address.Address, "", 1),
-		"02": NewCoinbaseTX(address.Address, "", 2),
+		"01": NewCoinbaseTX(address.Address, "", 1, 0),
+		"02": NewCoinbaseTX(address.Address, "", 2, 0),
 		"03": {
 			[]byte{3},
 			[]TXInput{},
@@ -110,12 +111,12 @@ func TestSign(t *testing.T) {
 
 	// New transaction to be signed (paid from the fake account)
 	txin := []TXInput{
-		{[]byte{1}, 0, nil, pubKey},
-		{[]byte{3}, 0, nil, pubKey},
-		{[]byte{3}, 2, nil, pubKey},
+		{[]byte{1}, 0, nil, pubKey, nil},
+		{[]byte{3}, 0, nil, pubKey, nil},
+		{[]byte{3}, 2, nil, pubKey, nil},
 	}
 	txout := []TXOutput{
-		{common.NewAmount(19), pubKeyHash},
+		{common.NewAmount(19), pubKeyHash, nil},
 	}
 	tx := Transaction{nil, txin, txout, 0}
 
@@ -146,13 +147,13 @@ func TestSign_Invalid(t *testing.T) {
 	address := KeyPair{*privKey, pubKey}.GenerateAddress()
 
 	// Previous transactions containing UTXO of the address
-	prevTXs := map[string]Transaction{"01": NewCoinbaseTX(address.Address, "", 1)}
+	prevTXs := map[string]Transaction{"01": NewCoinbaseTX(address.Address, "", 1, 0)}
 
 	// New transaction to be signed (paid from the fake account)
-	txin := []TXInput{{[]byte{1}, 0, nil, pubKey}}
-	txin1 := append(txin, TXInput{[]byte{1}, 1, nil, pubKey}) // Invalid
-	txin2 := append(txin, TXInput{[]byte{3}, 2, nil, pubKey}) // Invalid
-	txout := []TXOutput{{common.NewAmount(16), pubKeyHash}}
+	txin := []TXInput{{[]byte{1}, 0, nil, pubKey, nil}}
+	txin1 := append(txin, TXInput{[]byte{1}, 1, nil, pubKey, nil}) // Invalid
+	txin2 := append(txin, TXInput{[]byte{3}, 2, nil, pubKey, nil}) // Invalid
+	txout := []TXOutput{{common.NewAmount(16), pubKeyHash, nil}}
 
 	tests := []struct {
 		name        string
@@ -210,28 +211,42 @@ func TestVerifyCoinbaseTransaction(t *testing.T) {
 	prevTXs[string(t3.ID)] = t4
 
 	// test verifying coinbase transactions
-	var t5 = NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 5)
+	var t5 = NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 5, 0)
 	bh1 := make([]byte, 8)
 	binary.BigEndian.PutUint64(bh1, 5)
-	txin1 := TXInput{nil, -1, bh1, []byte(nil)}
+	txin1 := TXInput{nil, -1, bh1, []byte(nil), nil}
 	txout1 := NewTXOutput(common.NewAmount(10), "13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
 	var t6 = Transaction{nil, []TXInput{txin1}, []TXOutput{*txout1}, 0}
 
 	// test valid coinbase transaction
-	assert.True(t, t5.Verify(UTXOIndex{}, 5))
-	assert.True(t, t6.Verify(UTXOIndex{}, 5))
+	assert.True(t, t5.Verify(UTXOIndex{}, 5, 0))
+	assert.True(t, t6.Verify(UTXOIndex{}, 5, 0))
 
 	// test coinbase transaction with incorrect blockHeight
-	assert.False(t, t5.Verify(UTXOIndex{}, 10))
+	assert.False(t, t5.Verify(UTXOIndex{}, 10, 0))
 
 	// test coinbase transaction with incorrect subsidy
 	bh2 := make([]byte, 8)
 	binary.BigEndian.PutUint64(bh2, 5)
-	txin2 := TXInput{nil, -1, bh2, []byte(nil)}
+	txin2 := TXInput{nil, -1, bh2, []byte(nil), nil}
 	txout2 := NewTXOutput(common.NewAmount(20), "13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
 	var t7 = Transaction{nil, []TXInput{txin2}, []TXOutput{*txout2}, 0}
-	assert.False(t, t7.Verify(UTXOIndex{}, 5))
-
+	assert.False(t, t7.Verify(UTXOIndex{}, 5, 0))
+
+	// test coinbase transaction claiming fees
+	var t8 = NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 5, 3)
+	assert.Equal(t, common.NewAmount(13), t8.Vout[0].Value)
+	assert.True(t, t8.Verify(UTXOIndex{}, 5, 0))
+	t8.Tip = 4
+	assert.False(t, t8.Verify(UTXOIndex{}, 5, 0))
+
+	// test coinbase transaction with a signature too short to hold the blockHeight
+	txin3 := TXInput{nil, -1, []byte{5}, []byte(nil), nil}
+	txout3 := NewTXOutput(common.NewAmount(10), "13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F")
+	var t9 = Transaction{nil, []TXInput{txin3}, []TXOutput{*txout3}, 0}
+	assert.False(t, t9.Verify(UTXOIndex{}, 5, 0))
+	t9.Vin[0].Signature = nil
+	assert.False(t, t9.Verify(UTXOIndex{}, 5, 0))
 }
 
 func TestVerifyNoCoinbaseTransaction(t *testing.T) {
@@ -249,22 +264,18 @@ func TestVerifyNoCoinbaseTransaction(t *testing.T) {
 	//wrongPubKeyHash, _ := HashPubKey(wrongPubKey)
 	//wrongAddress := KeyPair{*wrongPrivKey, wrongPubKey}.GenerateAddress()
 	utxoIndex := NewUTXOIndex()
-	utxoIndex.index = map[string][]*UTXO{
-		string(pubKeyHash): []*UTXO{
-			&UTXO{common.NewAmount(4), pubKeyHash, []byte{1}, 0},
-			&UTXO{common.NewAmount(3), pubKeyHash, []byte{2}, 1},
-		},
-	}
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(4), pubKeyHash, nil}, []byte{1}, 0)
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(3), pubKeyHash, nil}, []byte{2}, 1)
 
 	// Prepare a transaction to be verified
-	txin := []TXInput{{[]byte{1}, 0, nil, pubKey}}
-	txin1 := append(txin, TXInput{[]byte{2}, 1, nil, pubKey})      // Normal test
-	txin2 := append(txin, TXInput{[]byte{2}, 1, nil, wrongPubKey}) // previous not found with wrong pubkey
-	txin3 := append(txin, TXInput{[]byte{3}, 1, nil, pubKey})      // previous not found with wrong Txid
-	txin4 := append(txin, TXInput{[]byte{2}, 2, nil, pubKey})      // previous not found with wrong TxIndex
-	txout := []TXOutput{{common.NewAmount(7), pubKey}}
-	//TODO  Reopen Invalid Amount Testcase when refactor AddBalance
-	//txout2 := []TXOutput{{common.NewAmount(8), pubKey}} //Vout amount > Vin amount
+	txin := []TXInput{{[]byte{1}, 0, nil, pubKey, nil}}
+	txin1 := append(txin, TXInput{[]byte{2}, 1, nil, pubKey, nil})      // Normal test
+	txin2 := append(txin, TXInput{[]byte{2}, 1, nil, wrongPubKey, nil}) // previous not found with wrong pubkey
+	txin3 := append(txin, TXInput{[]byte{3}, 1, nil, pubKey, nil})      // previous not found with wrong Txid
+	txin4 := append(txin, TXInput{[]byte{2}, 2, nil, pubKey, nil})      // previous not found with wrong TxIndex
+	txout := []TXOutput{{common.NewAmount(7), pubKey, nil}}
+	txout2 := []TXOutput{{common.NewAmount(8), pubKey, nil}} //Vout amount > Vin amount
+	txout3 := []TXOutput{{common.NewAmount(5), pubKey, nil}} //Vin amount - Vout amount = fee
 
 	tests := []struct {
 		name     string
@@ -276,7 +287,10 @@ func TestVerifyNoCoinbaseTransaction(t *testing.T) {
 		{"previous tx not found with wrong pubkey", Transaction{nil, txin2, txout, 0}, privKeyByte, false},
 		{"previous tx not found with wrong Txid", Transaction{nil, txin3, txout, 0}, privKeyByte, false},
 		{"previous tx not found with wrong TxIndex", Transaction{nil, txin4, txout, 0}, privKeyByte, false},
-		//{"Amount invalid", Transaction{nil, txin1, txout2, 0}, privKeyByte, false},
+		{"Amount invalid", Transaction{nil, txin1, txout2, 0}, privKeyByte, false},
+		{"Fee equals tip", Transaction{nil, txin1, txout3, 2}, privKeyByte, true},
+		{"Fee less than tip", Transaction{nil, txin1, txout3, 3}, privKeyByte, false},
+		{"Fee greater than tip", Transaction{nil, txin1, txout3, 1}, privKeyByte, false},
 		{"Sign invalid", Transaction{nil, txin1, txout, 0}, wrongPrivKeyByte, false},
 	}
 	for _, tt := range tests {
@@ -291,28 +305,28 @@ func TestVerifyNoCoinbaseTransaction(t *testing.T) {
 			}
 
 			// Verify the signatures
-			result := tt.tx.Verify(utxoIndex, 0)
+			result := tt.tx.Verify(utxoIndex, 0, 0)
 			assert.Equal(t, tt.ok, result)
 		})
 	}
 }
 
 func TestNewCoinbaseTX(t *testing.T) {
-	t1 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0)
-	expectVin := TXInput{nil, -1, []byte{0, 0, 0, 0, 0, 0, 0, 0}, []byte("Reward to '13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F'")}
-	expectVout := TXOutput{common.NewAmount(10), []byte{0x1c, 0x11, 0xfe, 0x6b, 0x98, 0x1, 0x56, 0xc5, 0x83, 0xec, 0xb1, 0xfc, 0x32, 0xdb, 0x28, 0x79, 0xb, 0x52, 0xeb, 0x2d}}
+	t1 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0, 0)
+	expectVin := TXInput{nil, -1, []byte{0, 0, 0, 0, 0, 0, 0, 0}, []byte("Reward to '13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F'"), nil}
+	expectVout := TXOutput{common.NewAmount(10), []byte{0x1c, 0x11, 0xfe, 0x6b, 0x98, 0x1, 0x56, 0xc5, 0x83, 0xec, 0xb1, 0xfc, 0x32, 0xdb, 0x28, 0x79, 0xb, 0x52, 0xeb, 0x2d}, nil}
 	assert.Equal(t, 1, len(t1.Vin))
 	assert.Equal(t, expectVin, t1.Vin[0])
 	assert.Equal(t, 1, len(t1.Vout))
 	assert.Equal(t, expectVout, t1.Vout[0])
 	assert.Equal(t, uint64(0), t1.Tip)
 
-	t2 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0)
+	t2 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0, 0)
 
 	// Assert that NewCoinbaseTX is deterministic (i.e. >1 coinbaseTXs in a block would have identical txid)
 	assert.Equal(t, t1, t2)
 
-	t3 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 1)
+	t3 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 1, 0)
 
 	assert.NotEqual(t, t1, t3)
 	assert.NotEqual(t, t1.ID, t3.ID)
@@ -329,7 +343,7 @@ func TestIsCoinBase(t *testing.T) {
 
 	assert.False(t, t1.IsCoinbase())
 
-	t2 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0)
+	t2 := NewCoinbaseTX("13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F", "", 0, 0)
 
 	assert.True(t, t2.IsCoinbase())
 
@@ -360,6 +374,27 @@ func TestTransaction_Proto(t *testing.T) {
 	assert.Equal(t, t1, t2)
 }
 
+func TestTransaction_Serialize(t *testing.T) {
+	tx := Transaction{
+		ID:   []byte{0xff},
+		Vin:  []TXInput{{[]byte{1}, 0, nil, nil, nil}},
+		Vout: []TXOutput{{common.NewAmount(5), []byte{2}, nil}},
+		Tip:  3,
+	}
+
+	// Fields are encoded in field number order as defined in core/pb/transaction.proto
+	expected := []byte{0x0a, 0x01, 0xff, 0x12, 0x03, 0x0a, 0x01, 0x01, 0x1a, 0x06, 0x0a, 0x01, 0x05, 0x12, 0x01, 0x02, 0x20, 0x03}
+	assert.Equal(t, expected, tx.Serialize())
+
+	// The ID is excluded from the hash
+	hash := sha256.Sum256(expected[3:])
+	assert.Equal(t, hash[:], tx.Hash())
+
+	decoded, err := DeserializeTransaction(tx.Serialize())
+	assert.Nil(t, err)
+	assert.Equal(t, tx, decoded)
+}
+
 func TestTransaction_FindTxInUtxoPool(t *testing.T) {
 	//prepare utxo pool
 	pubkey := []byte("12345678901234567890123456789012")
@@ -367,12 +402,11 @@ func TestTransaction_FindTxInUtxoPool(t *testing.T) {
 
 	Txin := MockTxInputsWithPubkey(pubkey)
 	Txin2 := MockTxInputsWithPubkey(pubkey)
-	utxo1 := &UTXO{common.NewAmount(10), pubkeyHash, Txin[0].Txid, Txin[0].Vout}
-	utxo2 := &UTXO{common.NewAmount(9), pubkeyHash, Txin[1].Txid, Txin[1].Vout}
-	utxo3 := &UTXO{common.NewAmount(9), pubkeyHash, Txin2[0].Txid, Txin2[0].Vout}
-	utxo4 := &UTXO{common.NewAmount(9), pubkeyHash, Txin2[1].Txid, Txin2[1].Vout}
 	utxoPool := NewUTXOIndex()
-	utxoPool.index[string(pubkeyHash)] = []*UTXO{utxo1, utxo2, utxo3, utxo4}
+	utxoPool.addUTXO(TXOutput{common.NewAmount(10), pubkeyHash, nil}, Txin[0].Txid, Txin[0].Vout)
+	utxoPool.addUTXO(TXOutput{common.NewAmount(9), pubkeyHash, nil}, Txin[1].Txid, Txin[1].Vout)
+	utxoPool.addUTXO(TXOutput{common.NewAmount(9), pubkeyHash, nil}, Txin2[0].Txid, Txin2[0].Vout)
+	utxoPool.addUTXO(TXOutput{common.NewAmount(9), pubkeyHash, nil}, Txin2[1].Txid, Txin2[1].Vout)
 
 	tx := MockTransaction()
 	txins, _ := tx.FindAllTxinsInUtxoPool(utxoPool)
@@ -382,31 +416,3 @@ func TestTransaction_FindTxInUtxoPool(t *testing.T) {
 	assert.NotNil(t, txins)
 }
 
-func TestNewUTXOTransactionforAddBalance(t *testing.T) {
-	receiverAddr := "13ZRUc4Ho3oK3Cw56PhE5rmaum9VBeAn5F"
-	testCases := []struct {
-		name        string
-		amount      *common.Amount
-		tx          Transaction
-		expectedErr error
-	}{
-		{"Add 13", common.NewAmount(13), Transaction{nil, []TXInput(nil), []TXOutput{*NewTXOutput(common.NewAmount(13), receiverAddr)}, 0}, nil},
-		{"Add 1", common.NewAmount(1), Transaction{nil, []TXInput(nil), []TXOutput{*NewTXOutput(common.NewAmount(1), receiverAddr)}, 0}, nil},
-		{"Add 0", common.NewAmount(0), Transaction{}, ErrInvalidAmount},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			tx, err := NewUTXOTransactionforAddBalance(Address{receiverAddr}, tc.amount)
-			if tc.expectedErr == nil {
-				assert.NoError(t, err)
-				assert.Equal(t, tc.tx.Vin, tx.Vin)
-				assert.Equal(t, tc.tx.Vout, tx.Vout)
-				assert.Equal(t, tc.tx.Tip, tx.Tip)
-			} else {
-				assert.Error(t, err)
-				assert.Equal(t, tc.expectedErr, err)
-				assert.Equal(t, tc.tx, tx)
-			}
-		})
-	}
-}
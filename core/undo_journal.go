@@ -0,0 +1,123 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+
+	"github.com/dappley/go-dappley/storage"
+	logger "github.com/sirupsen/logrus"
+)
+
+const undoJournalKeyPrefix = "undoJournal"
+
+var (
+	ErrUndoJournalNotFound = errors.New("ERROR: Undo journal not found")
+)
+
+// UndoJournal records the UTXOs consumed by a block, so that the block can be undone without looking up the
+// transactions it spends.
+type UndoJournal struct {
+	SpentUTXOs []*UTXO
+}
+
+func getUndoJournalKey(blockHash Hash) []byte {
+	return append([]byte(undoJournalKeyPrefix), blockHash...)
+}
+
+func (journal *UndoJournal) serialize() []byte {
+	var encoded bytes.Buffer
+	enc := gob.NewEncoder(&encoded)
+	err := enc.Encode(journal)
+	if err != nil {
+		logger.Panic(err)
+	}
+	return encoded.Bytes()
+}
+
+func deserializeUndoJournal(d []byte) (*UndoJournal, error) {
+	journal := &UndoJournal{}
+	decoder := gob.NewDecoder(bytes.NewReader(d))
+	err := decoder.Decode(journal)
+	return journal, err
+}
+
+// GetUndoJournal returns the undo journal of the block identified by blockHash
+func GetUndoJournal(db storage.Storage, blockHash Hash) (*UndoJournal, error) {
+	rawBytes, err := db.Get(getUndoJournalKey(blockHash))
+	if err != nil {
+		return nil, ErrUndoJournalNotFound
+	}
+	return deserializeUndoJournal(rawBytes)
+}
+
+func putUndoJournal(db storage.Storage, blockHash Hash, journal *UndoJournal) error {
+	return db.Put(getUndoJournalKey(blockHash), journal.serialize())
+}
+
+func delUndoJournal(db storage.Storage, blockHash Hash) error {
+	return db.Del(getUndoJournalKey(blockHash))
+}
+
+// loadUndoJournal returns the undo journal of blk. Blocks stored before undo journals existed have their journal
+// rebuilt by looking up the transactions spent by their inputs.
+func loadUndoJournal(db storage.Storage, bc *Blockchain, blk *Block) (*UndoJournal, error) {
+	journal, err := GetUndoJournal(db, blk.GetHash())
+	if err != ErrUndoJournalNotFound {
+		return journal, err
+	}
+
+	journal = &UndoJournal{}
+	for _, tx := range blk.GetTransactions() {
+		if tx.IsCoinbase() {
+			continue
+		}
+		for _, vin := range tx.Vin {
+			utxo, err := getUTXOSpent(vin, bc)
+			if err != nil {
+				return nil, err
+			}
+			journal.SpentUTXOs = append(journal.SpentUTXOs, utxo)
+		}
+	}
+	return journal, nil
+}
+
+// getUTXOSpent rebuilds the UTXO consumed by in, with the height and timestamp of the block that created it
+func getUTXOSpent(in TXInput, bc *Blockchain) (*UTXO, error) {
+	errNotFound := errors.New("txInput refers to non-existing transaction")
+	txIndex, err := GetTxIndex(bc.db, in.Txid)
+	if err != nil {
+		return nil, errNotFound
+	}
+	blk, err := bc.GetBlockByHash(txIndex.BlockId)
+	if err != nil {
+		return nil, errNotFound
+	}
+	tx, err := getTransactionFromBlock(blk, txIndex.BlockIndex, in.Txid)
+	if err != nil || in.Vout < 0 || in.Vout >= len(tx.Vout) {
+		return nil, errNotFound
+	}
+	utxo := newUTXO(tx.Vout[in.Vout], in.Txid, in.Vout)
+	utxo.Height = blk.GetHeight()
+	utxo.Timestamp = blk.GetTimestamp()
+	return utxo, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/dappley/go-dappley/common"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/dappley/go-dappley/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchain_AddBlockToTailStoresUndoJournal(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := CreateBlockchain(addr, s, nil)
+
+	blk1 := GenerateUtxoMockBlockWithoutInputs()
+	bc.AddBlockToTail(blk1)
+	blk2 := GenerateUtxoMockBlockWithInputs()
+	bc.AddBlockToTail(blk2)
+
+	journal, err := GetUndoJournal(s, blk2.GetHash())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(journal.SpentUTXOs))
+	assert.Equal(t, common.NewAmount(5), journal.SpentUTXOs[0].Value)
+	assert.Equal(t, common.NewAmount(7), journal.SpentUTXOs[1].Value)
+
+	journal, err = GetUndoJournal(s, blk1.GetHash())
+	assert.Nil(t, err)
+	assert.Empty(t, journal.SpentUTXOs)
+}
+
+func TestBlockchain_RollbackRestoresUTXOIndex(t *testing.T) {
+	tests := []struct {
+		name          string
+		legacyJournal bool
+	}{
+		{"with undo journal", false},
+		{"without undo journal", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.NewRamStorage()
+			defer s.Close()
+
+			addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+			bc := CreateBlockchain(addr, s, nil)
+
+			blk1 := GenerateUtxoMockBlockWithoutInputs()
+			bc.AddBlockToTail(blk1)
+			blk2 := GenerateUtxoMockBlockWithInputs()
+			bc.AddBlockToTail(blk2)
+
+			if tt.legacyJournal {
+				// Simulate a block stored before undo journals existed
+				s.Del(getUndoJournalKey(blk2.GetHash()))
+			}
+
+			assert.True(t, bc.Rollback(blk1.GetHash()))
+			assert.Equal(t, blk1.GetHash(), bc.GetTailBlockHash())
+
+			utxoIndex := LoadUTXOIndex(s)
+			addr1UTXOs := utxoIndex.GetUTXOsByPubKeyHash(address1Hash)
+			assert.Equal(t, 2, len(addr1UTXOs))
+			assert.Equal(t, common.NewAmount(5), addr1UTXOs[0].Value)
+			assert.Equal(t, common.NewAmount(7), addr1UTXOs[1].Value)
+			for _, u := range addr1UTXOs {
+				assert.Equal(t, blk1.GetHeight(), u.Height)
+				assert.Equal(t, blk1.GetTimestamp(), u.Timestamp)
+			}
+			assert.Equal(t, 0, len(utxoIndex.GetUTXOsByPubKeyHash(address2Hash)))
+
+			_, err := s.Get(util.UintToHex(blk2.GetHeight()))
+			assert.Equal(t, storage.ErrKeyInvalid, err)
+			_, err = GetUndoJournal(s, blk2.GetHash())
+			assert.Equal(t, ErrUndoJournalNotFound, err)
+			_, err = GetTxIndex(s, blk2.GetTransactions()[0].ID)
+			assert.Equal(t, ErrTransactionNotFound, err)
+		})
+	}
+}
+
+func TestBlockchain_RollbackFailedKeepsState(t *testing.T) {
+	s := storage.NewRamStorage()
+	defer s.Close()
+
+	addr := NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := CreateBlockchain(addr, s, nil)
+	genesisHash := bc.GetTailBlockHash()
+
+	blk1 := GenerateUtxoMockBlockWithoutInputs()
+	bc.AddBlockToTail(blk1)
+	blk2 := GenerateUtxoMockBlockWithInputs()
+	bc.AddBlockToTail(blk2)
+
+	// Genesis block is stored but is not an ancestor of blk1
+	assert.False(t, bc.Rollback(genesisHash))
+	assert.Equal(t, blk2.GetHash(), bc.GetTailBlockHash())
+
+	utxoIndex := LoadUTXOIndex(s)
+	assert.Equal(t, 1, len(utxoIndex.GetUTXOsByPubKeyHash(address1Hash)))
+	assert.Equal(t, 2, len(utxoIndex.GetUTXOsByPubKeyHash(address2Hash)))
+	_, err := GetUndoJournal(s, blk2.GetHash())
+	assert.Nil(t, err)
+	_, err = GetTxIndex(s, blk2.GetTransactions()[0].ID)
+	assert.Nil(t, err)
+}
@@ -20,77 +20,153 @@ package core
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/gob"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
-	"github.com/jinzhu/copier"
 	logger "github.com/sirupsen/logrus"
 
 	"github.com/dappley/go-dappley/common"
 	"github.com/dappley/go-dappley/storage"
 )
 
-const utxoMapKey = "utxo"
+const (
+	utxoKeyPrefix      = "utxo_"
+	utxoOwnerKeyPrefix = "utxoOwner_"
+	// legacyUtxoMapKey is the key under which older versions stored the whole index as one gob blob.
+	legacyUtxoMapKey = "utxo"
+)
 
-// UTXOIndex holds all unspent TXOutputs indexed by public key hash.
+// UTXOIndex holds all unspent TXOutputs. Each UTXO is stored in db under its outpoint (txid and vout) together with
+// a secondary entry prefixed by its public key hash. Changes made to the index are kept in memory until saved, and
+// only those changes are written to db.
 type UTXOIndex struct {
-	index map[string][]*UTXO
-	mutex *sync.RWMutex
+	db      storage.Storage
+	added   map[string]*UTXO // UTXOs created since the index was loaded, keyed by outpoint
+	removed map[string]*UTXO // UTXOs in db that have been spent since the index was loaded, keyed by outpoint
+	mutex   *sync.RWMutex
 }
 
-
-// UTXO contains the meta info of an unspent TXOutput.
+// UTXO contains the meta info of an unspent TXOutput. Height and Timestamp are those of the block that created it.
 type UTXO struct {
 	Value      *common.Amount
 	PubKeyHash []byte
 	Txid       []byte
 	TxIndex    int
+	Condition  *LockCondition
+	Height     uint64
+	Timestamp  int64
 }
 
-// NewUTXOIndex initializes an UTXOIndex instance
+// NewUTXOIndex initializes an empty UTXOIndex instance that is not backed by any db
 func NewUTXOIndex() UTXOIndex {
-	return UTXOIndex{make(map[string][]*UTXO), &sync.RWMutex{}}
+	return UTXOIndex{nil, make(map[string]*UTXO), make(map[string]*UTXO), &sync.RWMutex{}}
 }
 
-func deserializeUTXOIndex(d []byte) UTXOIndex {
+// LoadUTXOIndex returns the UTXOIndex backed by db.
+func LoadUTXOIndex(db storage.Storage) UTXOIndex {
 	utxos := NewUTXOIndex()
-	utxos.mutex.Lock()
-	defer utxos.mutex.Unlock()
-	decoder := gob.NewDecoder(bytes.NewReader(d))
-	err := decoder.Decode(&utxos.index)
-	if err != nil {
-		logger.Panicf("failed to deserialize UTXOs: %v", err)
-	}
+	utxos.db = db
 	return utxos
 }
 
-func (utxos UTXOIndex) serialize() []byte {
+// getOutpointKey returns the key identifying the TXOutput at vout of transaction txid
+func getOutpointKey(txid []byte, vout int) string {
+	key := make([]byte, 0, len(txid)+5)
+	key = append(key, byte(len(txid)))
+	key = append(key, txid...)
+	index := make([]byte, 4)
+	binary.BigEndian.PutUint32(index, uint32(vout))
+	return string(append(key, index...))
+}
+
+// getUTXOOwnerKeyPrefix returns the prefix shared by the secondary keys of all UTXOs owned by pubKeyHash
+func getUTXOOwnerKeyPrefix(pubKeyHash []byte) []byte {
+	prefix := append([]byte(utxoOwnerKeyPrefix), byte(len(pubKeyHash)))
+	return append(prefix, pubKeyHash...)
+}
+
+func getUTXOKey(outpoint string) []byte {
+	return append([]byte(utxoKeyPrefix), outpoint...)
+}
+
+func getUTXOOwnerKey(u *UTXO) []byte {
+	return append(getUTXOOwnerKeyPrefix(u.PubKeyHash), getOutpointKey(u.Txid, u.TxIndex)...)
+}
+
+func (u *UTXO) serialize() []byte {
 	var encoded bytes.Buffer
-	utxos.mutex.Lock()
-	defer utxos.mutex.Unlock()
 	enc := gob.NewEncoder(&encoded)
-	err := enc.Encode(utxos.index)
+	err := enc.Encode(u)
 	if err != nil {
 		logger.Panic(err)
 	}
 	return encoded.Bytes()
 }
 
-// LoadUTXOIndex returns the UTXOIndex fetched from db.
-func LoadUTXOIndex(db storage.Storage) UTXOIndex {
-	utxoBytes, err := db.Get([]byte(utxoMapKey))
+func deserializeUTXO(d []byte) (*UTXO, error) {
+	u := &UTXO{}
+	decoder := gob.NewDecoder(bytes.NewReader(d))
+	err := decoder.Decode(u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Save writes the changes made to the index since it was loaded to db
+func (utxos UTXOIndex) Save(db storage.Storage) error {
+	utxos.mutex.RLock()
+	defer utxos.mutex.RUnlock()
 
-	if err != nil && err.Error() == storage.ErrKeyInvalid.Error() || len(utxoBytes) == 0{
-		return NewUTXOIndex()
+	for outpoint, u := range utxos.removed {
+		if err := db.Del(getUTXOKey(outpoint)); err != nil {
+			return err
+		}
+		if err := db.Del(getUTXOOwnerKey(u)); err != nil {
+			return err
+		}
 	}
-	return deserializeUTXOIndex(utxoBytes)
+	for outpoint, u := range utxos.added {
+		utxoBytes := u.serialize()
+		if err := db.Put(getUTXOKey(outpoint), utxoBytes); err != nil {
+			return err
+		}
+		if err := db.Put(getUTXOOwnerKey(u), utxoBytes); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// Save stores the index to db
-func (utxos UTXOIndex) Save(mapkey string, db storage.Storage) error {
-	return db.Put([]byte(mapkey), utxos.serialize())
+// getStoredUTXO reads the UTXO at outpoint from db. It returns nil if db has no such UTXO.
+func (utxos UTXOIndex) getStoredUTXO(outpoint string) *UTXO {
+	if utxos.db == nil {
+		return nil
+	}
+	utxoBytes, err := utxos.db.Get(getUTXOKey(outpoint))
+	if err != nil {
+		return nil
+	}
+	u, err := deserializeUTXO(utxoBytes)
+	if err != nil {
+		logger.Warn(fmt.Errorf("failed to deserialize UTXO: %v", err))
+		return nil
+	}
+	return u
+}
+
+func (utxos UTXOIndex) findUTXO(outpoint string) *UTXO {
+	if u, ok := utxos.added[outpoint]; ok {
+		return u
+	}
+	if _, ok := utxos.removed[outpoint]; ok {
+		return nil
+	}
+	return utxos.getStoredUTXO(outpoint)
 }
 
 // FindUTXO returns the UTXO instance of the corresponding TXOutput in the transaction (identified by txid and vout)
@@ -98,58 +174,73 @@ func (utxos UTXOIndex) Save(mapkey string, db storage.Storage) error {
 func (utxos UTXOIndex) FindUTXO(txid []byte, vout int) *UTXO {
 	utxos.mutex.RLock()
 	defer utxos.mutex.RUnlock()
-	for _, utxoArray := range utxos.index {
-		for _, u := range utxoArray {
-			if bytes.Compare(u.Txid, txid) == 0 && u.TxIndex == vout {
-				return u
-			}
-		}
-	}
-	return nil
+	return utxos.findUTXO(getOutpointKey(txid, vout))
 }
 
-// GetUTXOsByPubKeyHash returns all current UTXOs identified by pubkey.
+// GetUTXOsByPubKeyHash returns all current UTXOs identified by pubkey, ordered by txid and vout.
 func (utxos UTXOIndex) GetUTXOsByPubKeyHash(pubkey []byte) []*UTXO {
 	utxos.mutex.RLock()
 	defer utxos.mutex.RUnlock()
-	return utxos.index[string(pubkey)]
 
+	found := make(map[string]*UTXO)
+	if utxos.db != nil {
+		values, err := utxos.db.GetByPrefix(getUTXOOwnerKeyPrefix(pubkey))
+		if err != nil {
+			logger.Warn(fmt.Errorf("failed to read UTXOs: %v", err))
+		}
+		for _, utxoBytes := range values {
+			u, err := deserializeUTXO(utxoBytes)
+			if err != nil {
+				logger.Warn(fmt.Errorf("failed to deserialize UTXO: %v", err))
+				continue
+			}
+			outpoint := getOutpointKey(u.Txid, u.TxIndex)
+			if _, ok := utxos.removed[outpoint]; !ok {
+				found[outpoint] = u
+			}
+		}
+	}
+	for outpoint, u := range utxos.added {
+		if bytes.Equal(u.PubKeyHash, pubkey) {
+			found[outpoint] = u
+		}
+	}
+
+	outpoints := make([]string, 0, len(found))
+	for outpoint := range found {
+		outpoints = append(outpoints, outpoint)
+	}
+	sort.Strings(outpoints)
 
+	var result []*UTXO
+	for _, outpoint := range outpoints {
+		result = append(result, found[outpoint])
+	}
+	return result
 }
 
 // FindUTXOByVin returns the UTXO instance identified by pubkeyHash, txid and vout
 func (utxos UTXOIndex) FindUTXOByVin(pubkeyHash []byte, txid []byte, vout int) *UTXO {
-	utxosOfKey := utxos.GetUTXOsByPubKeyHash(pubkeyHash)
-	for _, utxo := range utxosOfKey {
-		if bytes.Compare(utxo.Txid, txid) == 0 && utxo.TxIndex == vout {
-			return utxo
-		}
+	utxo := utxos.FindUTXO(txid, vout)
+	if utxo == nil || !bytes.Equal(utxo.PubKeyHash, pubkeyHash) {
+		return nil
 	}
-	return nil
+	return utxo
 }
 
-// Update removes the UTXOs spent in the transactions in newBlk from the index and adds UTXOs generated in the
-// transactions to the index. The index will be saved to db as a result. If saving failed, index won't be updated.
+// BuildForkUtxoIndex removes the UTXOs spent in the transactions in newBlk from the index and adds UTXOs generated in
+// the transactions to the index. The changes and the undo journal of newBlk will be saved to db as a result. If saving
+// failed, index won't be updated.
 func (utxos *UTXOIndex) BuildForkUtxoIndex(newBlk *Block, db storage.Storage) error {
 	// Create a copy of the index so operations below are only temporal
 	tempIndex := utxos.deepCopy()
-
-	for _, tx := range newBlk.GetTransactions() {
-		if !tx.IsCoinbase() {
-			for _, txin := range tx.Vin {
-				err := tempIndex.removeUTXO(txin.Txid, txin.Vout)
-				if err != nil {
-					logger.Warn(err)
-				}
-			}
-		}
-		for i, txout := range tx.Vout {
-			tempIndex.addUTXO(txout, tx.ID, i)
-		}
-	}
+	journal := tempIndex.applyBlock(newBlk)
 
 	// Save to database
-	err := tempIndex.Save(utxoMapKey, db)
+	err := tempIndex.Save(db)
+	if err == nil {
+		err = putUndoJournal(db, newBlk.GetHash(), journal)
+	}
 
 	// Assign the temporal copy to the original receiver index ONLY after it is successfully saved to db
 	if err == nil {
@@ -161,123 +252,181 @@ func (utxos *UTXOIndex) BuildForkUtxoIndex(newBlk *Block, db storage.Storage) er
 	return err
 }
 
-// newUTXO returns an UTXO instance constructed from a TXOutput.
-func newUTXO(txout TXOutput, txid []byte, vout int) *UTXO {
-	return &UTXO{txout.Value, txout.PubKeyHash, txid, vout}
+// applyBlock updates the index in memory with the transactions in blk and returns the UTXOs spent by them.
+func (utxos UTXOIndex) applyBlock(blk *Block) *UndoJournal {
+	journal := &UndoJournal{}
+	for _, tx := range blk.GetTransactions() {
+		spent := utxos.applyTransaction(tx, blk.GetHeight(), blk.GetTimestamp())
+		journal.SpentUTXOs = append(journal.SpentUTXOs, spent...)
+	}
+	return journal
 }
 
-// undoTxsInBlock compute the (previous) UTXOIndex resulted from undoing the transactions in given blk.
-// Note that the operation does not save the index to db.
-func (utxos UTXOIndex) undoTxsInBlock(blk *Block, bc *Blockchain, db storage.Storage) {
-
-	for _, tx := range blk.GetTransactions() {
-		err := utxos.excludeVoutsInTx(tx, db)
-		if err != nil {
-			logger.Panic(err)
-		}
-		if tx.IsCoinbase() {
-			continue
-		}
-		err = utxos.unspendVinsInTx(tx, bc)
-		if err != nil {
-			logger.Panic(err)
+// applyTransaction updates the index in memory with tx included in a block at blockHeight timestamped blockTime and
+// returns the UTXOs spent by it.
+func (utxos UTXOIndex) applyTransaction(tx *Transaction, blockHeight uint64, blockTime int64) []*UTXO {
+	var spentUTXOs []*UTXO
+	if !tx.IsCoinbase() {
+		for _, txin := range tx.Vin {
+			spent, err := utxos.spendUTXO(txin.Txid, txin.Vout)
+			if err != nil {
+				logger.Warn(err)
+				continue
+			}
+			spentUTXOs = append(spentUTXOs, spent)
 		}
 	}
+	for i, txout := range tx.Vout {
+		u := newUTXO(txout, tx.ID, i)
+		u.Height = blockHeight
+		u.Timestamp = blockTime
+		utxos.restoreUTXO(u)
+	}
+	return spentUTXOs
 }
 
-// excludeVoutsInTx undoes the spending of UTXO in a transaction.
-func (utxos UTXOIndex) excludeVoutsInTx(tx *Transaction, db storage.Storage) error {
-	for i := range tx.Vout {
-		err := utxos.removeUTXO(tx.ID, i)
-		if err != nil {
-			return err
+// undoBlock reverts the changes made to the index by blk, restoring the UTXOs recorded in its undo journal.
+func (utxos UTXOIndex) undoBlock(blk *Block, journal *UndoJournal) {
+	spentInBlock := make(map[string]bool)
+	for _, u := range journal.SpentUTXOs {
+		spentInBlock[getOutpointKey(u.Txid, u.TxIndex)] = true
+	}
+
+	createdInBlock := make(map[string]bool)
+	for _, tx := range blk.GetTransactions() {
+		for i := range tx.Vout {
+			outpoint := getOutpointKey(tx.ID, i)
+			createdInBlock[outpoint] = true
+			// Outputs spent within the same block are no longer in the index
+			if spentInBlock[outpoint] {
+				continue
+			}
+			if err := utxos.removeUTXO(tx.ID, i); err != nil {
+				logger.Warn(err)
+			}
 		}
 	}
-	return nil
-}
 
-// unspendVinsInTx includes UTXO the UTXOIndex as a result of undoing the spending of UTXO in a transaction.
-func (utxos UTXOIndex) unspendVinsInTx(tx *Transaction, bc *Blockchain) error {
-	for _, vin := range tx.Vin {
-		vout, voutIndex, err := getTXOutputSpent(vin, bc)
-		if err != nil {
-			return err
+	for _, u := range journal.SpentUTXOs {
+		if !createdInBlock[getOutpointKey(u.Txid, u.TxIndex)] {
+			utxos.restoreUTXO(u)
 		}
-		utxos.addUTXO(vout, tx.ID, voutIndex)
 	}
-	return nil
+}
+
+// newUTXO returns an UTXO instance constructed from a TXOutput.
+func newUTXO(txout TXOutput, txid []byte, vout int) *UTXO {
+	return &UTXO{txout.Value, txout.PubKeyHash, txid, vout, txout.Condition, 0, 0}
 }
 
 // addUTXO adds an unspent TXOutput to index
 func (utxos UTXOIndex) addUTXO(txout TXOutput, txid []byte, vout int) {
-	u := newUTXO(txout, txid, vout)
+	utxos.restoreUTXO(newUTXO(txout, txid, vout))
+}
+
+// restoreUTXO adds an UTXO instance to index
+func (utxos UTXOIndex) restoreUTXO(u *UTXO) {
 	utxos.mutex.Lock()
 	defer utxos.mutex.Unlock()
-	utxos.index[string(u.PubKeyHash)] = append(utxos.index[string(u.PubKeyHash)], u)
-
+	utxos.added[getOutpointKey(u.Txid, u.TxIndex)] = u
 }
 
 // removeUTXO finds and removes a UTXO from UTXOIndex
 func (utxos UTXOIndex) removeUTXO(txid []byte, vout int) error {
+	_, err := utxos.spendUTXO(txid, vout)
+	return err
+}
+
+// spendUTXO removes a UTXO from UTXOIndex and returns it
+func (utxos UTXOIndex) spendUTXO(txid []byte, vout int) (*UTXO, error) {
 	utxos.mutex.Lock()
 	defer utxos.mutex.Unlock()
 
-	for _, utxoArray := range utxos.index {
-		for i, u := range utxoArray {
-			if bytes.Compare(u.Txid, txid) == 0 && u.TxIndex == vout {
-				userUTXOs := utxos.index[string(u.PubKeyHash)]
-				utxos.index[string(u.PubKeyHash)] = append(userUTXOs[:i], userUTXOs[i+1:]...)
-				return nil
-			}
-		}
+	outpoint := getOutpointKey(txid, vout)
+	if u, ok := utxos.added[outpoint]; ok {
+		delete(utxos.added, outpoint)
+		return u, nil
 	}
-	return errors.New("UTXO: utxo not found when trying to remove from cache")
-}
-
-func getTXOutputSpent(in TXInput, bc *Blockchain) (TXOutput, int, error) {
-	tx, err := bc.FindTransaction(in.Txid)
-	if err != nil {
-		return  TXOutput{}, 0, errors.New("txInput refers to non-existing transaction")
+	if _, ok := utxos.removed[outpoint]; !ok {
+		if u := utxos.getStoredUTXO(outpoint); u != nil {
+			utxos.removed[outpoint] = u
+			return u, nil
+		}
 	}
-	return tx.Vout[in.Vout], in.Vout, nil
+	return nil, errors.New("UTXO: utxo not found when trying to remove from cache")
 }
 
+// deepCopy returns a copy of the index sharing the same db. Only the pending changes are copied.
 func (utxos UTXOIndex) deepCopy() UTXOIndex {
 	utxos.mutex.RLock()
 	defer utxos.mutex.RUnlock()
 	utxocopy := NewUTXOIndex()
-	copier.Copy(&utxocopy, &utxos)
-	if len(utxocopy.index)==0 {
-		utxocopy = NewUTXOIndex()
+	utxocopy.db = utxos.db
+	for outpoint, u := range utxos.added {
+		utxocopy.added[outpoint] = u
+	}
+	for outpoint, u := range utxos.removed {
+		utxocopy.removed[outpoint] = u
 	}
 	return utxocopy
 }
 
 // GetUTXOIndexAtBlockHash returns the previous snapshot of UTXOIndex when the block of given hash was the tail block.
-func GetUTXOIndexAtBlockHash(db storage.Storage, bc *Blockchain, hash Hash) (UTXOIndex, error){
+func GetUTXOIndexAtBlockHash(db storage.Storage, bc *Blockchain, hash Hash) (UTXOIndex, error) {
 	index := LoadUTXOIndex(db)
 	deepCopy := index.deepCopy()
 	bci := bc.Iterator()
 
 	// Start from the tail of blockchain, compute the previous UTXOIndex by undoing transactions
-	// in the block, until the block hash matches.
+	// in the block with its undo journal, until the block hash matches.
 	for {
 		block, err := bci.Next()
+		if err != nil {
+			return NewUTXOIndex(), err
+		}
 
 		if bytes.Compare(block.GetHash(), hash) == 0 {
 			break
 		}
 
-		if err != nil {
-			return NewUTXOIndex(), err
-		}
-
 		if len(block.GetPrevHash()) == 0 {
 			return NewUTXOIndex(), ErrBlockDoesNotExist
 		}
 
-		deepCopy.undoTxsInBlock(block, bc, db)
+		journal, err := loadUndoJournal(db, bc, block)
+		if err != nil {
+			return NewUTXOIndex(), err
+		}
+		deepCopy.undoBlock(block, journal)
 	}
 
 	return deepCopy, nil
 }
+
+// migrateLegacyUTXOIndex converts an index stored by older versions as a single blob into outpoint-keyed entries.
+// It does nothing if db holds no such blob.
+func migrateLegacyUTXOIndex(db storage.Storage) error {
+	utxoBytes, err := db.Get([]byte(legacyUtxoMapKey))
+	if err != nil || len(utxoBytes) == 0 {
+		return nil
+	}
+
+	legacyIndex := make(map[string][]*UTXO)
+	decoder := gob.NewDecoder(bytes.NewReader(utxoBytes))
+	if err := decoder.Decode(&legacyIndex); err != nil {
+		return err
+	}
+
+	utxos := NewUTXOIndex()
+	for _, utxoArray := range legacyIndex {
+		for _, u := range utxoArray {
+			utxos.added[getOutpointKey(u.Txid, u.TxIndex)] = u
+		}
+	}
+
+	if err := utxos.Save(db); err != nil {
+		return err
+	}
+	logger.Infof("UTXOIndex: migrated %d UTXOs to outpoint-keyed storage", len(utxos.added))
+	return db.Del([]byte(legacyUtxoMapKey))
+}
@@ -19,6 +19,8 @@
 package core
 
 import (
+	"bytes"
+	"encoding/gob"
 	"errors"
 	"github.com/dappley/go-dappley/storage/mocks"
 	"github.com/stretchr/testify/mock"
@@ -39,6 +41,7 @@ var bh1 = &BlockHeader{
 	time.Now().Unix(),
 	nil,
 	0,
+	nil,
 }
 
 var bh2 = &BlockHeader{
@@ -48,6 +51,7 @@ var bh2 = &BlockHeader{
 	time.Now().Unix(),
 	nil,
 	1,
+	nil,
 }
 
 // Padding address to 32 Byte
@@ -98,27 +102,29 @@ func MockUtxoInputs() []TXInput {
 			[]byte("tx1"),
 			0,
 			util.GenerateRandomAoB(2),
-			address1Bytes},
+			address1Bytes,
+			nil},
 		{
 			[]byte("tx1"),
 			1,
 			util.GenerateRandomAoB(2),
-			address1Bytes},
+			address1Bytes,
+			nil},
 	}
 }
 
 func MockUtxoOutputsWithoutInputs() []TXOutput {
 	return []TXOutput{
-		{common.NewAmount(5), address1Hash},
-		{common.NewAmount(7), address1Hash},
+		{common.NewAmount(5), address1Hash, nil},
+		{common.NewAmount(7), address1Hash, nil},
 	}
 }
 
 func MockUtxoOutputsWithInputs() []TXOutput {
 	return []TXOutput{
-		{common.NewAmount(4), address1Hash},
-		{common.NewAmount(5), address2Hash},
-		{common.NewAmount(3), address2Hash},
+		{common.NewAmount(4), address1Hash, nil},
+		{common.NewAmount(5), address2Hash, nil},
+		{common.NewAmount(3), address2Hash, nil},
 	}
 }
 
@@ -126,18 +132,18 @@ func TestAddUTXO(t *testing.T) {
 	db := storage.NewRamStorage()
 	defer db.Close()
 
-	txout := TXOutput{common.NewAmount(5), address1Hash}
+	txout := TXOutput{common.NewAmount(5), address1Hash, nil}
 	utxoIndex := NewUTXOIndex()
 
 	utxoIndex.addUTXO(txout, []byte{1}, 0)
 
-	addr1UTXOs := utxoIndex.index[string(address1Hash)]
+	addr1UTXOs := utxoIndex.GetUTXOsByPubKeyHash(address1Hash)
 	assert.Equal(t, 1, len(addr1UTXOs))
 	assert.Equal(t, txout.Value, addr1UTXOs[0].Value)
 	assert.Equal(t, []byte{1}, addr1UTXOs[0].Txid)
 	assert.Equal(t, 0, addr1UTXOs[0].TxIndex)
 
-	addr2UTXOs := utxoIndex.index["address2"]
+	addr2UTXOs := utxoIndex.GetUTXOsByPubKeyHash([]byte("address2"))
 	assert.Equal(t, 0, len(addr2UTXOs))
 }
 
@@ -147,22 +153,22 @@ func TestRemoveUTXO(t *testing.T) {
 
 	utxoIndex := NewUTXOIndex()
 
-	utxoIndex.index[string(address1Hash)] = append(utxoIndex.index[string(address1Hash)], &UTXO{common.NewAmount(5), address1Hash, []byte{1}, 0})
-	utxoIndex.index[string(address1Hash)] = append(utxoIndex.index[string(address1Hash)], &UTXO{common.NewAmount(2), address1Hash, []byte{1}, 1})
-	utxoIndex.index[string(address1Hash)] = append(utxoIndex.index[string(address1Hash)], &UTXO{common.NewAmount(2), address1Hash, []byte{2}, 0})
-	utxoIndex.index[string(address2Hash)] = append(utxoIndex.index[string(address2Hash)], &UTXO{common.NewAmount(4), address2Hash, []byte{1}, 2})
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(5), address1Hash, nil}, []byte{1}, 0)
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(2), address1Hash, nil}, []byte{1}, 1)
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(2), address1Hash, nil}, []byte{2}, 0)
+	utxoIndex.addUTXO(TXOutput{common.NewAmount(4), address2Hash, nil}, []byte{1}, 2)
 
 	err := utxoIndex.removeUTXO([]byte{1}, 0)
 
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(utxoIndex.index[string(address1Hash)]))
-	assert.Equal(t, 1, len(utxoIndex.index[string(address2Hash)]))
+	assert.Equal(t, 2, len(utxoIndex.GetUTXOsByPubKeyHash(address1Hash)))
+	assert.Equal(t, 1, len(utxoIndex.GetUTXOsByPubKeyHash(address2Hash)))
 
 	err = utxoIndex.removeUTXO([]byte{2}, 1) // Does not exists
 
 	assert.NotNil(t, err)
-	assert.Equal(t, 2, len(utxoIndex.index[string(address1Hash)]))
-	assert.Equal(t, 1, len(utxoIndex.index[string(address2Hash)]))
+	assert.Equal(t, 2, len(utxoIndex.GetUTXOsByPubKeyHash(address1Hash)))
+	assert.Equal(t, 1, len(utxoIndex.GetUTXOsByPubKeyHash(address2Hash)))
 }
 
 func TestUpdate(t *testing.T) {
@@ -176,13 +182,14 @@ func TestUpdate(t *testing.T) {
 
 	// Assert that both the original instance and the database copy are updated correctly
 	for _, index := range []UTXOIndex{utxoIndex, utxoIndexInDB} {
-		assert.Equal(t, 2, len(index.index[string(address1Hash)]))
-		assert.Equal(t, blk.transactions[0].ID, index.index[string(address1Hash)][0].Txid)
-		assert.Equal(t, 0, index.index[string(address1Hash)][0].TxIndex)
-		assert.Equal(t, blk.transactions[0].Vout[0].Value, index.index[string(address1Hash)][0].Value)
-		assert.Equal(t, blk.transactions[0].ID, index.index[string(address1Hash)][1].Txid)
-		assert.Equal(t, 1, index.index[string(address1Hash)][1].TxIndex)
-		assert.Equal(t, blk.transactions[0].Vout[1].Value, index.index[string(address1Hash)][1].Value)
+		addr1UTXOs := index.GetUTXOsByPubKeyHash(address1Hash)
+		assert.Equal(t, 2, len(addr1UTXOs))
+		assert.Equal(t, blk.transactions[0].ID, addr1UTXOs[0].Txid)
+		assert.Equal(t, 0, addr1UTXOs[0].TxIndex)
+		assert.Equal(t, blk.transactions[0].Vout[0].Value, addr1UTXOs[0].Value)
+		assert.Equal(t, blk.transactions[0].ID, addr1UTXOs[1].Txid)
+		assert.Equal(t, 1, addr1UTXOs[1].TxIndex)
+		assert.Equal(t, blk.transactions[0].Vout[1].Value, addr1UTXOs[1].Value)
 	}
 }
 
@@ -196,7 +203,7 @@ func TestUpdate_Failed(t *testing.T) {
 	utxoIndex := NewUTXOIndex()
 	err := utxoIndex.BuildForkUtxoIndex(blk, db)
 	assert.Equal(t, simulatedFailure, err)
-	assert.Equal(t, 0, len(utxoIndex.index[string(address1Hash)]))
+	assert.Equal(t, 0, len(utxoIndex.GetUTXOsByPubKeyHash(address1Hash)))
 }
 
 func TestCopyAndRevertUtxos(t *testing.T) {
@@ -228,19 +235,21 @@ func TestCopyAndRevertUtxos(t *testing.T) {
 		panic(err)
 	}
 
-	assert.Equal(t, 2, len(indexSnapshot.index[string(address1Hash)]))
-	assert.Equal(t, common.NewAmount(5), indexSnapshot.index[string(address1Hash)][0].Value)
-	assert.Equal(t, common.NewAmount(7), indexSnapshot.index[string(address1Hash)][1].Value)
-	assert.Equal(t, 0, len(indexSnapshot.index[string(address2Hash)]))
+	snapshotAddr1UTXOs := indexSnapshot.GetUTXOsByPubKeyHash(address1Hash)
+	assert.Equal(t, 2, len(snapshotAddr1UTXOs))
+	assert.Equal(t, common.NewAmount(5), snapshotAddr1UTXOs[0].Value)
+	assert.Equal(t, common.NewAmount(7), snapshotAddr1UTXOs[1].Value)
+	assert.Equal(t, 0, len(indexSnapshot.GetUTXOsByPubKeyHash(address2Hash)))
 }
 
 func TestFindUTXO(t *testing.T) {
 	Txin := MockTxInputs()
 	Txin = append(Txin, MockTxInputs()...)
-	utxo1 := &UTXO{common.NewAmount(10), []byte("addr1"), Txin[0].Txid, Txin[0].Vout}
-	utxo2 := &UTXO{common.NewAmount(9), []byte("addr1"), Txin[1].Txid, Txin[1].Vout}
+	utxo1 := &UTXO{common.NewAmount(10), []byte("addr1"), Txin[0].Txid, Txin[0].Vout, nil, 0, 0}
+	utxo2 := &UTXO{common.NewAmount(9), []byte("addr1"), Txin[1].Txid, Txin[1].Vout, nil, 0, 0}
 	utxoIndex := NewUTXOIndex()
-	utxoIndex.index["addr1"] = []*UTXO{utxo1, utxo2}
+	utxoIndex.addUTXO(TXOutput{utxo1.Value, utxo1.PubKeyHash, nil}, utxo1.Txid, utxo1.TxIndex)
+	utxoIndex.addUTXO(TXOutput{utxo2.Value, utxo2.PubKeyHash, nil}, utxo2.Txid, utxo2.TxIndex)
 
 	assert.Equal(t, utxo1, utxoIndex.FindUTXO(Txin[0].Txid, Txin[0].Vout))
 	assert.Equal(t, utxo2, utxoIndex.FindUTXO(Txin[1].Txid, Txin[1].Vout))
@@ -291,3 +300,55 @@ func TestConcurrentUTXOindexReadWrite(t *testing.T){
 	assert.True(t, true)
 }
 
+
+func TestUTXOIndex_SaveOnlyWritesChanges(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+
+	blk := GenerateUtxoMockBlockWithoutInputs()
+	utxoIndex := LoadUTXOIndex(db)
+	assert.Nil(t, utxoIndex.BuildForkUtxoIndex(blk, db))
+
+	// Spending a stored UTXO only deletes its entries and writes the new outputs
+	spendingBlk := GenerateUtxoMockBlockWithInputs()
+	utxoIndex = LoadUTXOIndex(db)
+	assert.Nil(t, utxoIndex.removeUTXO(blk.transactions[0].ID, 0))
+	assert.Nil(t, utxoIndex.removeUTXO(blk.transactions[0].ID, 1))
+	for i, txout := range spendingBlk.transactions[0].Vout {
+		utxoIndex.addUTXO(txout, spendingBlk.transactions[0].ID, i)
+	}
+	assert.Equal(t, 2, len(utxoIndex.removed))
+	assert.Equal(t, 3, len(utxoIndex.added))
+	assert.Nil(t, utxoIndex.Save(db))
+
+	utxoIndexInDB := LoadUTXOIndex(db)
+	assert.Nil(t, utxoIndexInDB.FindUTXO(blk.transactions[0].ID, 0))
+	assert.Equal(t, 1, len(utxoIndexInDB.GetUTXOsByPubKeyHash(address1Hash)))
+	assert.Equal(t, 2, len(utxoIndexInDB.GetUTXOsByPubKeyHash(address2Hash)))
+	assert.NotNil(t, utxoIndexInDB.FindUTXOByVin(address2Hash, spendingBlk.transactions[0].ID, 1))
+	assert.Nil(t, utxoIndexInDB.FindUTXOByVin(address1Hash, spendingBlk.transactions[0].ID, 1))
+}
+
+func TestMigrateLegacyUTXOIndex(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+
+	legacyIndex := map[string][]*UTXO{
+		string(address1Hash): {
+			{common.NewAmount(5), address1Hash, []byte{1}, 0, nil, 0, 0},
+			{common.NewAmount(7), address1Hash, []byte{1}, 1, nil, 0, 0},
+		},
+	}
+	var encoded bytes.Buffer
+	gob.NewEncoder(&encoded).Encode(legacyIndex)
+	db.Put([]byte(legacyUtxoMapKey), encoded.Bytes())
+
+	assert.Nil(t, migrateLegacyUTXOIndex(db))
+
+	_, err := db.Get([]byte(legacyUtxoMapKey))
+	assert.Equal(t, storage.ErrKeyInvalid, err)
+	utxos := LoadUTXOIndex(db).GetUTXOsByPubKeyHash(address1Hash)
+	assert.Equal(t, 2, len(utxos))
+	assert.Equal(t, common.NewAmount(5), utxos[0].Value)
+	assert.Equal(t, common.NewAmount(7), utxos[1].Value)
+}
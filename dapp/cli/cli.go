@@ -115,7 +115,7 @@ var cmdFlagsMap = map[string][]flagPars{
 			flagAmountBalance,
 			0,
 			valueTypeInt,
-			"The amount to add to the receiver.",
+			"The amount the faucet of a node started with -dev pays to the receiver.",
 		},
 	},
 	cliSend: {
@@ -20,7 +20,12 @@ package main
 
 import (
 	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/dappley/go-dappley/common"
 	"github.com/dappley/go-dappley/config"
 	"github.com/dappley/go-dappley/consensus"
 	"github.com/dappley/go-dappley/core"
@@ -37,12 +42,18 @@ const (
 	configFilePath  = "conf/default.conf"
 	genesisFilePath = "conf/genesis.conf"
 	defaultPassword = "password"
+	//peerDbSuffix is appended to the database path to get the path of the database of the address book and bans
+	peerDbSuffix = ".peers"
 )
 
 func main() {
 
 	var filePath string
+	var reindex bool
+	var dev bool
 	flag.StringVar(&filePath, "f", configFilePath, "Configuration File Path. Default to conf/default.conf")
+	flag.BoolVar(&reindex, "reindex", false, "Rebuild the transaction index from the blocks in the database")
+	flag.BoolVar(&dev, "dev", false, "Enable development features such as the faucet")
 	flag.Parse()
 
 	logger.SetLevel(logger.DebugLevel)
@@ -67,19 +78,32 @@ func main() {
 	//setup
 	db := storage.OpenDatabase(conf.GetNodeConfig().GetDbPath())
 	defer db.Close()
+	//peer data is written by the network goroutines and is kept apart from the batched writes of the blockchain
+	peerDb := storage.OpenDatabase(conf.GetNodeConfig().GetDbPath() + peerDbSuffix)
+	defer peerDb.Close()
 
 	//create blockchain
-	conss, _ := initConsensus(genesisConf)
+	conss, _ := initConsensus(genesisConf, conf.GetConsensusConfig())
 	conss.StartNewBlockMinting()
 	bc, err := core.GetBlockchain(db, conss)
+	if err == storage.ErrKeyInvalid {
+		bc, err = logic.CreateBlockchainWithAllocations(core.Address{genesisAddr}, getGenesisAllocations(genesisConf), db, conss)
+	}
 	if err != nil {
-		bc, err = logic.CreateBlockchain(core.Address{genesisAddr}, db, conss)
+		logger.Panic(err)
+	}
+	initTxPool(conf.GetTxPoolConfig(), bc.GetTxPool())
+
+	if reindex {
+		logger.Info("Rebuilding transaction index...")
+		err = bc.RebuildTxIndex()
 		if err != nil {
-			logger.Panic(err)
+			logger.Error("ERROR: Rebuild transaction index failed! Exiting...")
+			return
 		}
 	}
 
-	node, err := initNode(conf, bc)
+	node, err := initNode(conf, bc, peerDb)
 	if err != nil {
 		logger.Error("ERROR: initNode failed! Exiting...")
 		return
@@ -87,8 +111,15 @@ func main() {
 
 	//start rpc server
 	server := rpc.NewGrpcServer(node, defaultPassword)
+	if dev {
+		faucet, err := initFaucet(conf.GetFaucetConfig())
+		if err != nil {
+			logger.Error("ERROR: initFaucet failed! Exiting...")
+			return
+		}
+		server.EnableFaucet(faucet)
+	}
 	server.Start(conf.GetNodeConfig().GetRpcPort())
-	defer server.Stop()
 
 	//start mining
 	minerAddr := conf.GetConsensusConfig().GetMinerAddr()
@@ -98,23 +129,75 @@ func main() {
 	logic.SetLockWallet()     //lock the wallet
 
 	conss.Start()
-	defer conss.Stop()
 
-	select {}
+	//run until the node is interrupted
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	<-sigCh
+	logger.Info("Shutting down...")
+
+	//stop everything adding blocks and transactions before the pending transactions are saved. The databases are closed
+	//last by the deferred calls above.
+	conss.Stop()
+	server.Stop()
+	node.Stop()
+	bc.GetTxPool().Stop()
+	saveTxPool(bc)
 }
 
-func initConsensus(conf *configpb.DynastyConfig) (core.Consensus, *consensus.Dynasty) {
+func initConsensus(conf *configpb.DynastyConfig, consensusConf *configpb.ConsensusConfig) (core.Consensus, *consensus.Dynasty) {
 	//set up consensus
 	conss := consensus.NewDpos()
+	conss.SetBlockLimits(int(consensusConf.GetMaxBlockSize()), int(consensusConf.GetMaxBlockTxCount()))
 	dynasty := consensus.NewDynastyWithConfigProducers(conf.GetProducers())
 	conss.SetDynasty(dynasty)
 	conss.SetTargetBit(0)
 	return conss, dynasty
 }
 
-func initNode(conf *configpb.Config, bc *core.Blockchain) (*network.Node, error) {
+func getGenesisAllocations(conf *configpb.DynastyConfig) []core.GenesisAllocation {
+	var allocations []core.GenesisAllocation
+	for _, allocation := range conf.GetAllocations() {
+		allocations = append(allocations, core.GenesisAllocation{
+			Address: core.NewAddress(allocation.GetAddress()),
+			Amount:  common.NewAmount(allocation.GetAmount()),
+		})
+	}
+	return allocations
+}
+
+func initFaucet(conf *configpb.FaucetConfig) (*logic.Faucet, error) {
+	faucet, err := logic.NewFaucet(conf.GetPrivKey(), common.NewAmount(conf.GetMaxAmount()))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	logger.Info("Faucet Address is ", faucet.GetAddress().Address)
+	return faucet, nil
+}
+
+func initTxPool(conf *configpb.TxPoolConfig, txPool *core.TransactionPool) {
+	if conf.GetTtl() > 0 {
+		txPool.SetTTL(time.Duration(conf.GetTtl()) * time.Second)
+	}
+	if conf.GetMaxTxsPerAddress() > 0 {
+		txPool.SetMaxTransactionsPerAddress(int(conf.GetMaxTxsPerAddress()))
+	}
+	txPool.SetMinTip(conf.GetMinTip())
+}
+
+//saveTxPool saves the pending transactions so that they are reloaded on the next start. The pool is also saved with
+//every block added to the blockchain, so that it survives a node that is not shut down cleanly.
+func saveTxPool(bc *core.Blockchain) {
+	err := bc.GetTxPool().SaveToDatabase(bc.GetDb())
+	if err != nil {
+		logger.Error("ERROR: Save transaction pool failed! ", err)
+	}
+}
+
+func initNode(conf *configpb.Config, bc *core.Blockchain, peerDb storage.Storage) (*network.Node, error) {
 	//create node
-	node := network.NewNode(bc)
+	node := network.NewNodeWithPeerDb(bc, peerDb)
 	nodeConfig := conf.GetNodeConfig()
 	port := nodeConfig.GetPort()
 	keyPath := nodeConfig.GetKeyPath()
@@ -124,14 +207,19 @@ func initNode(conf *configpb.Config, bc *core.Blockchain) (*network.Node, error)
 			logger.Error(err)
 		}
 	}
+	if maxFrameSize := nodeConfig.GetMaxFrameSize(); maxFrameSize > 0 {
+		node.SetMaxFrameSize(int(maxFrameSize))
+	}
+	node.SetPeerTargets(int(nodeConfig.GetMaxOutboundPeers()), int(nodeConfig.GetMaxInboundPeers()))
+	seeds := nodeConfig.GetSeeds()
+	if seed := nodeConfig.GetSeed(); seed != "" {
+		seeds = append(seeds, seed)
+	}
+	node.SetSeeds(seeds)
 	err := node.Start(int(port))
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
-	seed := nodeConfig.GetSeed()
-	if seed != "" {
-		node.AddStreamByString(seed)
-	}
 	return node, nil
 }
@@ -43,6 +43,8 @@ var (
 	ErrPasswordNotMatch     = errors.New("ERROR: Password not correct")
 	ErrPathEmpty            = errors.New("ERROR: Path empty")
 	ErrPasswordEmpty        = errors.New("ERROR: Password empty")
+	ErrInvalidFaucetKey     = errors.New("ERROR: Faucet private key is invalid")
+	ErrFaucetAmountTooLarge = errors.New("ERROR: Amount exceeds the faucet limit")
 )
 
 //create a blockchain
@@ -56,6 +58,26 @@ func CreateBlockchain(address core.Address, db storage.Storage, consensus core.C
 	return bc, nil
 }
 
+//create a blockchain whose genesis block seeds the balances listed in allocations
+func CreateBlockchainWithAllocations(address core.Address, allocations []core.GenesisAllocation, db storage.Storage, consensus core.Consensus) (*core.Blockchain, error) {
+	if !address.ValidateAddress() {
+		return nil, ErrInvalidAddress
+	}
+
+	for _, allocation := range allocations {
+		if !allocation.Address.ValidateAddress() {
+			return nil, ErrInvalidAddress
+		}
+		if allocation.Amount.Validate() != nil || allocation.Amount.IsZero() {
+			return nil, ErrInvalidAmount
+		}
+	}
+
+	bc := core.CreateBlockchainWithAllocations(address, allocations, db, consensus)
+
+	return bc, nil
+}
+
 //create a wallet from path
 func CreateWallet(path string, password string) (*client.Wallet, error) {
 	if len(path) == 0 {
@@ -234,17 +256,44 @@ func Send(senderWallet *client.Wallet, to core.Address, amount *common.Amount, t
 	}
 
 	tx, err := core.NewUTXOTransaction(bc.GetDb(), senderWallet.GetAddress(), to, amount, *senderWallet.GetKeyPair(), bc, tip)
-	bc.GetTxPool().Push(tx)
-	node.TxBroadcast(&tx)
 	if err != nil {
 		return err
 	}
 
-	return err
+	//the node relays the transaction once it is admitted to the pool
+	err = bc.GetTxPool().Push(tx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Faucet pays coins to any address from a funded key through normal signed transactions. It is meant for
+// development chains only and must be funded, for example by a genesis allocation, to pay anything.
+type Faucet struct {
+	keyPair   *core.KeyPair
+	maxAmount *common.Amount
+}
+
+// NewFaucet returns a faucet spending from the key privKey and paying at most maxAmount per request. A zero maxAmount
+// leaves requests unbounded.
+func NewFaucet(privKey string, maxAmount *common.Amount) (*Faucet, error) {
+	keyPair, err := core.NewKeyPairFromHex(privKey)
+	if err != nil {
+		return nil, ErrInvalidFaucetKey
+	}
+	return &Faucet{keyPair, maxAmount}, nil
 }
 
-//add balance
-func AddBalance(address core.Address, amount *common.Amount, bc *core.Blockchain) error {
+// GetAddress returns the address the faucet spends from
+func (f *Faucet) GetAddress() core.Address {
+	return f.keyPair.GenerateAddress()
+}
+
+// Send pays amount to address from the faucet and broadcasts the transaction. The transaction pays the minimum tip of
+// the transaction pool of node.
+func (f *Faucet) Send(address core.Address, amount *common.Amount, node *network.Node) error {
 	if !address.ValidateAddress() {
 		return ErrInvalidAddress
 	}
@@ -253,14 +302,20 @@ func AddBalance(address core.Address, amount *common.Amount, bc *core.Blockchain
 		return ErrInvalidAmount
 	}
 
-	tx, err := core.NewUTXOTransactionforAddBalance(address, amount)
+	if f.maxAmount != nil && !f.maxAmount.IsZero() && amount.Cmp(f.maxAmount) > 0 {
+		return ErrFaucetAmountTooLarge
+	}
 
+	bc := node.GetBlockchain()
+	tx, err := core.NewUTXOTransaction(bc.GetDb(), f.GetAddress(), address, amount, *f.keyPair, bc, bc.GetTxPool().GetMinTip())
 	if err != nil {
 		return err
 	}
 
-	bc.GetTxPool().Push(tx)
-
-	return err
-
+	//the node relays the transaction once it is admitted to the pool
+	err = bc.GetTxPool().Push(tx)
+	if err != nil {
+		return err
+	}
+	return nil
 }
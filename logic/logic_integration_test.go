@@ -94,12 +94,13 @@ func TestSend(t *testing.T) {
 			}
 			pow.Stop()
 			core.WaitFullyStop(pow, 20)
-			// Verify balance of sender's wallet (genesis "mineReward" - transferred amount)
+			// Verify balance of sender's wallet (genesis "mineReward" - transferred amount - tip)
 			senderBalance, err := GetBalance(senderWallet.GetAddress(), store)
 			if err != nil {
 				panic(err)
 			}
 			expectedBalance, _ := mineReward.Sub(tc.expectedTransfer)
+			expectedBalance, _ = expectedBalance.Sub(common.NewAmount(tc.expectedTip))
 			assert.Equal(t, expectedBalance, senderBalance)
 
 			// Balance of the receiver's wallet should be the amount transferred
@@ -114,7 +115,7 @@ func TestSend(t *testing.T) {
 			if err != nil {
 				panic(err)
 			}
-			assert.Equal(t, mineReward.Times(bc.GetMaxHeight()), minerBalance)
+			assert.Equal(t, mineReward.Times(bc.GetMaxHeight()).Add(common.NewAmount(tc.expectedTip)), minerBalance)
 
 		})
 	}
@@ -278,10 +279,7 @@ func TestBlockMsgRelaySingleMiner(t *testing.T) {
 	//expect every node should have # of entries in dapmsg cache equal to their blockchain height
 	heights := []int{0, 0, 0, 0} //keep track of each node's blockchain height
 	for i := 0; i < len(nodes); i++ {
-		nodes[i].GetRecentlyRcvedDapMsgs().Range(func(k, v interface{}) bool {
-			heights[i]++
-			return true
-		})
+		heights[i] = nodes[i].GetDapMsgCache(network.SyncBlock).Len()
 		assert.Equal(t, heights[i], int(bcs[i].GetMaxHeight()))
 
 	}
@@ -353,10 +351,7 @@ func TestBlockMsgRelayMeshNetworkMultipleMiners(t *testing.T) {
 	//expect every node should have # of entries in dapmsg cache equal to their blockchain height
 	heights := []int{0, 0, 0, 0} //keep track of each node's blockchain height
 	for i := 0; i < len(nodes); i++ {
-		nodes[i].GetRecentlyRcvedDapMsgs().Range(func(k, v interface{}) bool {
-			heights[i]++
-			return true
-		})
+		heights[i] = nodes[i].GetDapMsgCache(network.SyncBlock).Len()
 		assert.Equal(t, heights[i], int(bcs[i].GetMaxHeight()))
 	}
 }
@@ -414,37 +409,46 @@ func TestForkChoice(t *testing.T) {
 	}
 }
 
-// Integration test for adding balance
-func TestAddBalance(t *testing.T) {
+const faucetKey = "bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e"
+
+// Integration test for paying from a faucet funded by the genesis block
+func TestFaucet(t *testing.T) {
 	testCases := []struct {
 		name         string
-		addAmount    *common.Amount
+		amount       *common.Amount
+		minTip       uint64
 		expectedDiff *common.Amount
 		expectedErr  error
 	}{
-		{"Add 5", common.NewAmount(5), common.NewAmount(5), nil},
-		{"Add zero", common.NewAmount(0), common.NewAmount(0), ErrInvalidAmount},
+		{"Send 5", common.NewAmount(5), 0, common.NewAmount(5), nil},
+		{"Send 5 with min tip", common.NewAmount(5), 2, common.NewAmount(5), nil},
+		{"Send zero", common.NewAmount(0), 0, common.NewAmount(0), ErrInvalidAmount},
+		{"Send above limit", common.NewAmount(60), 0, common.NewAmount(0), ErrFaucetAmountTooLarge},
+		{"Send above funds", common.NewAmount(40), 0, common.NewAmount(0), core.ErrInsufficientFund},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-
 			store := storage.NewRamStorage()
 			defer store.Close()
 
-			// Create a coinbase address
-			addr := core.Address{"1G4r54VdJsotfCukXUWmg1ZRnhjUs6TvbV"}
+			faucet, err := NewFaucet(faucetKey, common.NewAmount(50))
+			assert.Nil(t, err)
 
-			bc, pow := createBlockchain(addr, store)
+			// Create a coinbase address and fund the faucet in the genesis block
+			addr := core.Address{"1G4r54VdJsotfCukXUWmg1ZRnhjUs6TvbV"}
+			pow := consensus.NewProofOfWork()
+			bc, err := CreateBlockchainWithAllocations(addr, []core.GenesisAllocation{{faucet.GetAddress(), common.NewAmount(30)}}, store, pow)
+			assert.Nil(t, err)
 
 			// Create a new wallet address for testing
 			testAddr := core.Address{"1AUrNJCRM5X5fDdmm3E3yjCrXQMLvDj9tb"}
 
-			// Add `addAmount` to the balance of the new wallet
-			err := AddBalance(testAddr, tc.addAmount, bc)
-			assert.Equal(t, err, tc.expectedErr)
+			bc.GetTxPool().SetMinTip(tc.minTip)
+			node := network.FakeNodeWithPidAndAddr(bc, "a", "b")
+			err = faucet.Send(testAddr, tc.amount, node)
+			assert.Equal(t, tc.expectedErr, err)
 
 			// Start mining to approve the transaction
-			node := network.FakeNodeWithPidAndAddr(bc, "a", "b")
 			pow.Setup(node, addr.Address)
 			pow.SetTargetBit(0)
 			pow.Start()
@@ -461,8 +465,8 @@ func TestAddBalance(t *testing.T) {
 	}
 }
 
-// Integration test for adding balance to invalid address
-func TestAddBalanceWithInvalidAddress(t *testing.T) {
+// Integration test for paying from a faucet to an invalid address
+func TestFaucetWithInvalidAddress(t *testing.T) {
 	testCases := []struct {
 		name    string
 		address string
@@ -475,17 +479,24 @@ func TestAddBalanceWithInvalidAddress(t *testing.T) {
 			store := storage.NewRamStorage()
 			defer store.Close()
 
-			// Create a coinbase wallet address
 			addr := core.Address{"1G4r54VdJsotfCukXUWmg1ZRnhjUs6TvbV"}
+
 			// Create a blockchain
 			bc, err := CreateBlockchain(addr, store, nil)
 			assert.Nil(t, err)
-			err = AddBalance(core.Address{tc.address}, common.NewAmount(8), bc)
+			faucet, err := NewFaucet(faucetKey, common.NewAmount(0))
+			assert.Nil(t, err)
+			err = faucet.Send(core.Address{tc.address}, common.NewAmount(8), network.FakeNodeWithPidAndAddr(bc, "a", "b"))
 			assert.Equal(t, ErrInvalidAddress, err)
 		})
 	}
 }
 
+func TestNewFaucetWithInvalidKey(t *testing.T) {
+	_, err := NewFaucet("invalid key", common.NewAmount(0))
+	assert.Equal(t, ErrInvalidFaucetKey, err)
+}
+
 func connectNodes(node1 *network.Node, node2 *network.Node) {
 	node1.AddStream(
 		node2.GetPeerID(),
@@ -516,7 +527,7 @@ func TestDoubleMint (t *testing.T){
 
 	dynasty := consensus.NewDynastyWithProducers([]string{validProducerAddr})
 	producerHash := core.HashAddress([]byte(validProducerAddr))
-	tx := &core.Transaction{nil, []core.TXInput{core.TXInput{[]byte{}, -1,nil,nil}}, []core.TXOutput{core.TXOutput{common.NewAmount(0), producerHash}}, 0}
+	tx := &core.Transaction{nil, []core.TXInput{core.TXInput{[]byte{}, -1,nil,nil, nil}}, []core.TXOutput{core.TXOutput{common.NewAmount(0), producerHash, nil}}, 0}
 
 	for i:=0; i< 3 ;i++  {
 		blk:=createValidBlock(producerHash, tx, validProducerKey, parent)
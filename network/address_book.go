@@ -0,0 +1,284 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dappley/go-dappley/network/pb"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-peer"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	//maxAddressBookSize is the maximum number of peers recorded in the address book
+	maxAddressBookSize = 1000
+	//maxDialFailures is the number of consecutive failed dials after which a peer is forgotten
+	maxDialFailures = 16
+	//maxNewPeerDialFailures is the number of failed dials after which a peer that was never connected is forgotten
+	maxNewPeerDialFailures = 3
+	//minRedialBackoff is the time to wait before dialing a peer again. It doubles after every failed dial.
+	minRedialBackoff = 30 * time.Second
+	//maxRedialBackoff is the maximum time to wait before dialing a peer again
+	maxRedialBackoff = time.Hour
+	//addressBookKeyPrefix is the prefix of the keys under which the known peers are saved across restarts
+	addressBookKeyPrefix = "addrbook_"
+)
+
+//knownPeer is a peer recorded in the address book
+type knownPeer struct {
+	peer        *Peer
+	lastSeen    time.Time
+	lastAttempt time.Time
+	successes   uint32
+	failures    uint32
+}
+
+//AddressBook records the peers the node has learned about and the outcome of dialing them. It is saved in storage so
+//that the peers can be dialed again after a restart.
+type AddressBook struct {
+	peers map[peer.ID]*knownPeer
+	db    storage.Storage
+	mutex *sync.Mutex
+}
+
+//NewAddressBook returns the address book saved in db. The address book is only kept in memory if db is nil.
+func NewAddressBook(db storage.Storage) *AddressBook {
+	ab := &AddressBook{
+		peers: make(map[peer.ID]*knownPeer),
+		db:    db,
+		mutex: &sync.Mutex{},
+	}
+	ab.load()
+	return ab
+}
+
+//Add records a peer the node can dial. The address of a known peer is updated.
+func (ab *AddressBook) Add(p *Peer) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	ab.add(p)
+}
+
+func (ab *AddressBook) add(p *Peer) *knownPeer {
+	if kp, ok := ab.peers[p.peerid]; ok {
+		if kp.peer.addr.String() != p.addr.String() {
+			kp.peer = p
+			ab.save(kp)
+		}
+		return kp
+	}
+	if len(ab.peers) >= maxAddressBookSize {
+		ab.evict()
+	}
+	kp := &knownPeer{peer: p}
+	ab.peers[p.peerid] = kp
+	ab.save(kp)
+	return kp
+}
+
+//Remove forgets a peer
+func (ab *AddressBook) Remove(pid peer.ID) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	ab.remove(pid)
+}
+
+func (ab *AddressBook) remove(pid peer.ID) {
+	if _, ok := ab.peers[pid]; !ok {
+		return
+	}
+	delete(ab.peers, pid)
+	if ab.db != nil {
+		ab.db.Del(addressBookKey(pid))
+	}
+}
+
+//MarkSuccess records a successful connection to a peer, adding it to the address book if needed
+func (ab *AddressBook) MarkSuccess(p *Peer) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	kp := ab.add(p)
+	kp.lastSeen = time.Now()
+	kp.successes++
+	kp.failures = 0
+	ab.save(kp)
+}
+
+//MarkFailure records a failed dial. Peers failing too many times in a row are forgotten.
+func (ab *AddressBook) MarkFailure(pid peer.ID) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	kp, ok := ab.peers[pid]
+	if !ok {
+		return
+	}
+	kp.lastAttempt = time.Now()
+	kp.failures++
+	if kp.failures >= maxDialFailures || (kp.successes == 0 && kp.failures >= maxNewPeerDialFailures) {
+		logger.Debug("AddressBook: Forgetting peer ", pid, " after ", kp.failures, " failed dials")
+		ab.remove(pid)
+		return
+	}
+	ab.save(kp)
+}
+
+//GetDialCandidates returns at most max peers that are due to be dialed and are not excluded, the most recently seen
+//first. The returned peers are not returned again before their backoff elapses.
+func (ab *AddressBook) GetDialCandidates(max int, exclude func(pid peer.ID) bool) []*Peer {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	now := time.Now()
+	var candidates []*knownPeer
+	for pid, kp := range ab.peers {
+		if exclude(pid) || now.Before(kp.lastAttempt.Add(redialBackoff(kp.failures))) {
+			continue
+		}
+		candidates = append(candidates, kp)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].lastSeen.Equal(candidates[j].lastSeen) {
+			return candidates[i].lastSeen.After(candidates[j].lastSeen)
+		}
+		return candidates[i].failures < candidates[j].failures
+	})
+	if len(candidates) > max {
+		candidates = candidates[:max]
+	}
+
+	peers := []*Peer{}
+	for _, kp := range candidates {
+		kp.lastAttempt = now
+		ab.save(kp)
+		peers = append(peers, kp.peer)
+	}
+	return peers
+}
+
+//GetPeers returns the peers in the address book
+func (ab *AddressBook) GetPeers() []*Peer {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+
+	peers := []*Peer{}
+	for _, kp := range ab.peers {
+		peers = append(peers, kp.peer)
+	}
+	return peers
+}
+
+//redialBackoff returns the time to wait before dialing a peer that failed the given number of consecutive dials
+func redialBackoff(failures uint32) time.Duration {
+	backoff := minRedialBackoff
+	for i := uint32(0); i < failures && backoff < maxRedialBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRedialBackoff {
+		return maxRedialBackoff
+	}
+	return backoff
+}
+
+//evict forgets the peer with the most failed dials, the least recently seen among them
+func (ab *AddressBook) evict() {
+	var worst *knownPeer
+	for _, kp := range ab.peers {
+		if worst == nil || kp.failures > worst.failures ||
+			(kp.failures == worst.failures && kp.lastSeen.Before(worst.lastSeen)) {
+			worst = kp
+		}
+	}
+	if worst != nil {
+		ab.remove(worst.peer.peerid)
+	}
+}
+
+func addressBookKey(pid peer.ID) []byte {
+	return []byte(addressBookKeyPrefix + peer.IDB58Encode(pid))
+}
+
+func (ab *AddressBook) save(kp *knownPeer) {
+	if ab.db == nil {
+		return
+	}
+	data, err := proto.Marshal(kp.ToProto())
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := ab.db.Put(addressBookKey(kp.peer.peerid), data); err != nil {
+		logger.Warn("AddressBook: Unable to save peer ", kp.peer.peerid, ": ", err)
+	}
+}
+
+func (ab *AddressBook) load() {
+	if ab.db == nil {
+		return
+	}
+	values, err := ab.db.GetByPrefix([]byte(addressBookKeyPrefix))
+	if err != nil {
+		return
+	}
+	for _, data := range values {
+		kppb := &networkpb.KnownPeer{}
+		if err := proto.Unmarshal(data, kppb); err != nil {
+			logger.Warn("AddressBook: Saved peer cannot be decoded")
+			continue
+		}
+		kp := &knownPeer{}
+		if err := kp.FromProto(kppb); err != nil {
+			logger.Warn("AddressBook: Saved peer cannot be decoded: ", err)
+			continue
+		}
+		ab.peers[kp.peer.peerid] = kp
+	}
+}
+
+func (kp *knownPeer) ToProto() proto.Message {
+	return &networkpb.KnownPeer{
+		Peer:        kp.peer.ToProto().(*networkpb.Peer),
+		LastSeen:    kp.lastSeen.Unix(),
+		LastAttempt: kp.lastAttempt.Unix(),
+		Successes:   kp.successes,
+		Failures:    kp.failures,
+	}
+}
+
+func (kp *knownPeer) FromProto(pb proto.Message) error {
+	kppb := pb.(*networkpb.KnownPeer)
+	if kppb.Peer == nil {
+		return ErrInvalidMessageFormat
+	}
+	kp.peer = &Peer{}
+	if err := kp.peer.FromProto(kppb.Peer); err != nil {
+		return err
+	}
+	kp.lastSeen = time.Unix(kppb.LastSeen, 0)
+	kp.lastAttempt = time.Unix(kppb.LastAttempt, 0)
+	kp.successes = kppb.Successes
+	kp.failures = kppb.Failures
+	return nil
+}
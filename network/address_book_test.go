@@ -0,0 +1,126 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dappley/go-dappley/storage"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPeers(t *testing.T) (*Peer, *Peer, *Peer) {
+	p1, err := CreatePeerFromString("/ip4/127.0.0.1/tcp/10000/ipfs/QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	assert.Nil(t, err)
+	p2, err := CreatePeerFromString("/ip4/127.0.0.1/tcp/10001/ipfs/QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	assert.Nil(t, err)
+	p3, err := CreatePeerFromString("/ip4/127.0.0.1/tcp/10002/ipfs/QmWyMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	assert.Nil(t, err)
+	return p1, p2, p3
+}
+
+func noExclusion(pid peer.ID) bool { return false }
+
+func TestAddressBook_Persistence(t *testing.T) {
+	db := storage.NewRamStorage()
+	defer db.Close()
+	p1, p2, p3 := createTestPeers(t)
+
+	ab := NewAddressBook(db)
+	ab.Add(p1)
+	ab.MarkSuccess(p2)
+	ab.MarkSuccess(p3)
+	ab.MarkFailure(p3.peerid)
+	ab.Remove(p1.peerid)
+
+	loaded := NewAddressBook(db)
+	assert.ElementsMatch(t, []*Peer{p2, p3}, loaded.GetPeers())
+	assert.Equal(t, uint32(1), loaded.peers[p2.peerid].successes)
+	assert.Equal(t, uint32(0), loaded.peers[p2.peerid].failures)
+	assert.Equal(t, ab.peers[p2.peerid].lastSeen.Unix(), loaded.peers[p2.peerid].lastSeen.Unix())
+	assert.Equal(t, uint32(1), loaded.peers[p3.peerid].failures)
+	assert.Equal(t, ab.peers[p3.peerid].lastAttempt.Unix(), loaded.peers[p3.peerid].lastAttempt.Unix())
+}
+
+func TestAddressBook_GetDialCandidates(t *testing.T) {
+	p1, p2, p3 := createTestPeers(t)
+	ab := NewAddressBook(nil)
+	ab.Add(p1)
+	ab.MarkSuccess(p2)
+	ab.MarkSuccess(p3)
+	ab.peers[p3.peerid].lastSeen = time.Now().Add(-time.Hour)
+
+	//the most recently seen peers are dialed first
+	assert.Equal(t, []*Peer{p2, p3}, ab.GetDialCandidates(2, noExclusion))
+	//peers are not dialed again before their backoff elapses
+	assert.Equal(t, []*Peer{p1}, ab.GetDialCandidates(2, noExclusion))
+	assert.Len(t, ab.GetDialCandidates(2, noExclusion), 0)
+
+	ab.peers[p2.peerid].lastAttempt = time.Now().Add(-minRedialBackoff)
+	ab.peers[p3.peerid].lastAttempt = time.Now().Add(-minRedialBackoff)
+	excluded := ab.GetDialCandidates(2, func(pid peer.ID) bool { return pid == p2.peerid })
+	assert.Equal(t, []*Peer{p3}, excluded)
+}
+
+func TestAddressBook_MarkFailure(t *testing.T) {
+	p1, p2, _ := createTestPeers(t)
+	ab := NewAddressBook(nil)
+	ab.Add(p1)
+	ab.MarkSuccess(p2)
+
+	//peers that were never connected are forgotten quickly
+	for i := 0; i < maxNewPeerDialFailures; i++ {
+		ab.MarkFailure(p1.peerid)
+	}
+	assert.Equal(t, []*Peer{p2}, ab.GetPeers())
+
+	for i := 0; i < maxDialFailures-1; i++ {
+		ab.MarkFailure(p2.peerid)
+	}
+	assert.Equal(t, []*Peer{p2}, ab.GetPeers())
+	ab.MarkFailure(p2.peerid)
+	assert.Len(t, ab.GetPeers(), 0)
+}
+
+func TestAddressBook_Evict(t *testing.T) {
+	p1, p2, _ := createTestPeers(t)
+	ab := NewAddressBook(nil)
+	ab.MarkSuccess(p1)
+	ab.MarkSuccess(p2)
+	ab.MarkFailure(p2.peerid)
+	for i := 2; i < maxAddressBookSize; i++ {
+		ab.peers[peer.ID(strconv.Itoa(i))] = &knownPeer{peer: &Peer{peerid: peer.ID(strconv.Itoa(i))}, lastSeen: time.Now()}
+	}
+
+	//the peer with the most failed dials is evicted for new peers
+	p3, _ := CreatePeerFromString("/ip4/127.0.0.1/tcp/10002/ipfs/QmWyMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	ab.Add(p3)
+	assert.Len(t, ab.peers, maxAddressBookSize)
+	assert.NotContains(t, ab.peers, p2.peerid)
+	assert.Contains(t, ab.peers, p3.peerid)
+}
+
+func TestRedialBackoff(t *testing.T) {
+	assert.Equal(t, minRedialBackoff, redialBackoff(0))
+	assert.Equal(t, 4*minRedialBackoff, redialBackoff(2))
+	assert.Equal(t, maxRedialBackoff, redialBackoff(maxDialFailures))
+}
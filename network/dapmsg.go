@@ -19,11 +19,20 @@
 package network
 
 import (
+	"crypto/sha256"
+	"errors"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/dappley/go-dappley/network/pb"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
 	"time"
 )
 
+var (
+	ErrInvalidDapMsgSignature = errors.New("ERROR: Dappley message signature is invalid")
+)
+
 type DapMsg struct{
 	cmd 	string
 	data 	[]byte
@@ -31,6 +40,8 @@ type DapMsg struct{
 	key string
 	uniOrBroadcast int``
 	counter uint64
+	origin []byte
+	signature []byte
 }
 
 func NewDapmsg(cmd string, data []byte, from string, uniOrBroadcast int, counter *uint64) *DapMsg {
@@ -38,7 +49,7 @@ func NewDapmsg(cmd string, data []byte, from string, uniOrBroadcast int, counter
 		*counter = 0
 	}
 	*counter++
-	return &DapMsg{cmd, data, time.Now().Unix(), from, uniOrBroadcast, *counter}
+	return &DapMsg{cmd, data, time.Now().Unix(), from, uniOrBroadcast, *counter, nil, nil}
 }
 
 func (dm *DapMsg) GetCmd() string{
@@ -61,6 +72,67 @@ func (dm *DapMsg) GetKey() string{
 	return dm.key
 }
 
+//GetHash returns the hash of the command and the data of the message. The same message relayed by different peers has
+//the same hash.
+func (dm *DapMsg) GetHash() []byte {
+	hash := sha256.New()
+	hash.Write([]byte(dm.cmd))
+	hash.Write([]byte{0})
+	hash.Write(dm.data)
+	return hash.Sum(nil)
+}
+
+//Sign signs the hash of the message with the private key of the node creating it, so that the peers receiving it can
+//verify that it was not modified by the peers relaying it
+func (dm *DapMsg) Sign(privKey crypto.PrivKey) error {
+	origin, err := crypto.MarshalPublicKey(privKey.GetPublic())
+	if err != nil {
+		return err
+	}
+	signature, err := privKey.Sign(dm.GetHash())
+	if err != nil {
+		return err
+	}
+	dm.origin = origin
+	dm.signature = signature
+	return nil
+}
+
+func (dm *DapMsg) IsSigned() bool {
+	return len(dm.signature) > 0
+}
+
+//VerifySignature returns ErrInvalidDapMsgSignature unless the message is signed by its origin
+func (dm *DapMsg) VerifySignature() error {
+	if !dm.IsSigned() {
+		return ErrInvalidDapMsgSignature
+	}
+	pubKey, err := crypto.UnmarshalPublicKey(dm.origin)
+	if err != nil {
+		return ErrInvalidDapMsgSignature
+	}
+	if ok, err := pubKey.Verify(dm.GetHash(), dm.signature); err != nil || !ok {
+		return ErrInvalidDapMsgSignature
+	}
+	return nil
+}
+
+//GetOrigin returns the id of the node that signed the message. It is empty if the message is not signed.
+//The signature needs to be verified before the origin can be trusted.
+func (dm *DapMsg) GetOrigin() peer.ID {
+	if !dm.IsSigned() {
+		return ""
+	}
+	pubKey, err := crypto.UnmarshalPublicKey(dm.origin)
+	if err != nil {
+		return ""
+	}
+	pid, err := peer.IDFromPublicKey(pubKey)
+	if err != nil {
+		return ""
+	}
+	return pid
+}
 
 func (dm *DapMsg) ToProto() proto.Message{
 	return &networkpb.Dapmsg{
@@ -68,6 +140,10 @@ func (dm *DapMsg) ToProto() proto.Message{
 		Data: dm.data,
 		UnixTimeRecvd: dm.unixTimeRecvd,
 		Key: dm.key,
+		UniOrBroadcast: int64(dm.uniOrBroadcast),
+		Counter: dm.counter,
+		Origin: dm.origin,
+		Signature: dm.signature,
 	}
 }
 
@@ -76,5 +152,9 @@ func (dm *DapMsg) FromProto(pb proto.Message){
 	dm.data = pb.(*networkpb.Dapmsg).Data
 	dm.unixTimeRecvd =pb.(*networkpb.Dapmsg).UnixTimeRecvd
 	dm.key = pb.(*networkpb.Dapmsg).Key
+	dm.uniOrBroadcast = int(pb.(*networkpb.Dapmsg).UniOrBroadcast)
+	dm.counter = pb.(*networkpb.Dapmsg).Counter
+	dm.origin = pb.(*networkpb.Dapmsg).Origin
+	dm.signature = pb.(*networkpb.Dapmsg).Signature
 
 }
\ No newline at end of file
@@ -21,12 +21,15 @@ package network
 import (
 	"testing"
 	"github.com/dappley/go-dappley/network/pb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDapmsg_ToProto(t *testing.T) {
 	new := networkpb.Peer{}
-	msg :=DapMsg{"cmd", []byte{1,2,3,4}, 11111111, new.Addr, Unicast, uint64(0)}
+	msg :=DapMsg{"cmd", []byte{1,2,3,4}, 11111111, new.Addr, Unicast, uint64(0), nil, nil}
 	retMsg := &networkpb.Dapmsg{Cmd: "cmd",Data: []byte{1,2,3,4}, UnixTimeRecvd: 11111111, Key: new.Addr, UniOrBroadcast:Unicast, Counter:uint64(0)}
 
 	assert.Equal(t,msg.ToProto(),retMsg)
@@ -34,10 +37,53 @@ func TestDapmsg_ToProto(t *testing.T) {
 
 func TestDapMsg_FromProto(t *testing.T) {
 	new := networkpb.Peer{}
-	msg :=DapMsg{"cmd", []byte{1,2,3,4}, 11111111, new.Addr, Unicast, uint64(0)}
+	msg :=DapMsg{"cmd", []byte{1,2,3,4}, 11111111, new.Addr, Unicast, uint64(0), nil, nil}
 	retMsg := &networkpb.Dapmsg{Cmd: "cmd",Data: []byte{1,2,3,4}, UnixTimeRecvd:11111111, Key: new.Addr, UniOrBroadcast:Unicast, Counter:uint64(0)}
 	msg2 := DapMsg{}
 	msg2.FromProto(retMsg)
 
 	assert.Equal(t,msg,msg2)
-}
\ No newline at end of file
+}
+
+func TestDapMsg_GetHash(t *testing.T) {
+	msg := DapMsg{"cmd", []byte{1,2,3,4}, 11111111, "key1", Broadcast, uint64(0), nil, nil}
+	relayed := DapMsg{"cmd", []byte{1,2,3,4}, 22222222, "key2", Broadcast, uint64(1), nil, nil}
+	otherCmd := DapMsg{"cmd2", []byte{1,2,3,4}, 11111111, "key1", Broadcast, uint64(0), nil, nil}
+
+	assert.Equal(t, msg.GetHash(), relayed.GetHash())
+	assert.NotEqual(t, msg.GetHash(), otherCmd.GetHash())
+}
+
+func TestDapMsg_Sign(t *testing.T) {
+	privKey, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.Nil(t, err)
+	pid, err := peer.IDFromPublicKey(pubKey)
+	assert.Nil(t, err)
+
+	msg := DapMsg{"cmd", []byte{1,2,3,4}, 11111111, "key1", Broadcast, uint64(0), nil, nil}
+	assert.False(t, msg.IsSigned())
+	assert.Equal(t, peer.ID(""), msg.GetOrigin())
+	assert.Equal(t, ErrInvalidDapMsgSignature, msg.VerifySignature())
+
+	assert.Nil(t, msg.Sign(privKey))
+	assert.True(t, msg.IsSigned())
+	assert.Nil(t, msg.VerifySignature())
+	assert.Equal(t, pid, msg.GetOrigin())
+
+	//the signature is kept when the message is relayed
+	data, err := proto.Marshal(msg.ToProto())
+	assert.Nil(t, err)
+	dmpb := &networkpb.Dapmsg{}
+	assert.Nil(t, proto.Unmarshal(data, dmpb))
+	relayed := DapMsg{}
+	relayed.FromProto(dmpb)
+	assert.Equal(t, msg, relayed)
+	assert.Nil(t, relayed.VerifySignature())
+
+	//the signature does not match a modified message
+	relayed.data = []byte{5,6,7,8}
+	assert.Equal(t, ErrInvalidDapMsgSignature, relayed.VerifySignature())
+	relayed.data = msg.data
+	relayed.signature = []byte{1,2,3}
+	assert.Equal(t, ErrInvalidDapMsgSignature, relayed.VerifySignature())
+}
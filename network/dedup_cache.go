@@ -0,0 +1,92 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru"
+)
+
+const (
+	//DefaultDedupCacheSize is the default number of messages remembered by a DedupCache
+	DefaultDedupCacheSize = 4096
+	//DefaultDedupCacheTTL is the default time a DedupCache remembers a message
+	DefaultDedupCacheTTL = 10 * time.Minute
+)
+
+//DedupCacheStats counts the lookups of a DedupCache
+type DedupCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+//DedupCache remembers the hashes of recently received messages so that messages gossiped by several peers are only
+//handled once. The least recently used hashes are forgotten when the cache is full, and hashes expire after a while.
+type DedupCache struct {
+	entries *lru.Cache
+	ttl     time.Duration
+	hits    uint64
+	misses  uint64
+	mutex   *sync.Mutex
+}
+
+func NewDedupCache(size int, ttl time.Duration) *DedupCache {
+	entries, _ := lru.New(size)
+	return &DedupCache{
+		entries: entries,
+		ttl:     ttl,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+//CheckAndAdd returns true if hash was added recently. Otherwise it adds hash and returns false.
+func (c *DedupCache) CheckAndAdd(hash []byte) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if expiry, ok := c.entries.Get(string(hash)); ok && now.Before(expiry.(time.Time)) {
+		c.hits++
+		return true
+	}
+	c.entries.Add(string(hash), now.Add(c.ttl))
+	c.misses++
+	return false
+}
+
+//Add adds hash without counting a lookup, so that messages sent by the node are not handled when peers relay them back
+func (c *DedupCache) Add(hash []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.Add(string(hash), time.Now().Add(c.ttl))
+}
+
+//Len returns the number of hashes in the cache, including the expired hashes not yet evicted
+func (c *DedupCache) Len() int {
+	return c.entries.Len()
+}
+
+func (c *DedupCache) GetStats() DedupCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return DedupCacheStats{c.hits, c.misses, c.entries.Len()}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dappley/go-dappley/network/pb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupCache_CheckAndAdd(t *testing.T) {
+	cache := NewDedupCache(2, time.Hour)
+
+	assert.False(t, cache.CheckAndAdd([]byte{1}))
+	assert.True(t, cache.CheckAndAdd([]byte{1}))
+	assert.False(t, cache.CheckAndAdd([]byte{2}))
+	//hashes added by the node are not counted
+	cache.Add([]byte{3})
+	assert.Equal(t, DedupCacheStats{Hits: 1, Misses: 2, Size: 2}, cache.GetStats())
+
+	//the least recently used hash is forgotten when the cache is full
+	assert.True(t, cache.CheckAndAdd([]byte{3}))
+	assert.False(t, cache.CheckAndAdd([]byte{1}))
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDedupCache_Expiry(t *testing.T) {
+	cache := NewDedupCache(DefaultDedupCacheSize, 10*time.Millisecond)
+
+	assert.False(t, cache.CheckAndAdd([]byte{1}))
+	assert.True(t, cache.CheckAndAdd([]byte{1}))
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, cache.CheckAndAdd([]byte{1}))
+	assert.True(t, cache.CheckAndAdd([]byte{1}))
+}
+
+func TestNode_isNetworkRadiation(t *testing.T) {
+	n := FakeNodeWithPidAndAddr(nil, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+
+	//messages with the same content are handled once, whoever sends them
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: SyncBlock, data: []byte{1}, key: "peer1", uniOrBroadcast: Broadcast}))
+	assert.True(t, n.isNetworkRadiation(DapMsg{cmd: SyncBlock, data: []byte{1}, key: "peer2", uniOrBroadcast: Broadcast}))
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: SyncPeerList, data: []byte{1}, key: "peer1", uniOrBroadcast: Broadcast}))
+	//only gossiped commands are deduplicated
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: GetTip, key: "peer1", uniOrBroadcast: Broadcast}))
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: GetTip, key: "peer1", uniOrBroadcast: Broadcast}))
+	//unicast replies are handled even if their content was received before
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: SyncBlock, data: []byte{1}, key: "peer3", uniOrBroadcast: Unicast}))
+	//transactions are only sent on request, so they are not deduplicated
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: BroadcastTx, data: []byte{1}, key: "peer1", uniOrBroadcast: Broadcast}))
+	assert.False(t, n.isNetworkRadiation(DapMsg{cmd: BroadcastTx, data: []byte{1}, key: "peer2", uniOrBroadcast: Broadcast}))
+
+	//the messages broadcast by the node are not handled when they are relayed back
+	data, err := n.prepareData(&networkpb.Peerlist{}, SyncBlock, Broadcast)
+	assert.Nil(t, err)
+	dmpb := &networkpb.Dapmsg{}
+	assert.Nil(t, proto.Unmarshal(data, dmpb))
+	dm := DapMsg{}
+	dm.FromProto(dmpb)
+	assert.True(t, n.isNetworkRadiation(dm))
+
+	stats := n.GetDapMsgCacheStats()
+	assert.Equal(t, DedupCacheStats{Hits: 2, Misses: 1, Size: 2}, stats[SyncBlock])
+	assert.Len(t, stats, 2)
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dappley/go-dappley/core"
+	"github.com/dappley/go-dappley/network/pb"
+	"github.com/gogo/protobuf/proto"
+)
+
+//ProtocolMajorVersion is the major version of the protocol spoken with peers. It is the only version in the ID of the
+//streams, so that peers with different minor versions connect and compare their full versions in the handshake.
+const ProtocolMajorVersion = "1"
+
+//ProtocolVersion is the version of the protocol spoken with peers. Peers with a different major version are
+//disconnected.
+const ProtocolVersion = ProtocolMajorVersion + ".0.0"
+
+//capabilities of a node announced in the handshake
+const (
+	CapabilityTxInventory = "txinv"
+	CapabilityHeadersSync = "headers"
+	CapabilityGetBlocks   = "getblocks"
+)
+
+//handshakeTimeout is the time a peer has to complete the handshake before it is disconnected
+const handshakeTimeout = 10 * time.Second
+
+var localCapabilities = []string{CapabilityTxInventory, CapabilityHeadersSync, CapabilityGetBlocks}
+
+var (
+	ErrHandshakeRequired      = errors.New("ERROR: Peer sent a message before completing the handshake")
+	ErrHandshakeTimeout       = errors.New("ERROR: Peer did not complete the handshake in time")
+	ErrGenesisMismatch        = errors.New("ERROR: Peer has a different genesis block")
+	ErrIncompatibleProtocol   = errors.New("ERROR: Peer has an incompatible protocol version")
+	ErrInvalidHandshakeFormat = errors.New("ERROR: Handshake message is invalid")
+)
+
+//HelloMsg is exchanged by peers when they connect. The peer opening the stream sends it with the Hello command and the
+//other peer answers with its own in a HelloAck.
+type HelloMsg struct {
+	protocolVersion string
+	genesisHash     core.Hash
+	height          uint64
+	capabilities    []string
+}
+
+//NewHelloMsg returns the Hello describing the node running bc
+func NewHelloMsg(bc *core.Blockchain) *HelloMsg {
+	var genesisHash core.Hash
+	if genesis, err := bc.GetBlockByHeight(0); err == nil {
+		genesisHash = genesis.GetHash()
+	}
+	return &HelloMsg{ProtocolVersion, genesisHash, bc.GetMaxHeight(), localCapabilities}
+}
+
+func (h *HelloMsg) GetProtocolVersion() string { return h.protocolVersion }
+func (h *HelloMsg) GetGenesisHash() core.Hash  { return h.genesisHash }
+func (h *HelloMsg) GetHeight() uint64          { return h.height }
+func (h *HelloMsg) GetCapabilities() []string  { return h.capabilities }
+
+func (h *HelloMsg) HasCapability(capability string) bool {
+	for _, c := range h.capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+//verify returns an error if a peer sending h cannot talk to the node described by local
+func (h *HelloMsg) verify(local *HelloMsg) error {
+	if !bytes.Equal(h.genesisHash, local.genesisHash) {
+		return ErrGenesisMismatch
+	}
+	if majorVersion(h.protocolVersion) != majorVersion(local.protocolVersion) {
+		return ErrIncompatibleProtocol
+	}
+	return nil
+}
+
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
+func (h *HelloMsg) ToProto() proto.Message {
+	return &networkpb.Hello{
+		ProtocolVersion: h.protocolVersion,
+		GenesisHash:     h.genesisHash,
+		Height:          h.height,
+		Capabilities:    h.capabilities,
+	}
+}
+
+func (h *HelloMsg) FromProto(pb proto.Message) {
+	h.protocolVersion = pb.(*networkpb.Hello).ProtocolVersion
+	h.genesisHash = pb.(*networkpb.Hello).GenesisHash
+	h.height = pb.(*networkpb.Hello).Height
+	h.capabilities = pb.(*networkpb.Hello).Capabilities
+}
+
+//getFromProtoHelloMsg decodes the Hello carried by a Hello or HelloAck message
+func getFromProtoHelloMsg(data []byte) (*HelloMsg, error) {
+	hellopb := &networkpb.Hello{}
+	if err := proto.Unmarshal(data, hellopb); err != nil {
+		return nil, ErrInvalidHandshakeFormat
+	}
+	hello := &HelloMsg{}
+	hello.FromProto(hellopb)
+	return hello, nil
+}
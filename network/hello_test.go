@@ -0,0 +1,93 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"testing"
+
+	"github.com/dappley/go-dappley/core"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHelloMsg(t *testing.T) {
+	bc := core.GenerateMockBlockchain(5)
+	genesis, _ := bc.GetBlockByHeight(0)
+
+	hello := NewHelloMsg(bc)
+	assert.Equal(t, ProtocolVersion, hello.GetProtocolVersion())
+	//streams are opened with the major version only
+	assert.Equal(t, ProtocolMajorVersion, majorVersion(ProtocolVersion))
+	assert.Equal(t, "dappley/"+ProtocolMajorVersion, protocalName)
+	assert.Equal(t, genesis.GetHash(), hello.GetGenesisHash())
+	assert.Equal(t, uint64(5), hello.GetHeight())
+	assert.True(t, hello.HasCapability(CapabilityHeadersSync))
+	assert.False(t, hello.HasCapability("unknown"))
+
+	data, err := proto.Marshal(hello.ToProto())
+	assert.Nil(t, err)
+	decoded, err := getFromProtoHelloMsg(data)
+	assert.Nil(t, err)
+	assert.Equal(t, hello, decoded)
+
+	_, err = getFromProtoHelloMsg([]byte{0xFF})
+	assert.Equal(t, ErrInvalidHandshakeFormat, err)
+}
+
+func TestHelloMsg_verify(t *testing.T) {
+	local := &HelloMsg{"1.2.0", core.Hash("genesis"), 10, localCapabilities}
+
+	tests := []struct {
+		name   string
+		hello  *HelloMsg
+		retErr error
+	}{
+		{
+			name:   "SameVersion",
+			hello:  &HelloMsg{"1.2.0", core.Hash("genesis"), 20, nil},
+			retErr: nil,
+		},
+		{
+			name:   "DifferentMinorVersion",
+			hello:  &HelloMsg{"1.3.1", core.Hash("genesis"), 0, nil},
+			retErr: nil,
+		},
+		{
+			name:   "DifferentMajorVersion",
+			hello:  &HelloMsg{"2.0.0", core.Hash("genesis"), 20, nil},
+			retErr: ErrIncompatibleProtocol,
+		},
+		{
+			name:   "NoVersion",
+			hello:  &HelloMsg{"", core.Hash("genesis"), 20, nil},
+			retErr: ErrIncompatibleProtocol,
+		},
+		{
+			name:   "DifferentGenesis",
+			hello:  &HelloMsg{"1.2.0", core.Hash("other"), 20, nil},
+			retErr: ErrGenesisMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retErr, tt.hello.verify(local))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dappley/go-dappley/network/pb"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	//txRequestTimeout is how long a transaction requested from a peer is not requested again from other peers
+	txRequestTimeout = 30 * time.Second
+	//maxInventorySize is the maximum number of transaction IDs announced or requested in a single message
+	maxInventorySize = 500
+)
+
+//Inventory is a list of transaction IDs announced to or requested from a peer
+type Inventory struct {
+	hashes [][]byte
+}
+
+func NewInventory(hashes [][]byte) *Inventory {
+	return &Inventory{hashes}
+}
+
+func (inv *Inventory) GetHashes() [][]byte { return inv.hashes }
+
+func (inv *Inventory) ToProto() proto.Message {
+	return &networkpb.Inventory{Hashes: inv.hashes}
+}
+
+func (inv *Inventory) FromProto(pb proto.Message) {
+	inv.hashes = pb.(*networkpb.Inventory).Hashes
+}
+
+//txRequests keeps track of the transactions requested from peers that have not been received yet
+type txRequests struct {
+	requested map[string]time.Time
+	timeout   time.Duration
+	mutex     *sync.Mutex
+}
+
+func newTxRequests(timeout time.Duration) *txRequests {
+	return &txRequests{
+		requested: make(map[string]time.Time),
+		timeout:   timeout,
+		mutex:     &sync.Mutex{},
+	}
+}
+
+//add records a request for txid and returns false if txid is already requested and the request has not timed out
+func (r *txRequests) add(txid []byte) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if requestedAt, ok := r.requested[string(txid)]; ok && now.Sub(requestedAt) < r.timeout {
+		return false
+	}
+	if len(r.requested) >= maxInventorySize {
+		r.removeExpired(now)
+	}
+	r.requested[string(txid)] = now
+	return true
+}
+
+//remove forgets the request for txid once the transaction is received. It returns false if txid was not requested.
+func (r *txRequests) remove(txid []byte) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.requested[string(txid)]; !ok {
+		return false
+	}
+	delete(r.requested, string(txid))
+	return true
+}
+
+func (r *txRequests) removeExpired(now time.Time) {
+	for txid, requestedAt := range r.requested {
+		if now.Sub(requestedAt) >= r.timeout {
+			delete(r.requested, txid)
+		}
+	}
+}
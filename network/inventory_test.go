@@ -0,0 +1,92 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dappley/go-dappley/core"
+	"github.com/dappley/go-dappley/network/pb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventory_Proto(t *testing.T) {
+	inv := NewInventory([][]byte{{1}, {2, 3}})
+
+	data, err := proto.Marshal(inv.ToProto())
+	assert.Nil(t, err)
+	invpb := &networkpb.Inventory{}
+	assert.Nil(t, proto.Unmarshal(data, invpb))
+
+	decoded := &Inventory{}
+	decoded.FromProto(invpb)
+	assert.Equal(t, inv, decoded)
+}
+
+func TestTxRequests_Add(t *testing.T) {
+	requests := newTxRequests(50 * time.Millisecond)
+
+	assert.True(t, requests.add([]byte{1}))
+	assert.False(t, requests.add([]byte{1}))
+	assert.True(t, requests.add([]byte{2}))
+
+	//a received transaction can be requested again
+	requests.remove([]byte{1})
+	assert.True(t, requests.add([]byte{1}))
+
+	//a request that timed out can be sent to another peer
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, requests.add([]byte{2}))
+}
+
+func TestTxRequests_RemoveExpired(t *testing.T) {
+	requests := newTxRequests(time.Millisecond)
+	for i := 0; i < maxInventorySize; i++ {
+		requests.add([]byte{byte(i), byte(i >> 8)})
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	assert.True(t, requests.add([]byte("new")))
+	assert.Len(t, requests.requested, 1)
+}
+
+func TestNode_getMissingTxs(t *testing.T) {
+	bc := core.GenerateMockBlockchain(1)
+	n := FakeNodeWithPidAndAddr(bc, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+
+	pooledTx := core.MockTransaction()
+	pooledTx.ID = []byte{1}
+	bc.GetTxPool().Push(*pooledTx)
+
+	missing := n.getMissingTxs([][]byte{{1}, {2}, {3}})
+	assert.Equal(t, [][]byte{{2}, {3}}, missing)
+
+	//transactions already requested are not requested again from another peer
+	missing = n.getMissingTxs([][]byte{{2}, {3}, {4}})
+	assert.Equal(t, [][]byte{{4}}, missing)
+
+	//inventories larger than the limit are truncated
+	txids := [][]byte{}
+	for i := 0; i < maxInventorySize+10; i++ {
+		txids = append(txids, []byte{5, byte(i), byte(i >> 8)})
+	}
+	assert.Len(t, n.getMissingTxs(txids), maxInventorySize)
+}
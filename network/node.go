@@ -19,9 +19,11 @@
 package network
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
 	"errors"
@@ -30,11 +32,11 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"io/ioutil"
-	"sync"
 
 	"github.com/dappley/go-dappley/core"
 	"github.com/dappley/go-dappley/core/pb"
 	"github.com/dappley/go-dappley/network/pb"
+	"github.com/dappley/go-dappley/storage"
 	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-crypto"
@@ -47,53 +49,162 @@ import (
 )
 
 const (
-	protocalName           = "dappley/1.0.0"
+	protocalName           = "dappley/" + ProtocolMajorVersion
 	syncPeerTimeLimitMs    = 1000
 	MaxMsgCountBeforeReset = 999999
+	//MaxGetBlocksCount is the maximum number of blocks sent in response to GetBlocks
+	MaxGetBlocksCount = 128
+	//messageOverhead is the number of bytes of a frame reserved for the envelope of a message carrying blocks
+	messageOverhead = 1024
+	//bannedPeersKey is the key under which the banned peers are saved across restarts
+	bannedPeersKey = "bannedPeers"
+	//DefaultMaxOutboundPeers is the default number of peers the node keeps dialed
+	DefaultMaxOutboundPeers = 8
+	//DefaultMaxInboundPeers is the default number of peers the node accepts connections from
+	DefaultMaxInboundPeers = 12
+	//reconnectInterval is the interval at which the node dials known peers when it has too few outbound peers
+	reconnectInterval = 10 * time.Second
+	//minSeedRetryInterval is the time to wait before dialing the seeds again when none of them can be reached. It
+	//doubles after every attempt.
+	minSeedRetryInterval = 5 * time.Second
+	//maxSeedRetryInterval is the maximum time to wait before dialing the seeds again
+	maxSeedRetryInterval = 5 * time.Minute
 )
 
 var (
-	ErrDapMsgNoCmd  = errors.New("ERROR: Dappley message has no command input")
-	ErrIsInPeerlist = errors.New("ERROR: Peer already exists in peerlist")
+	ErrDapMsgNoCmd   = errors.New("ERROR: Dappley message has no command input")
+	ErrIsInPeerlist  = errors.New("ERROR: Peer already exists in peerlist")
+	ErrPeerBanned    = errors.New("ERROR: Peer is banned")
+	ErrPeerNotBanned = errors.New("ERROR: Peer is not banned")
 )
 
 type Node struct {
 	host                   host.Host
 	info                   *Peer
 	bc                     *core.Blockchain
-	streams                map[peer.ID]*Stream
+	streams                *StreamRegistry
 	peerList               *PeerList
 	exitCh                 chan bool
-	recentlyRcvedDapMsgs   *sync.Map
+	stopOnce               *sync.Once
+	dapMsgCaches           map[string]*DedupCache
 	dapMsgBroadcastCounter *uint64
 	privKey                crypto.PrivKey
+	txRequests             *txRequests
+	syncManager            *SyncManager
+	maxFrameSize           int
+	addressBook            *AddressBook
+	maxOutboundPeers       int
+	maxInboundPeers        int
+	seeds                  []*Peer
+	peerDb                 storage.Storage
+	txRelayCh              chan core.Transaction
 }
 
-//create new Node instance
+//create new Node instance. The address book and the banned peers are only kept in memory.
 func NewNode(bc *core.Blockchain) *Node {
+	return NewNodeWithPeerDb(bc, nil)
+}
+
+//NewNodeWithPeerDb returns a node saving the address book and the banned peers in peerDb. peerDb must not be the
+//database of the blockchain, whose writes are batched while blocks are added.
+func NewNodeWithPeerDb(bc *core.Blockchain, peerDb storage.Storage) *Node {
 	placeholder := uint64(0)
-	return &Node{nil,
+	node := &Node{nil,
 		nil,
 		bc,
-		make(map[peer.ID]*Stream, 10),
+		NewStreamRegistry(),
 		NewPeerList(nil),
 		make(chan bool, 1),
-		&sync.Map{},
+		&sync.Once{},
+		newDapMsgCaches(),
 		&placeholder,
 		nil,
+		newTxRequests(txRequestTimeout),
+		nil,
+		DefaultMaxFrameSize,
+		NewAddressBook(peerDb),
+		DefaultMaxOutboundPeers,
+		DefaultMaxInboundPeers,
+		nil,
+		peerDb,
+		nil,
+	}
+	node.syncManager = NewSyncManager(bc, node)
+	node.loadBannedPeers()
+	return node
+}
+
+//newDapMsgCaches returns the caches of the commands whose messages are only handled once, even if several peers send
+//them
+func newDapMsgCaches() map[string]*DedupCache {
+	caches := make(map[string]*DedupCache)
+	for _, cmd := range []string{SyncBlock, SyncPeerList} {
+		caches[cmd] = NewDedupCache(DefaultDedupCacheSize, DefaultDedupCacheTTL)
 	}
+	return caches
 }
 
+//isNetworkRadiation returns true if the node received the same broadcast recently. Unicast messages are replies
+//requested by the node and are always handled.
 func (n *Node) isNetworkRadiation(dapmsg DapMsg) bool {
-	if _, value := n.recentlyRcvedDapMsgs.Load(dapmsg.GetKey()); value == true {
-		return true
+	if dapmsg.uniOrBroadcast != Broadcast {
+		return false
 	}
-	return false
+	cache, ok := n.dapMsgCaches[dapmsg.GetCmd()]
+	if !ok {
+		return false
+	}
+	return cache.CheckAndAdd(dapmsg.GetHash())
 }
 
 func (n *Node) GetBlockchain() *core.Blockchain    { return n.bc }
 func (n *Node) GetPeerList() *PeerList             { return n.peerList }
-func (n *Node) GetRecentlyRcvedDapMsgs() *sync.Map { return n.recentlyRcvedDapMsgs }
+func (n *Node) GetSyncManager() *SyncManager       { return n.syncManager }
+func (n *Node) GetAddressBook() *AddressBook       { return n.addressBook }
+func (n *Node) GetStreamRegistry() *StreamRegistry { return n.streams }
+
+//GetDapMsgCache returns the cache of the messages received with cmd, or nil if they are not deduplicated
+func (n *Node) GetDapMsgCache(cmd string) *DedupCache { return n.dapMsgCaches[cmd] }
+
+//GetDapMsgCacheStats returns the statistics of the cache of every deduplicated command
+func (n *Node) GetDapMsgCacheStats() map[string]DedupCacheStats {
+	stats := make(map[string]DedupCacheStats)
+	for cmd, cache := range n.dapMsgCaches {
+		stats[cmd] = cache.GetStats()
+	}
+	return stats
+}
+
+//SetMaxFrameSize sets the maximum size of the messages sent to and received from peers. Peers sending larger messages
+//are disconnected.
+func (n *Node) SetMaxFrameSize(maxFrameSize int) {
+	n.maxFrameSize = maxFrameSize
+}
+
+//SetPeerTargets sets the number of peers the node keeps dialed and the number of peers it accepts connections from.
+//Targets that are not positive are left unchanged. Connections are also limited to PEERLISTMAXSIZE peers in total.
+func (n *Node) SetPeerTargets(maxOutboundPeers int, maxInboundPeers int) {
+	if maxOutboundPeers > 0 {
+		n.maxOutboundPeers = maxOutboundPeers
+	}
+	if maxInboundPeers > 0 {
+		n.maxInboundPeers = maxInboundPeers
+	}
+}
+
+//SetSeeds sets the full addresses of the peers dialed to join the network when the node starts. Invalid addresses are
+//ignored.
+func (n *Node) SetSeeds(seeds []string) {
+	n.seeds = nil
+	for _, seed := range seeds {
+		p, err := CreatePeerFromString(seed)
+		if err != nil {
+			logger.Warn("Node: Invalid seed address ", seed, ": ", err)
+			continue
+		}
+		n.seeds = append(n.seeds, p)
+	}
+}
 
 func (n *Node) Start(listenPort int) error {
 
@@ -104,13 +215,32 @@ func (n *Node) Start(listenPort int) error {
 
 	n.host = h
 	n.info, err = CreatePeerFromMultiaddr(addr)
+	if n.privKey == nil {
+		//sign messages with the identity generated by the host
+		n.privKey = h.Peerstore().PrivKey(h.ID())
+	}
 
 	//set streamhandler. streamHanlder function is called upon stream connection
 	n.host.SetStreamHandler(protocalName, n.streamHandler)
 	n.StartRequestLoop()
+	n.StartTxRelayLoop()
+	n.StartReconnectLoop()
+	n.StartBootstrap()
+	n.syncManager.Start()
 	return err
 }
 
+//Stop stops the loops started by Start. It can be called more than once, but the node cannot be started again.
+func (n *Node) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.exitCh)
+		n.syncManager.Stop()
+		if n.txRelayCh != nil {
+			n.bc.GetTxPool().Unsubscribe(n.txRelayCh)
+		}
+	})
+}
+
 func (n *Node) StartRequestLoop() {
 
 	go func() {
@@ -119,13 +249,114 @@ func (n *Node) StartRequestLoop() {
 			case <-n.exitCh:
 				return
 			case brPars := <-n.bc.GetBlockPool().BlockRequestCh():
-				n.RequestBlockUnicast(brPars.BlockHash, brPars.Pid)
+				n.RequestBlocksUnicast(brPars.Pid)
+			case rcvedTx := <-n.bc.GetTxPool().InvalidTxCh():
+				n.misbehave(rcvedTx.Pid, InvalidTxPenalty, "sent an invalid transaction")
 			}
 		}
 	}()
 
 }
 
+//StartTxRelayLoop starts verifying the transactions received from peers and announcing every transaction admitted to
+//the transaction pool. Transactions admitted together are announced in a single inventory.
+func (n *Node) StartTxRelayLoop() {
+	txPool := n.bc.GetTxPool()
+	txCh := txPool.Subscribe()
+	//closed when the node stops, which ends the loop
+	n.txRelayCh = txCh
+	txPool.Start()
+
+	go func() {
+		for tx := range txCh {
+			txids := [][]byte{tx.ID}
+			for len(txids) < maxInventorySize && len(txCh) > 0 {
+				next, ok := <-txCh
+				if !ok {
+					break
+				}
+				txids = append(txids, next.ID)
+			}
+			n.BroadcastTxInv(txids)
+		}
+	}()
+}
+
+//StartReconnectLoop starts dialing the peers of the address book whenever the node has fewer outbound peers than its
+//target
+func (n *Node) StartReconnectLoop() {
+	go func() {
+		ticker := time.NewTicker(reconnectInterval)
+		defer ticker.Stop()
+		n.dialPeers()
+		for {
+			select {
+			case <-n.exitCh:
+				return
+			case <-ticker.C:
+				n.dialPeers()
+			}
+		}
+	}()
+}
+
+//StartBootstrap dials the seeds until the node is connected to one of them, retrying with backoff. Meanwhile the
+//reconnect loop dials the peers learned in previous runs, so the node joins the network even if no seed can be reached.
+func (n *Node) StartBootstrap() {
+	if len(n.seeds) == 0 {
+		return
+	}
+	go func() {
+		retryInterval := minSeedRetryInterval
+		for !n.dialSeeds() {
+			logger.Warn("Node: None of the seeds can be reached. Retrying in ", retryInterval)
+			select {
+			case <-n.exitCh:
+				return
+			case <-time.After(retryInterval):
+			}
+			retryInterval *= 2
+			if retryInterval > maxSeedRetryInterval {
+				retryInterval = maxSeedRetryInterval
+			}
+		}
+	}()
+}
+
+//dialSeeds dials the seeds the node is not connected to and returns true if it is connected to at least one seed
+func (n *Node) dialSeeds() bool {
+	connected := false
+	for _, seed := range n.seeds {
+		if !n.streams.IsConnected(seed.peerid) {
+			if err := n.AddStream(seed.peerid, seed.addr); err != nil && err != ErrIsInPeerlist {
+				logger.Warn("Node: Unable to dial seed ", seed.addr, ": ", err)
+			}
+		}
+		if n.streams.IsConnected(seed.peerid) {
+			connected = true
+		}
+	}
+	return connected
+}
+
+//dialPeers dials peers of the address book until the node has maxOutboundPeers outbound peers
+func (n *Node) dialPeers() {
+	missing := n.maxOutboundPeers - n.streams.Count(true)
+	if missing <= 0 {
+		return
+	}
+	candidates := n.addressBook.GetDialCandidates(missing, func(pid peer.ID) bool {
+		return n.streams.IsConnected(pid) || pid == n.GetPeerID() || n.peerList.IsBanned(pid)
+	})
+	for _, p := range candidates {
+		err := n.AddStream(p.peerid, p.addr)
+		if err != nil && err != ErrIsInPeerlist && err != ErrPeerBanned {
+			logger.Debug("Node: Unable to dial peer ", p.peerid, ": ", err)
+			n.addressBook.MarkFailure(p.peerid)
+		}
+	}
+}
+
 //LoadNetworkKeyFromFile reads the network privatekey from a file
 func (n *Node) LoadNetworkKeyFromFile(filePath string) error {
 	bytes, err := ioutil.ReadFile(filePath)
@@ -209,6 +440,9 @@ func (n *Node) AddStream(peerid peer.ID, targetAddr ma.Multiaddr) error {
 		logger.Debug(targetAddr.String() + " is already in peerlist of " + n.GetPeerMultiaddr().String())
 		return ErrIsInPeerlist
 	}
+	if n.peerList.IsBanned(peerid) {
+		return ErrPeerBanned
+	}
 
 	n.host.Peerstore().AddAddr(peerid, targetAddr, pstore.PermanentAddrTTL)
 
@@ -218,31 +452,131 @@ func (n *Node) AddStream(peerid peer.ID, targetAddr ma.Multiaddr) error {
 		return err
 	}
 	// Create a buffered stream so that read and write are non blocking.
-	n.streamHandler(stream)
-
-	// Add the peer list
-	if len(n.peerList.peers) >= PEERLISTMAXSIZE {
-		n.peerList.RemoveOneIP(&Peer{peerid, targetAddr})
-	}
-	n.peerList.Add(&Peer{peerid, targetAddr})
+	n.startStream(stream, true)
 
 	return nil
 }
 
 func (n *Node) streamHandler(s net.Stream) {
+	n.startStream(s, false)
+}
+
+//startStream starts exchanging messages on a stream. The node sends its Hello if it opened the stream, and waits for the
+//Hello of the peer otherwise. The peer is disconnected if the handshake is not completed within handshakeTimeout.
+func (n *Node) startStream(s net.Stream, outbound bool) {
 	// Create a buffer stream for non blocking read and write.
 	logger.Info(n.GetPeerMultiaddr(), " Connected Stream to Peer Addr:", s.Conn().RemoteMultiaddr())
 
 	peer := &Peer{s.Conn().RemotePeer(), s.Conn().RemoteMultiaddr()}
-	if !n.peerList.ListIsFull() && !n.peerList.IsInPeerlist(peer) {
-		n.peerList.Add(peer)
-		//start stream
-		ns := NewStream(s, n)
-		n.streams[s.Conn().RemotePeer()] = ns
-		ns.Start()
+	if n.peerList.IsBanned(peer.peerid) {
+		logger.Info("Node: Refused stream from banned peer ", peer.peerid)
+		s.Close()
+		return
+	}
+	if !outbound && n.streams.Count(false) >= n.maxInboundPeers {
+		logger.Info("Node: Refused stream from ", peer.peerid, ": the node has too many inbound peers")
+		s.Close()
+		return
+	}
+	if n.peerList.ListIsFull() || n.peerList.IsInPeerlist(peer) {
+		s.Close()
+		return
+	}
+	//start stream
+	ns := NewStream(s, n)
+	ns.outbound = outbound
+	if err := n.streams.Add(ns); err != nil {
+		logger.Debug("Node: Closing stream to ", peer.peerid, ": ", err)
+		s.Close()
+		return
+	}
+	n.peerList.Add(peer)
+	ns.Start()
+	if outbound {
+		ns.sendHello(Hello)
+	}
+	go ns.waitForHandshake(handshakeTimeout)
+}
+
+//onStreamStopped removes the peer of a stopped stream from the node
+func (n *Node) onStreamStopped(s *Stream) {
+	if !n.streams.Remove(s) {
+		return
+	}
+	n.peerList.DeletePeer(&Peer{s.peerID, s.remoteAddr})
+	n.syncManager.RemovePeer(s.peerID)
+}
+
+//onHandshake starts exchanging peers and blocks with a peer that completed the handshake. Peers the node dialed are
+//recorded in the address book.
+func (n *Node) onHandshake(s *Stream, hello *HelloMsg) {
+	logger.Info("Node: Completed handshake with ", s.peerID, " at height ", hello.GetHeight())
+	if s.outbound {
+		n.addressBook.MarkSuccess(&Peer{s.peerID, s.remoteAddr})
+	}
+	n.SyncPeersUnicast(s.peerID)
+	n.syncManager.OnTip(s.peerID, hello.GetHeight())
+}
+
+//misbehave raises the misbehaviour score of a peer and bans it once the score reaches BanThreshold
+func (n *Node) misbehave(pid peer.ID, penalty int, reason string) {
+	score := n.peerList.AddMisbehaviour(pid, penalty)
+	logger.Warn("Node: Peer ", pid, " ", reason, ". Misbehaviour score: ", score)
+	if score >= BanThreshold {
+		n.BanPeer(pid, DefaultBanDuration)
+	}
+}
 
-		n.SyncPeersUnicast(peer.peerid)
+//BanPeer disconnects a peer and refuses to connect to it for duration
+func (n *Node) BanPeer(pid peer.ID, duration time.Duration) error {
+	logger.Info("Node: Banning peer ", pid, " for ", duration)
+	n.peerList.Ban(pid, time.Now().Add(duration))
+	n.addressBook.Remove(pid)
+	if s, ok := n.streams.Get(pid); ok {
+		s.StopStream()
 	}
+	return n.saveBannedPeers()
+}
+
+//UnbanPeer allows connecting to a banned peer again
+func (n *Node) UnbanPeer(pid peer.ID) error {
+	if !n.peerList.Unban(pid) {
+		return ErrPeerNotBanned
+	}
+	logger.Info("Node: Unbanned peer ", pid)
+	return n.saveBannedPeers()
+}
+
+//GetBannedPeers returns the banned peers and the time their bans end
+func (n *Node) GetBannedPeers() map[peer.ID]time.Time {
+	return n.peerList.GetBannedPeers()
+}
+
+func (n *Node) saveBannedPeers() error {
+	if n.peerDb == nil {
+		return nil
+	}
+	data, err := proto.Marshal(n.peerList.BansToProto())
+	if err != nil {
+		return err
+	}
+	return n.peerDb.Put([]byte(bannedPeersKey), data)
+}
+
+func (n *Node) loadBannedPeers() {
+	if n.peerDb == nil {
+		return
+	}
+	data, err := n.peerDb.Get([]byte(bannedPeersKey))
+	if err != nil {
+		return
+	}
+	bannedPeers := &networkpb.BannedPeers{}
+	if err := proto.Unmarshal(data, bannedPeers); err != nil {
+		logger.Warn("Node: Saved banned peers cannot be decoded")
+		return
+	}
+	n.peerList.BansFromProto(bannedPeers)
 }
 
 func (n *Node) GetInfo() *Peer { return n.info }
@@ -280,9 +614,15 @@ func (n *Node) prepareData(msgData proto.Message, cmd string, uniOrBroadcast int
 
 	//build a dappley message
 	dm := NewDapmsg(cmd, bytes, n.info.peerid.String()+strconv.FormatUint(*n.dapMsgBroadcastCounter, 10), uniOrBroadcast, n.dapMsgBroadcastCounter)
-	if dm.cmd == SyncBlock {
-		logger.Debug("Node: ", n.info.peerid, " broadcasting block with key ", dm.key)
-		n.cacheDapMsg(*dm)
+	//sign broadcasts so that the peers they are relayed to can verify they were not modified on the way
+	if uniOrBroadcast == Broadcast && n.privKey != nil {
+		if err := dm.Sign(n.privKey); err != nil {
+			return nil, err
+		}
+	}
+	if cache, ok := n.dapMsgCaches[cmd]; ok && uniOrBroadcast == Broadcast {
+		logger.Debug("Node: ", n.info.peerid, " broadcasting ", cmd, " with key ", dm.key)
+		cache.Add(dm.GetHash())
 	}
 	data, err := proto.Marshal(dm.ToProto())
 	if err != nil {
@@ -310,8 +650,13 @@ func (n *Node) SyncPeersBroadcast() error {
 	return nil
 }
 
+//TxBroadcast announces the transaction to all peers. Peers that don't have it request its body with GetTx.
 func (n *Node) TxBroadcast(tx *core.Transaction) error {
-	data, err := n.prepareData(tx.ToProto(), BroadcastTx, Broadcast)
+	return n.BroadcastTxInv([][]byte{tx.ID})
+}
+
+func (n *Node) BroadcastTxInv(txids [][]byte) error {
+	data, err := n.prepareData(NewInventory(txids).ToProto(), TxInv, Broadcast)
 	if err != nil {
 		return err
 	}
@@ -324,17 +669,23 @@ func (n *Node) SyncPeersUnicast(pid peer.ID) error {
 	if err != nil {
 		return err
 	}
-	n.unicast(data, pid)
-	return nil
+	return n.unicast(data, pid)
 }
 
-func (n *Node) BroadcastTxCmd(txn *core.Transaction) error {
-	data, err := n.prepareData(txn.ToProto(), BroadcastTx, Broadcast)
+func (n *Node) RequestTxsUnicast(txids [][]byte, pid peer.ID) error {
+	data, err := n.prepareData(NewInventory(txids).ToProto(), GetTx, Unicast)
 	if err != nil {
 		return err
 	}
-	n.broadcast(data)
-	return nil
+	return n.unicast(data, pid)
+}
+
+func (n *Node) SendTxUnicast(tx *core.Transaction, pid peer.ID) error {
+	data, err := n.prepareData(tx.ToProto(), BroadcastTx, Unicast)
+	if err != nil {
+		return err
+	}
+	return n.unicast(data, pid)
 }
 
 func (n *Node) SendBlockUnicast(block *core.Block, pid peer.ID) error {
@@ -342,8 +693,7 @@ func (n *Node) SendBlockUnicast(block *core.Block, pid peer.ID) error {
 	if err != nil {
 		return err
 	}
-	n.unicast(data, pid)
-	return nil
+	return n.unicast(data, pid)
 }
 
 func (n *Node) RequestBlockUnicast(hash core.Hash, pid peer.ID) error {
@@ -354,25 +704,80 @@ func (n *Node) RequestBlockUnicast(hash core.Hash, pid peer.ID) error {
 	if err != nil {
 		return err
 	}
-	n.unicast(data, pid)
+	return n.unicast(data, pid)
+}
+
+//RequestBlocksUnicast requests the blocks following the latest block in common with the blockchain of the peer
+func (n *Node) RequestBlocksUnicast(pid peer.ID) error {
+	request := &networkpb.GetBlocksRequest{MaxCount: MaxGetBlocksCount}
+	for _, hash := range n.bc.GetBlockLocator() {
+		request.Locator = append(request.Locator, hash)
+	}
+	data, err := n.prepareData(request, GetBlocks, Unicast)
+	if err != nil {
+		return err
+	}
+	return n.unicast(data, pid)
+}
+
+func (n *Node) RequestTipBroadcast() error {
+	data, err := n.prepareData(nil, GetTip, Broadcast)
+	if err != nil {
+		return err
+	}
+	n.broadcast(data)
 	return nil
 }
 
-//broadcast data
+func (n *Node) RequestTipUnicast(pid peer.ID) error {
+	data, err := n.prepareData(nil, GetTip, Unicast)
+	if err != nil {
+		return err
+	}
+	return n.unicast(data, pid)
+}
+
+func (n *Node) RequestHeadersUnicast(startHeight uint64, count uint64, pid peer.ID) error {
+	request := &networkpb.GetHeadersRequest{StartHeight: startHeight, Count: count}
+	data, err := n.prepareData(request, GetHeaders, Unicast)
+	if err != nil {
+		return err
+	}
+	return n.unicast(data, pid)
+}
+
+func (n *Node) RequestBodiesUnicast(hashes [][]byte, pid peer.ID) error {
+	data, err := n.prepareData(NewInventory(hashes).ToProto(), GetBodies, Unicast)
+	if err != nil {
+		return err
+	}
+	return n.unicast(data, pid)
+}
+
+//broadcast data to the peers that completed the handshake
 func (n *Node) broadcast(data []byte) {
-	for _, s := range n.streams {
-		s.Send(data)
+	for _, s := range n.streams.GetAll() {
+		if !s.isHandshaked() {
+			continue
+		}
+		if err := s.Send(data); err != nil {
+			logger.Debug("Node: Unable to send to ", s.peerID, ": ", err)
+		}
 	}
 }
 
-//unicast data
-func (n *Node) unicast(data []byte, pid peer.ID) {
-	n.streams[pid].Send(data)
+//unicast data. It returns ErrPeerNotConnected if the node has no stream to the peer.
+func (n *Node) unicast(data []byte, pid peer.ID) error {
+	s, ok := n.streams.Get(pid)
+	if !ok {
+		return ErrPeerNotConnected
+	}
+	return s.Send(data)
 }
 
-func (n *Node) addBlockToPool(block *core.Block, pid peer.ID) {
+func (n *Node) addBlockToPool(block *core.Block, pid peer.ID) error {
 	//add block to blockpool. Make sure this is none blocking.
-	n.bc.GetBlockPool().Push(block, pid)
+	return n.bc.GetBlockPool().Push(block, pid)
 }
 
 func (n *Node) getFromProtoBlockMsg(data []byte) *core.Block {
@@ -393,24 +798,22 @@ func (n *Node) getFromProtoBlockMsg(data []byte) *core.Block {
 	return block
 }
 func (n *Node) syncBlockHandler(dm *DapMsg, pid peer.ID) {
-	if n.isNetworkRadiation(*dm) {
-		logger.Debug("Node: ", n.GetPeerID(), " Already received ", dm.GetKey(), " before")
-		return
-	}
-
-	n.RelayDapMsg(*dm)
-	n.cacheDapMsg(*dm)
 	blk := n.getFromProtoBlockMsg(dm.GetData())
 	logger.Debug("Node: ", n.GetPeerID(), " Received Block: Hash:", hex.EncodeToString(blk.GetHash()), ", Height:", blk.GetHeight())
 
-	n.addBlockToPool(blk, pid)
-}
-
-func (n *Node) cacheDapMsg(dm DapMsg) {
-	n.recentlyRcvedDapMsgs.Store(dm.GetKey(), true)
+	if err := n.addBlockToPool(blk, pid); err != nil {
+		n.misbehave(pid, InvalidBlockPenalty, "sent an invalid block")
+		//the signature of the message was verified when it was received, so the node that created it is known
+		if origin := dm.GetOrigin(); origin != "" && origin != pid {
+			n.misbehave(origin, InvalidBlockPenalty, "created an invalid block")
+		}
+		return
+	}
+	//only relay blocks that pass verification so that peers are not penalized for blocks they forward
+	n.RelayDapMsg(*dm)
 }
 
-func (n *Node) addTxToPool(data []byte) {
+func (n *Node) addTxToPool(data []byte, pid peer.ID) {
 
 	//create a block proto
 	txpb := &corepb.Transaction{}
@@ -418,6 +821,8 @@ func (n *Node) addTxToPool(data []byte) {
 	//unmarshal byte to proto
 	if err := proto.Unmarshal(data, txpb); err != nil {
 		logger.Warn(err)
+		n.misbehave(pid, MalformedMessagePenalty, "sent a malformed transaction")
+		return
 	}
 
 	//create an empty tx
@@ -425,8 +830,66 @@ func (n *Node) addTxToPool(data []byte) {
 
 	//load the tx with proto
 	tx.FromProto(txpb)
-	//add tx to txpool
-	n.bc.GetTxPool().Push(*tx)
+	if !n.txRequests.remove(tx.ID) {
+		n.misbehave(pid, UnsolicitedDataPenalty, "sent a transaction that was not requested")
+		return
+	}
+	if n.bc.GetTxPool().GetTransaction(tx.ID) != nil {
+		return
+	}
+	//queue tx to be verified and added to txpool
+	n.bc.GetTxPool().PushTransaction(*tx, pid)
+}
+
+func (n *Node) getFromProtoInventoryMsg(data []byte) *Inventory {
+	invpb := &networkpb.Inventory{}
+	if err := proto.Unmarshal(data, invpb); err != nil {
+		logger.Warn(err)
+	}
+
+	inv := &Inventory{}
+	inv.FromProto(invpb)
+	return inv
+}
+
+//txInvHandler requests the announced transactions that are neither in the transaction pool nor already requested
+func (n *Node) txInvHandler(data []byte, pid peer.ID) {
+	missing := n.getMissingTxs(n.getFromProtoInventoryMsg(data).GetHashes())
+	if len(missing) == 0 {
+		return
+	}
+	n.RequestTxsUnicast(missing, pid)
+}
+
+//getMissingTxs returns the transaction IDs that are neither in the transaction pool nor already requested, and records
+//them as requested
+func (n *Node) getMissingTxs(txids [][]byte) [][]byte {
+	if len(txids) > maxInventorySize {
+		txids = txids[:maxInventorySize]
+	}
+	missing := [][]byte{}
+	for _, txid := range txids {
+		if n.bc.GetTxPool().GetTransaction(txid) != nil {
+			continue
+		}
+		if n.txRequests.add(txid) {
+			missing = append(missing, txid)
+		}
+	}
+	return missing
+}
+
+//getTxHandler sends the requested transactions that are in the transaction pool
+func (n *Node) getTxHandler(data []byte, pid peer.ID) {
+	txids := n.getFromProtoInventoryMsg(data).GetHashes()
+	if len(txids) > maxInventorySize {
+		txids = txids[:maxInventorySize]
+	}
+	for _, txid := range txids {
+		if tx := n.bc.GetTxPool().GetTransaction(txid); tx != nil {
+			n.SendTxUnicast(tx, pid)
+		}
+	}
 }
 
 func (n *Node) addMultiPeers(data []byte) {
@@ -446,24 +909,20 @@ func (n *Node) addMultiPeers(data []byte) {
 		//load the block with proto
 		pl.FromProto(plpb)
 
-		//remove the node's own peer info from the list
-		newpl := &PeerList{[]*Peer{n.info}}
+		//record the new peers in the address book, except the node itself
+		newpl := &PeerList{peers: []*Peer{n.info}}
 		newpl = newpl.FindNewPeers(pl)
-		//find the new added peers
-		newpl = n.peerList.FindNewPeers(newpl)
-
-		//wait for random time within the time limit
-		time.Sleep(time.Millisecond * time.Duration(rand.Intn(syncPeerTimeLimitMs)))
-
-		//add streams for new peers
 		for _, p := range newpl.GetPeerlist() {
-			if !n.peerList.IsInPeerlist(p) && p.peerid != n.info.peerid {
-				n.AddStream(p.peerid, p.addr)
+			if p.addr != nil && p.peerid != "" && !n.peerList.IsBanned(p.peerid) {
+				n.addressBook.Add(p)
 			}
 		}
 
-		//add peers
-		n.peerList.MergePeerlist(newpl)
+		//wait for random time within the time limit
+		time.Sleep(time.Millisecond * time.Duration(rand.Intn(syncPeerTimeLimitMs)))
+
+		//dial new peers if the node needs more outbound peers
+		n.dialPeers()
 	}()
 }
 
@@ -476,3 +935,239 @@ func (n *Node) sendRequestedBlock(hash []byte, pid peer.ID) {
 	block := core.Deserialize(blockBytes)
 	n.SendBlockUnicast(block, pid)
 }
+
+func (n *Node) sendTip(pid peer.ID) {
+	tip := &networkpb.ChainTip{Hash: n.bc.GetTailBlockHash(), Height: n.bc.GetMaxHeight()}
+	data, err := n.prepareData(tip, Tip, Unicast)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := n.unicast(data, pid); err != nil {
+		logger.Debug("Node: Unable to send to ", pid, ": ", err)
+	}
+}
+
+func (n *Node) tipHandler(data []byte, pid peer.ID) {
+	tip := &networkpb.ChainTip{}
+	if err := proto.Unmarshal(data, tip); err != nil {
+		logger.Warn(err)
+		return
+	}
+	n.syncManager.OnTip(pid, tip.Height)
+}
+
+//getHeaders returns the headers of the blocks of the blockchain from startHeight, at most maxHeadersPerRequest of them
+func (n *Node) getHeaders(startHeight uint64, count uint64) []*corepb.BlockHeader {
+	if count > maxHeadersPerRequest {
+		count = maxHeadersPerRequest
+	}
+	headers := []*corepb.BlockHeader{}
+	for height := startHeight; height < startHeight+count; height++ {
+		block, err := n.bc.GetBlockByHeight(height)
+		if err != nil {
+			break
+		}
+		headers = append(headers, block.GetHeader().ToProto().(*corepb.BlockHeader))
+	}
+	return headers
+}
+
+func (n *Node) sendHeaders(data []byte, pid peer.ID) {
+	request := &networkpb.GetHeadersRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		logger.Warn(err)
+		return
+	}
+	headers := &networkpb.BlockHeaders{Headers: n.getHeaders(request.StartHeight, request.Count)}
+	data, err := n.prepareData(headers, Headers, Unicast)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := n.unicast(data, pid); err != nil {
+		logger.Debug("Node: Unable to send to ", pid, ": ", err)
+	}
+}
+
+func (n *Node) headersHandler(data []byte, pid peer.ID) {
+	headerspb := &networkpb.BlockHeaders{}
+	if err := proto.Unmarshal(data, headerspb); err != nil {
+		logger.Warn(err)
+		return
+	}
+	n.syncManager.OnHeaders(pid, getHeadersFromProto(headerspb.Headers))
+}
+
+//getHeadersFromProto returns blocks without transactions carrying the headers
+func getHeadersFromProto(headerspb []*corepb.BlockHeader) []*core.Block {
+	headers := []*core.Block{}
+	for _, headerpb := range headerspb {
+		if headerpb == nil {
+			continue
+		}
+		header := &core.Block{}
+		header.FromProto(&corepb.Block{Header: headerpb})
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+//getBodies returns the blocks of the blockchain with the given hashes, at most maxBodiesPerRequest of them. The blocks
+//following the first one that does not fit in a frame are left out, so that the requester asks for them again.
+func (n *Node) getBodies(hashes [][]byte) []*corepb.Block {
+	if len(hashes) > maxBodiesPerRequest {
+		hashes = hashes[:maxBodiesPerRequest]
+	}
+	blocks := []*corepb.Block{}
+	size := 0
+	for _, hash := range hashes {
+		block, err := n.bc.GetBlockByHash(hash)
+		if err != nil {
+			continue
+		}
+		blockpb := block.ToProto().(*corepb.Block)
+		size += getEncodedBlockSize(blockpb)
+		if size > n.maxFrameSize-messageOverhead {
+			break
+		}
+		blocks = append(blocks, blockpb)
+	}
+	return blocks
+}
+
+//getEncodedBlockSize returns the number of bytes blockpb adds to the encoding of a list of blocks
+func getEncodedBlockSize(blockpb *corepb.Block) int {
+	size := proto.Size(blockpb)
+	//field tag and length prefix of the embedded message
+	return 1 + proto.SizeVarint(uint64(size)) + size
+}
+
+func (n *Node) sendBodies(data []byte, pid peer.ID) {
+	blocks := &networkpb.Blocks{Blocks: n.getBodies(n.getFromProtoInventoryMsg(data).GetHashes())}
+	data, err := n.prepareData(blocks, Bodies, Unicast)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := n.unicast(data, pid); err != nil {
+		logger.Debug("Node: Unable to send to ", pid, ": ", err)
+	}
+}
+
+func (n *Node) bodiesHandler(data []byte, pid peer.ID) {
+	blockspb := &networkpb.Blocks{}
+	if err := proto.Unmarshal(data, blockspb); err != nil {
+		logger.Warn(err)
+		return
+	}
+	n.syncManager.OnBodies(pid, getBlocksFromProto(blockspb.Blocks))
+}
+
+func getBlocksFromProto(blockspb []*corepb.Block) []*core.Block {
+	blocks := []*core.Block{}
+	for _, blockpb := range blockspb {
+		if blockpb == nil || blockpb.Header == nil {
+			continue
+		}
+		block := &core.Block{}
+		block.FromProto(blockpb)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+//getBlocks returns the blocks following the first block of locator that is part of the blockchain, at most
+//MaxGetBlocksCount of them and no more than fit in a frame
+func (n *Node) getBlocks(locator [][]byte, maxCount uint32) []*corepb.Block {
+	if maxCount > MaxGetBlocksCount {
+		maxCount = MaxGetBlocksCount
+	}
+	var hashes []core.Hash
+	for _, hash := range locator {
+		hashes = append(hashes, hash)
+	}
+	blocks := []*corepb.Block{}
+	size := 0
+	for _, block := range n.bc.GetBlocksAfter(n.bc.FindCommonBlock(hashes), int(maxCount)) {
+		blockpb := block.ToProto().(*corepb.Block)
+		size += getEncodedBlockSize(blockpb)
+		if size > n.maxFrameSize-messageOverhead {
+			break
+		}
+		blocks = append(blocks, blockpb)
+	}
+	return blocks
+}
+
+func (n *Node) sendBlocks(data []byte, pid peer.ID) {
+	request := &networkpb.GetBlocksRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		logger.Warn(err)
+		return
+	}
+	blocks := &networkpb.Blocks{Blocks: n.getBlocks(request.Locator, request.MaxCount)}
+	data, err := n.prepareData(blocks, Blocks, Unicast)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := n.unicast(data, pid); err != nil {
+		logger.Debug("Node: Unable to send to ", pid, ": ", err)
+	}
+}
+
+func (n *Node) blocksHandler(data []byte, pid peer.ID) {
+	blockspb := &networkpb.Blocks{}
+	if err := proto.Unmarshal(data, blockspb); err != nil {
+		logger.Warn(err)
+		return
+	}
+	blocks := getBlocksFromProto(blockspb.Blocks)
+	if n.addBlocks(blocks, pid) {
+		//the peer may have more blocks, as responses are limited in count and size. It sends none once it has no more.
+		n.RequestBlocksUnicast(pid)
+	}
+}
+
+//addBlocks adds the blocks received in response to GetBlocks to the blockchain. The blocks must follow each other
+//from a block of the blockchain, and are only added if they extend the blockchain or make a fork higher than it. The
+//peer sending invalid blocks is penalized.
+func (n *Node) addBlocks(blocks []*core.Block, pid peer.ID) bool {
+	for len(blocks) > 0 && n.bc.IsInBlockchain(blocks[0].GetHash()) {
+		blocks = blocks[1:]
+	}
+	if len(blocks) == 0 {
+		return false
+	}
+
+	parent, err := n.bc.GetBlockByHash(blocks[0].GetPrevHash())
+	if err != nil {
+		logger.Warn("Node: Received blocks that do not follow a block of the blockchain")
+		return false
+	}
+	for _, blk := range blocks {
+		if !parent.IsParentBlock(blk) || !blk.VerifyHash() {
+			logger.Warn("Node: Received blocks that do not follow each other")
+			n.misbehave(pid, InvalidBlockPenalty, "sent blocks that do not follow each other")
+			return false
+		}
+		if n.bc.GetConsensus() != nil && !n.bc.GetConsensus().VerifyBlock(blk) {
+			logger.Warn("Node: Received a block that cannot pass signature verification")
+			n.misbehave(pid, InvalidBlockPenalty, "sent a block that cannot pass signature verification")
+			return false
+		}
+		parent = blk
+	}
+
+	extendsTail := bytes.Equal(blocks[0].GetPrevHash(), n.bc.GetTailBlockHash())
+	if !extendsTail && parent.GetHeight() <= n.bc.GetMaxHeight() {
+		return false
+	}
+	err = addBlocksToBlockchain(n.bc, blocks)
+	if err == core.ErrInvalidForkTransactions {
+		n.misbehave(pid, InvalidBlockPenalty, "sent blocks with invalid transactions")
+	}
+	return err == nil
+}
+
@@ -20,11 +20,15 @@ package network
 
 import (
 	"testing"
+	"github.com/dappley/go-dappley/core"
+	"github.com/libp2p/go-libp2p-peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/dappley/go-dappley/network/pb"
+	"github.com/dappley/go-dappley/storage"
 	"github.com/gogo/protobuf/proto"
 	"bytes"
 	"os"
+	"github.com/libp2p/go-libp2p-crypto"
 	logger "github.com/sirupsen/logrus"
 )
 
@@ -81,4 +85,81 @@ func TestNode_prepareData(t *testing.T){
 			assert.Equal(t,tt.retErr,err)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestNode_BanPeer(t *testing.T) {
+	bc := core.GenerateMockBlockchain(1)
+	peerDb := storage.NewRamStorage()
+	defer peerDb.Close()
+	n := NewNodeWithPeerDb(bc, peerDb)
+	pid, _ := peer.IDB58Decode("QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+
+	//peers are banned once their misbehaviour score reaches the threshold
+	n.misbehave(pid, InvalidBlockPenalty, "sent an invalid block")
+	assert.Len(t, n.GetBannedPeers(), 0)
+	n.misbehave(pid, InvalidBlockPenalty, "sent an invalid block")
+	assert.Contains(t, n.GetBannedPeers(), pid)
+
+	//bans are restored after a restart
+	restarted := NewNodeWithPeerDb(bc, peerDb)
+	assert.True(t, restarted.GetPeerList().IsBanned(pid))
+	assert.Nil(t, restarted.UnbanPeer(pid))
+	assert.Equal(t, ErrPeerNotBanned, restarted.UnbanPeer(pid))
+	assert.False(t, NewNodeWithPeerDb(bc, peerDb).GetPeerList().IsBanned(pid))
+	//peer data is not written to the database of the blockchain
+	_, err := bc.GetDb().Get([]byte(bannedPeersKey))
+	assert.Equal(t, storage.ErrKeyInvalid, err)
+}
+
+func TestNode_StopTwice(t *testing.T) {
+	bc := core.GenerateMockBlockchain(1)
+	n := NewNode(bc)
+	n.StartTxRelayLoop()
+	defer bc.GetTxPool().Stop()
+
+	n.Stop()
+	n.Stop()
+}
+
+func TestNode_SetSeeds(t *testing.T) {
+	n := NewNode(nil)
+	n.SetSeeds([]string{
+		"/ip4/127.0.0.1/tcp/10000/ipfs/QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ",
+		"invalid",
+		"/ip4/127.0.0.1/tcp/10001",
+		"/ip4/127.0.0.1/tcp/10002/ipfs/QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ",
+	})
+
+	//addresses without a peer ID are ignored
+	assert.Len(t, n.seeds, 2)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/10000", n.seeds[0].addr.String())
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/10002", n.seeds[1].addr.String())
+}
+
+func TestNode_SignBroadcasts(t *testing.T) {
+	privKey, pubKey, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	assert.Nil(t, err)
+	origin, _ := peer.IDFromPublicKey(pubKey)
+	n := FakeNodeWithPidAndAddr(nil, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	n.privKey = privKey
+
+	decode := func(data []byte) DapMsg {
+		dmpb := &networkpb.Dapmsg{}
+		assert.Nil(t, proto.Unmarshal(data, dmpb))
+		dm := DapMsg{}
+		dm.FromProto(dmpb)
+		return dm
+	}
+
+	//only broadcasts are signed
+	data, err := n.prepareData(&networkpb.Peerlist{}, SyncPeerList, Broadcast)
+	assert.Nil(t, err)
+	dm := decode(data)
+	assert.Nil(t, dm.VerifySignature())
+	assert.Equal(t, origin, dm.GetOrigin())
+
+	data, err = n.prepareData(&networkpb.Peerlist{}, SyncPeerList, Unicast)
+	assert.Nil(t, err)
+	dm = decode(data)
+	assert.False(t, dm.IsSigned())
+}
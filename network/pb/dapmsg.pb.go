@@ -25,6 +25,8 @@ type Dapmsg struct {
 	Key                  string   `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
 	UniOrBroadcast       int64    `protobuf:"varint,5,opt,name=uniOrBroadcast,proto3" json:"uniOrBroadcast,omitempty"`
 	Counter              uint64   `protobuf:"varint,6,opt,name=counter,proto3" json:"counter,omitempty"`
+	Origin               []byte   `protobuf:"bytes,7,opt,name=origin,proto3" json:"origin,omitempty"`
+	Signature            []byte   `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -34,7 +36,7 @@ func (m *Dapmsg) Reset()         { *m = Dapmsg{} }
 func (m *Dapmsg) String() string { return proto.CompactTextString(m) }
 func (*Dapmsg) ProtoMessage()    {}
 func (*Dapmsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapmsg_44e24eee61b0d9c0, []int{0}
+	return fileDescriptor_dapmsg_9fb791d6b6faeb06, []int{0}
 }
 func (m *Dapmsg) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Dapmsg.Unmarshal(m, b)
@@ -96,24 +98,39 @@ func (m *Dapmsg) GetCounter() uint64 {
 	return 0
 }
 
+func (m *Dapmsg) GetOrigin() []byte {
+	if m != nil {
+		return m.Origin
+	}
+	return nil
+}
+
+func (m *Dapmsg) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Dapmsg)(nil), "networkpb.Dapmsg")
 }
 
-func init() { proto.RegisterFile("dapmsg.proto", fileDescriptor_dapmsg_44e24eee61b0d9c0) }
-
-var fileDescriptor_dapmsg_44e24eee61b0d9c0 = []byte{
-	// 177 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0x49, 0x2c, 0xc8,
-	0x2d, 0x4e, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0xcc, 0x4b, 0x2d, 0x29, 0xcf, 0x2f,
-	0xca, 0x2e, 0x48, 0x52, 0x5a, 0xc1, 0xc8, 0xc5, 0xe6, 0x02, 0x96, 0x13, 0x12, 0xe0, 0x62, 0x4e,
-	0xce, 0x4d, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x02, 0x31, 0x85, 0x84, 0xb8, 0x58, 0x52,
-	0x12, 0x4b, 0x12, 0x25, 0x98, 0x14, 0x18, 0x35, 0x78, 0x82, 0xc0, 0x6c, 0x21, 0x15, 0x2e, 0xde,
-	0xd2, 0xbc, 0xcc, 0x8a, 0x90, 0xcc, 0xdc, 0xd4, 0xa0, 0xd4, 0xe4, 0xb2, 0x14, 0x09, 0x66, 0x05,
-	0x46, 0x0d, 0xe6, 0x20, 0x54, 0x41, 0x90, 0x59, 0xd9, 0xa9, 0x95, 0x12, 0x2c, 0x10, 0xb3, 0xb2,
-	0x53, 0x2b, 0x85, 0xd4, 0xb8, 0xf8, 0x4a, 0xf3, 0x32, 0xfd, 0x8b, 0x9c, 0x8a, 0xf2, 0x13, 0x53,
-	0x92, 0x13, 0x8b, 0x4b, 0x24, 0x58, 0xc1, 0x1a, 0xd1, 0x44, 0x85, 0x24, 0xb8, 0xd8, 0x93, 0xf3,
-	0x4b, 0xf3, 0x4a, 0x52, 0x8b, 0x24, 0xd8, 0x14, 0x18, 0x35, 0x58, 0x82, 0x60, 0xdc, 0x24, 0x36,
-	0xb0, 0xe3, 0x8d, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa2, 0x7e, 0x8f, 0x9e, 0xcc, 0x00, 0x00,
-	0x00,
+func init() { proto.RegisterFile("dapmsg.proto", fileDescriptor_dapmsg_9fb791d6b6faeb06) }
+
+var fileDescriptor_dapmsg_9fb791d6b6faeb06 = []byte{
+	// 201 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x8f, 0xc1, 0x4a, 0xc0, 0x30,
+	0x10, 0x44, 0x89, 0xad, 0xa9, 0x5d, 0xaa, 0xc8, 0x1e, 0x64, 0x0f, 0x1e, 0x82, 0x88, 0xe4, 0xe4,
+	0xc5, 0x3f, 0x10, 0xef, 0x42, 0xf0, 0x07, 0xd2, 0x24, 0x94, 0x50, 0x9a, 0x94, 0x34, 0x51, 0xfb,
+	0xb3, 0x7e, 0x8b, 0x34, 0x2a, 0xa2, 0xb7, 0x99, 0x37, 0xec, 0xb0, 0x03, 0x83, 0xd5, 0xeb, 0xb2,
+	0x4d, 0xf7, 0x6b, 0x8a, 0x39, 0x62, 0x1f, 0x5c, 0x7e, 0x8b, 0x69, 0x5e, 0xc7, 0x9b, 0x0f, 0x06,
+	0xfc, 0xa9, 0x66, 0x78, 0x09, 0x8d, 0x59, 0x2c, 0x31, 0xc1, 0x64, 0xaf, 0x0e, 0x89, 0x08, 0xad,
+	0xd5, 0x59, 0xd3, 0x89, 0x60, 0x72, 0x50, 0x55, 0xe3, 0x2d, 0x9c, 0x97, 0xe0, 0xdf, 0x5f, 0xfc,
+	0xe2, 0x94, 0x33, 0xaf, 0x96, 0x1a, 0xc1, 0x64, 0xa3, 0xfe, 0xc2, 0xa3, 0x6b, 0x76, 0x3b, 0xb5,
+	0x5f, 0x5d, 0xb3, 0xdb, 0xf1, 0x0e, 0x2e, 0x4a, 0xf0, 0xcf, 0xe9, 0x31, 0x45, 0x6d, 0x8d, 0xde,
+	0x32, 0x9d, 0xd6, 0xc3, 0x7f, 0x14, 0x09, 0x3a, 0x13, 0x4b, 0xc8, 0x2e, 0x11, 0x17, 0x4c, 0xb6,
+	0xea, 0xc7, 0xe2, 0x15, 0xf0, 0x98, 0xfc, 0xe4, 0x03, 0x75, 0xf5, 0x9f, 0x6f, 0x87, 0xd7, 0xd0,
+	0x6f, 0x7e, 0x0a, 0x3a, 0x97, 0xe4, 0xe8, 0xac, 0x46, 0xbf, 0x60, 0xe4, 0x75, 0xf2, 0xc3, 0xe7,
+	0x00, 0x3f, 0x16, 0xd0, 0xd8, 0x02, 0x01, 0x00, 0x00,
 }
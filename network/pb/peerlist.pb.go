@@ -29,7 +29,7 @@ func (m *Peerlist) Reset()         { *m = Peerlist{} }
 func (m *Peerlist) String() string { return proto.CompactTextString(m) }
 func (*Peerlist) ProtoMessage()    {}
 func (*Peerlist) Descriptor() ([]byte, []int) {
-	return fileDescriptor_peerlist_f54f6cedf2875c20, []int{0}
+	return fileDescriptor_peerlist_b016a30bb1e4305f, []int{0}
 }
 func (m *Peerlist) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Peerlist.Unmarshal(m, b)
@@ -68,7 +68,7 @@ func (m *Peer) Reset()         { *m = Peer{} }
 func (m *Peer) String() string { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()    {}
 func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_peerlist_f54f6cedf2875c20, []int{1}
+	return fileDescriptor_peerlist_b016a30bb1e4305f, []int{1}
 }
 func (m *Peer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Peer.Unmarshal(m, b)
@@ -102,21 +102,189 @@ func (m *Peer) GetAddr() string {
 	return ""
 }
 
+type BannedPeer struct {
+	Peerid               string   `protobuf:"bytes,1,opt,name=peerid,proto3" json:"peerid,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BannedPeer) Reset()         { *m = BannedPeer{} }
+func (m *BannedPeer) String() string { return proto.CompactTextString(m) }
+func (*BannedPeer) ProtoMessage()    {}
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_peerlist_b016a30bb1e4305f, []int{2}
+}
+func (m *BannedPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BannedPeer.Unmarshal(m, b)
+}
+func (m *BannedPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BannedPeer.Marshal(b, m, deterministic)
+}
+func (dst *BannedPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BannedPeer.Merge(dst, src)
+}
+func (m *BannedPeer) XXX_Size() int {
+	return xxx_messageInfo_BannedPeer.Size(m)
+}
+func (m *BannedPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BannedPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BannedPeer proto.InternalMessageInfo
+
+func (m *BannedPeer) GetPeerid() string {
+	if m != nil {
+		return m.Peerid
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type BannedPeers struct {
+	BannedPeers          []*BannedPeer `protobuf:"bytes,1,rep,name=bannedPeers,proto3" json:"bannedPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BannedPeers) Reset()         { *m = BannedPeers{} }
+func (m *BannedPeers) String() string { return proto.CompactTextString(m) }
+func (*BannedPeers) ProtoMessage()    {}
+func (*BannedPeers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_peerlist_b016a30bb1e4305f, []int{3}
+}
+func (m *BannedPeers) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BannedPeers.Unmarshal(m, b)
+}
+func (m *BannedPeers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BannedPeers.Marshal(b, m, deterministic)
+}
+func (dst *BannedPeers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BannedPeers.Merge(dst, src)
+}
+func (m *BannedPeers) XXX_Size() int {
+	return xxx_messageInfo_BannedPeers.Size(m)
+}
+func (m *BannedPeers) XXX_DiscardUnknown() {
+	xxx_messageInfo_BannedPeers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BannedPeers proto.InternalMessageInfo
+
+func (m *BannedPeers) GetBannedPeers() []*BannedPeer {
+	if m != nil {
+		return m.BannedPeers
+	}
+	return nil
+}
+
+type KnownPeer struct {
+	Peer                 *Peer    `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	LastSeen             int64    `protobuf:"varint,2,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	LastAttempt          int64    `protobuf:"varint,3,opt,name=lastAttempt,proto3" json:"lastAttempt,omitempty"`
+	Successes            uint32   `protobuf:"varint,4,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures             uint32   `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KnownPeer) Reset()         { *m = KnownPeer{} }
+func (m *KnownPeer) String() string { return proto.CompactTextString(m) }
+func (*KnownPeer) ProtoMessage()    {}
+func (*KnownPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_peerlist_b016a30bb1e4305f, []int{4}
+}
+func (m *KnownPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnownPeer.Unmarshal(m, b)
+}
+func (m *KnownPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnownPeer.Marshal(b, m, deterministic)
+}
+func (dst *KnownPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnownPeer.Merge(dst, src)
+}
+func (m *KnownPeer) XXX_Size() int {
+	return xxx_messageInfo_KnownPeer.Size(m)
+}
+func (m *KnownPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnownPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnownPeer proto.InternalMessageInfo
+
+func (m *KnownPeer) GetPeer() *Peer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *KnownPeer) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *KnownPeer) GetLastAttempt() int64 {
+	if m != nil {
+		return m.LastAttempt
+	}
+	return 0
+}
+
+func (m *KnownPeer) GetSuccesses() uint32 {
+	if m != nil {
+		return m.Successes
+	}
+	return 0
+}
+
+func (m *KnownPeer) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Peerlist)(nil), "networkpb.Peerlist")
 	proto.RegisterType((*Peer)(nil), "networkpb.Peer")
+	proto.RegisterType((*BannedPeer)(nil), "networkpb.BannedPeer")
+	proto.RegisterType((*BannedPeers)(nil), "networkpb.BannedPeers")
+	proto.RegisterType((*KnownPeer)(nil), "networkpb.KnownPeer")
 }
 
-func init() { proto.RegisterFile("network/pb/peerlist.proto", fileDescriptor_peerlist_f54f6cedf2875c20) }
+func init() {
+	proto.RegisterFile("network/pb/peerlist.proto", fileDescriptor_peerlist_b016a30bb1e4305f)
+}
 
-var fileDescriptor_peerlist_f54f6cedf2875c20 = []byte{
-	// 128 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcc, 0x4b, 0x2d, 0x29,
-	0xcf, 0x2f, 0xca, 0xd6, 0x2f, 0x48, 0xd2, 0x2f, 0x48, 0x4d, 0x2d, 0xca, 0xc9, 0x2c, 0x2e, 0xd1,
-	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x4a, 0x15, 0x24, 0x29, 0x99, 0x73, 0x71, 0x04,
-	0x40, 0x25, 0x85, 0xb4, 0xb9, 0x38, 0x60, 0x0a, 0x25, 0x18, 0x15, 0x98, 0x35, 0xb8, 0x8d, 0xf8,
-	0xf5, 0xe0, 0x2a, 0xf5, 0x40, 0xca, 0x82, 0xe0, 0x0a, 0x94, 0x8c, 0xb8, 0x58, 0x40, 0x22, 0x42,
-	0x62, 0x5c, 0x6c, 0x20, 0xb1, 0xcc, 0x14, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x28, 0x4f,
-	0x48, 0x88, 0x8b, 0x25, 0x31, 0x25, 0xa5, 0x48, 0x82, 0x09, 0x2c, 0x0a, 0x66, 0x27, 0xb1, 0x81,
-	0xad, 0x37, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0xab, 0xf1, 0x14, 0x84, 0x9b, 0x00, 0x00, 0x00,
+var fileDescriptor_peerlist_b016a30bb1e4305f = []byte{
+	// 262 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x41, 0x4b, 0xc4, 0x30,
+	0x10, 0x85, 0x89, 0xdb, 0x5d, 0xda, 0x29, 0x22, 0x04, 0x95, 0x28, 0x1e, 0x4a, 0xbc, 0x14, 0x84,
+	0x2e, 0xac, 0x87, 0x05, 0x6f, 0x7a, 0xf0, 0xe2, 0x45, 0xe2, 0x2f, 0x68, 0xb7, 0x23, 0x14, 0x6b,
+	0x1a, 0x92, 0x94, 0xfd, 0x4b, 0xfe, 0x4c, 0xc9, 0x18, 0xdb, 0x1e, 0xc4, 0xdb, 0xbc, 0xf7, 0xbe,
+	0x49, 0x5e, 0x08, 0x5c, 0x69, 0xf4, 0xc7, 0xc1, 0x7e, 0x6c, 0x4d, 0xb3, 0x35, 0x88, 0xb6, 0xef,
+	0x9c, 0xaf, 0x8c, 0x1d, 0xfc, 0xc0, 0xb3, 0x18, 0x99, 0x46, 0xee, 0x21, 0x7d, 0x8d, 0x21, 0xbf,
+	0x83, 0xf4, 0x17, 0x14, 0xac, 0x58, 0x95, 0xf9, 0xee, 0xac, 0x9a, 0xc8, 0x2a, 0x60, 0x6a, 0x02,
+	0xe4, 0x0e, 0x92, 0xe0, 0xf0, 0x4b, 0xd8, 0x04, 0xaf, 0x6b, 0x05, 0x2b, 0x58, 0x99, 0xa9, 0xa8,
+	0x38, 0x87, 0xa4, 0x6e, 0x5b, 0x2b, 0x4e, 0xc8, 0xa5, 0x59, 0x3e, 0x00, 0x3c, 0xd5, 0x5a, 0x63,
+	0xfb, 0xef, 0xe6, 0x39, 0xac, 0x47, 0xed, 0xbb, 0x9e, 0x56, 0x57, 0xea, 0x47, 0xc8, 0x67, 0xc8,
+	0xe7, 0x5d, 0xc7, 0xf7, 0x90, 0x37, 0xb3, 0x8c, 0x75, 0x2f, 0x16, 0x75, 0x67, 0x58, 0x2d, 0x49,
+	0xf9, 0xc5, 0x20, 0x7b, 0xd1, 0xc3, 0x51, 0x53, 0x87, 0x5b, 0x48, 0xc2, 0xad, 0xd4, 0xe0, 0x8f,
+	0xe7, 0x52, 0xc8, 0xaf, 0x21, 0xed, 0x6b, 0xe7, 0xdf, 0x10, 0x75, 0xec, 0x34, 0x69, 0x5e, 0x40,
+	0x1e, 0xe6, 0x47, 0xef, 0xf1, 0xd3, 0x78, 0xb1, 0xa2, 0x78, 0x69, 0xf1, 0x1b, 0xc8, 0xdc, 0x78,
+	0x38, 0xa0, 0x73, 0xe8, 0x44, 0x52, 0xb0, 0xf2, 0x54, 0xcd, 0x46, 0x38, 0xfb, 0xbd, 0xee, 0xfa,
+	0xd1, 0xa2, 0x13, 0x6b, 0x0a, 0x27, 0xdd, 0x6c, 0xe8, 0xb7, 0xee, 0xbf, 0x07, 0x00, 0x5d, 0xab,
+	0xf2, 0x10, 0xca, 0x01, 0x00, 0x00,
 }
@@ -20,6 +20,8 @@ package network
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/dappley/go-dappley/network/pb"
 	"github.com/gogo/protobuf/proto"
@@ -30,8 +32,36 @@ import (
 
 var PEERLISTMAXSIZE = 20
 
+const (
+	//BanThreshold is the misbehaviour score at which a peer is banned
+	BanThreshold = 100
+	//DefaultBanDuration is how long a peer reaching BanThreshold is banned
+	DefaultBanDuration = 24 * time.Hour
+	//scoreDecayInterval is the time it takes for the misbehaviour score of a peer to decrease by one
+	scoreDecayInterval = time.Minute
+)
+
+//penalties added to the misbehaviour score of a peer
+const (
+	InvalidBlockPenalty     = 50
+	InvalidTxPenalty        = 10
+	MalformedMessagePenalty = 25
+	UnsolicitedDataPenalty  = 5
+	InvalidSignaturePenalty = 50
+)
+
+//PeerList holds the connected peers and the misbehaviour scores and bans of peers. It can be used from several
+//goroutines.
 type PeerList struct {
-	peers []*Peer
+	peers         []*Peer
+	misbehaviours map[peer.ID]*misbehaviour
+	bans          map[peer.ID]time.Time
+	mutex         sync.Mutex
+}
+
+type misbehaviour struct {
+	score   int
+	updated time.Time
 }
 
 type Peer struct {
@@ -98,23 +128,26 @@ func NewPeerListStr(strs []string) *PeerList {
 }
 
 func (pl *PeerList) ListIsFull() bool {
-	if len(pl.peers) < PEERLISTMAXSIZE {
-		return false
-	}
-	return true
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	return len(pl.peers) >= PEERLISTMAXSIZE
 }
 
 //remove old ip give space for new ip
 func (pl *PeerList) RemoveOneIP(p *Peer) {
-	if !pl.IsInPeerlist(p) {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	if !pl.isInPeerlist(p) && len(pl.peers) > 0 {
 		pl.peers = append(pl.peers[:0], pl.peers[1:]...)
 	}
 }
 
-func (pl *PeerList) DeletePeer(p *Peer){
-	for i, peer := range pl.GetPeerlist(){
+func (pl *PeerList) DeletePeer(p *Peer) {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	for i, peer := range pl.peers {
 		if peer.peerid.String() == p.peerid.String() || peer.addr.String() == p.addr.String() {
-			pl.peers = append(pl.peers[:i],pl.peers[i+1:]...)
+			pl.peers = append(pl.peers[:i], pl.peers[i+1:]...)
 			return
 		}
 	}
@@ -122,8 +155,10 @@ func (pl *PeerList) DeletePeer(p *Peer){
 
 //Add a multiadress.
 func (pl *PeerList) Add(p *Peer) {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
 	//add only if it is not already existed in the list
-	if !pl.IsInPeerlist(p) && (len(pl.peers) < PEERLISTMAXSIZE) {
+	if !pl.isInPeerlist(p) && (len(pl.peers) < PEERLISTMAXSIZE) && !pl.isBanned(p.peerid) {
 		pl.peers = append(pl.peers, p)
 	}
 }
@@ -151,13 +186,23 @@ func (pl *PeerList) FindNewPeers(newpl *PeerList) *PeerList {
 	return retpl
 }
 
-//Get peerList
+//GetPeerlist returns a copy of the peers in the list
 func (pl *PeerList) GetPeerlist() []*Peer {
-	return pl.peers
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	peers := make([]*Peer, len(pl.peers))
+	copy(peers, pl.peers)
+	return peers
 }
 
 //Check if a multiaddress is already existed in the list
 func (pl *PeerList) IsInPeerlist(p *Peer) bool {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	return pl.isInPeerlist(p)
+}
+
+func (pl *PeerList) isInPeerlist(p *Peer) bool {
 
 	if p == nil {
 		return false
@@ -175,6 +220,115 @@ func (pl *PeerList) IsInPeerlist(p *Peer) bool {
 	return false
 }
 
+//AddMisbehaviour raises the misbehaviour score of a peer by penalty and returns the new score
+func (pl *PeerList) AddMisbehaviour(pid peer.ID, penalty int) int {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+
+	if pl.misbehaviours == nil {
+		pl.misbehaviours = make(map[peer.ID]*misbehaviour)
+	}
+	now := time.Now()
+	m, ok := pl.misbehaviours[pid]
+	if !ok {
+		m = &misbehaviour{0, now}
+		pl.misbehaviours[pid] = m
+	}
+	m.decay(now)
+	m.score += penalty
+	return m.score
+}
+
+//GetScore returns the misbehaviour score of a peer
+func (pl *PeerList) GetScore(pid peer.ID) int {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+
+	m, ok := pl.misbehaviours[pid]
+	if !ok {
+		return 0
+	}
+	m.decay(time.Now())
+	return m.score
+}
+
+//decay decreases the score by one for every scoreDecayInterval elapsed since the last update
+func (m *misbehaviour) decay(now time.Time) {
+	intervals := now.Sub(m.updated) / scoreDecayInterval
+	m.score -= int(intervals)
+	m.updated = m.updated.Add(intervals * scoreDecayInterval)
+	if m.score <= 0 {
+		m.score = 0
+		m.updated = now
+	}
+}
+
+//Ban removes a peer from the list and refuses to add it again until the given time. Its misbehaviour score is reset.
+func (pl *PeerList) Ban(pid peer.ID, until time.Time) {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+
+	if pl.bans == nil {
+		pl.bans = make(map[peer.ID]time.Time)
+	}
+	pl.bans[pid] = until
+	delete(pl.misbehaviours, pid)
+
+	for i, p := range pl.peers {
+		if p.peerid == pid {
+			pl.peers = append(pl.peers[:i], pl.peers[i+1:]...)
+			return
+		}
+	}
+}
+
+//Unban lifts the ban of a peer. It returns false if the peer is not banned.
+func (pl *PeerList) Unban(pid peer.ID) bool {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+
+	if _, ok := pl.bans[pid]; !ok {
+		return false
+	}
+	delete(pl.bans, pid)
+	return true
+}
+
+//IsBanned returns true if a peer is banned
+func (pl *PeerList) IsBanned(pid peer.ID) bool {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	return pl.isBanned(pid)
+}
+
+func (pl *PeerList) isBanned(pid peer.ID) bool {
+	pl.removeExpiredBans()
+	_, ok := pl.bans[pid]
+	return ok
+}
+
+//GetBannedPeers returns the banned peers and the time their bans end
+func (pl *PeerList) GetBannedPeers() map[peer.ID]time.Time {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+
+	pl.removeExpiredBans()
+	bans := make(map[peer.ID]time.Time, len(pl.bans))
+	for pid, until := range pl.bans {
+		bans[pid] = until
+	}
+	return bans
+}
+
+func (pl *PeerList) removeExpiredBans() {
+	now := time.Now()
+	for pid, until := range pl.bans {
+		if !until.After(now) {
+			delete(pl.bans, pid)
+		}
+	}
+}
+
 //convert to protobuf
 func (p *Peer) ToProto() proto.Message {
 	return &networkpb.Peer{
@@ -199,6 +353,8 @@ func (p *Peer) FromProto(pb proto.Message) error {
 
 //convert to protobuf
 func (pl *PeerList) ToProto() proto.Message {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
 
 	var peerlist []*networkpb.Peer
 	for i := range pl.peers {
@@ -213,6 +369,8 @@ func (pl *PeerList) ToProto() proto.Message {
 //convert from protobuf
 func (pl *PeerList) FromProto(pb proto.Message) {
 	peerlist := pb.(*networkpb.Peerlist).Peerlist
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
 	pl.peers = nil
 	for _, peer := range peerlist {
 		p := &Peer{}
@@ -220,3 +378,27 @@ func (pl *PeerList) FromProto(pb proto.Message) {
 		pl.peers = append(pl.peers, p)
 	}
 }
+
+//BansToProto converts the bans of the list to protobuf
+func (pl *PeerList) BansToProto() proto.Message {
+	bannedPeers := []*networkpb.BannedPeer{}
+	for pid, until := range pl.GetBannedPeers() {
+		bannedPeers = append(bannedPeers, &networkpb.BannedPeer{
+			Peerid: peer.IDB58Encode(pid),
+			Until:  until.Unix(),
+		})
+	}
+	return &networkpb.BannedPeers{BannedPeers: bannedPeers}
+}
+
+//BansFromProto bans the peers in a protobuf converted by BansToProto
+func (pl *PeerList) BansFromProto(pb proto.Message) {
+	for _, bannedPeer := range pb.(*networkpb.BannedPeers).BannedPeers {
+		pid, err := peer.IDB58Decode(bannedPeer.Peerid)
+		if err != nil {
+			logger.Warn("PeerList: Banned peer ", bannedPeer.Peerid, " cannot be decoded")
+			continue
+		}
+		pl.Ban(pid, time.Unix(bannedPeer.Until, 0))
+	}
+}
@@ -19,7 +19,10 @@
 package network
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/dappley/go-dappley/network/pb"
@@ -607,3 +610,88 @@ func TestPeerList_RemoveOneIP(t *testing.T) {
 	assert.Equal(t, 20, len(pl1.peers))
 	assert.True(t, pl1.IsInPeerlist(p2))
 }
+
+func TestPeerList_AddMisbehaviour(t *testing.T) {
+	pl := NewPeerList(nil)
+	pid := peer.ID("peer1")
+
+	assert.Equal(t, 0, pl.GetScore(pid))
+	assert.Equal(t, InvalidTxPenalty, pl.AddMisbehaviour(pid, InvalidTxPenalty))
+	assert.Equal(t, InvalidTxPenalty+InvalidBlockPenalty, pl.AddMisbehaviour(pid, InvalidBlockPenalty))
+	assert.Equal(t, 0, pl.GetScore(peer.ID("peer2")))
+
+	//scores decrease by one every scoreDecayInterval
+	pl.misbehaviours[pid].updated = time.Now().Add(-5*scoreDecayInterval - scoreDecayInterval/2)
+	assert.Equal(t, InvalidTxPenalty+InvalidBlockPenalty-5, pl.GetScore(pid))
+	pl.misbehaviours[pid].updated = time.Now().Add(-time.Hour * 24)
+	assert.Equal(t, 0, pl.GetScore(pid))
+}
+
+func TestPeerList_Ban(t *testing.T) {
+	p1, _ := CreatePeerFromString("/ip4/127.0.0.1/tcp/10000/ipfs/QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	p2, _ := CreatePeerFromString("/ip4/127.0.0.1/tcp/10001/ipfs/QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	pl := NewPeerList([]*Peer{p1, p2})
+	pl.AddMisbehaviour(p1.peerid, InvalidBlockPenalty)
+
+	//banned peers are removed and can't be added again
+	pl.Ban(p1.peerid, time.Now().Add(time.Hour))
+	assert.True(t, pl.IsBanned(p1.peerid))
+	assert.False(t, pl.IsBanned(p2.peerid))
+	assert.Equal(t, []*Peer{p2}, pl.GetPeerlist())
+	pl.Add(p1)
+	assert.Equal(t, []*Peer{p2}, pl.GetPeerlist())
+	assert.Equal(t, 0, pl.GetScore(p1.peerid))
+
+	assert.True(t, pl.Unban(p1.peerid))
+	assert.False(t, pl.Unban(p1.peerid))
+	pl.Add(p1)
+	assert.Equal(t, []*Peer{p2, p1}, pl.GetPeerlist())
+
+	//bans end after their duration
+	pl.Ban(p2.peerid, time.Now().Add(-time.Second))
+	assert.False(t, pl.IsBanned(p2.peerid))
+	assert.Len(t, pl.GetBannedPeers(), 0)
+}
+
+func TestPeerList_BansProto(t *testing.T) {
+	pid1, _ := peer.IDB58Decode("QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	pid2, _ := peer.IDB58Decode("QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	until := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	pl := NewPeerList(nil)
+	pl.Ban(pid1, until)
+	pl.Ban(pid2, until.Add(time.Hour))
+
+	decoded := NewPeerList(nil)
+	decoded.BansFromProto(pl.BansToProto())
+	assert.Equal(t, map[peer.ID]time.Time{pid1: until, pid2: until.Add(time.Hour)}, decoded.GetBannedPeers())
+}
+
+func TestPeerList_Concurrency(t *testing.T) {
+	pl := NewPeerList(nil)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < PEERLISTMAXSIZE; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p, err := CreatePeerFromString(fmt.Sprintf("/ip4/192.168.10.%d/tcp/10000/ipfs/QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", i))
+			assert.Nil(t, err)
+			p.peerid = peer.ID([]byte{byte(i)})
+			pl.Add(p)
+			pl.IsInPeerlist(p)
+			pl.ToProto()
+			pl.ListIsFull()
+			pl.Ban(p.peerid, time.Now().Add(time.Hour))
+			pl.DeletePeer(p)
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, pl.GetPeerlist(), 0)
+}
+
+func TestPeerList_GetPeerlistReturnsCopy(t *testing.T) {
+	p1, _ := CreatePeerFromString("/ip4/192.168.10.110/tcp/10000/ipfs/QmWyMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+	pl := NewPeerList([]*Peer{p1})
+	peers := pl.GetPeerlist()
+	peers[0] = nil
+	assert.Equal(t, []*Peer{p1}, pl.GetPeerlist())
+}
@@ -20,9 +20,12 @@ package network
 
 import (
 	"bufio"
+	"encoding/binary"
 	"errors"
-	"reflect"
+	"hash/crc32"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/dappley/go-dappley/network/pb"
 	"github.com/gogo/protobuf/proto"
@@ -37,17 +40,33 @@ const (
 	SyncPeerList = "SyncPeerList"
 	RequestBlock = "requestBlock"
 	BroadcastTx  = "BroadcastTx"
+	TxInv        = "TxInv"
+	GetTx        = "GetTx"
+	GetTip       = "GetTip"
+	Tip          = "Tip"
+	GetHeaders   = "GetHeaders"
+	Headers      = "Headers"
+	GetBodies    = "GetBodies"
+	Bodies       = "Bodies"
+	GetBlocks    = "GetBlocks"
+	Blocks       = "Blocks"
+	Hello        = "Hello"
+	HelloAck     = "HelloAck"
 	Unicast      = 0
 	Broadcast    = 1
 )
 
-var (
-	ErrInvalidMessageFormat = errors.New("Message format is invalid")
-)
+//DefaultMaxFrameSize is the default maximum size of the payload of a frame
+const DefaultMaxFrameSize = 4 * 1024 * 1024
+
+//checksumLength is the length of the CRC-32 checksum following the payload of a frame
+const checksumLength = 4
 
 var (
-	startBytes = []byte{0x7E, 0x7E}
-	endBytes   = []byte{0x7F, 0x7F, 0}
+	ErrInvalidMessageFormat = errors.New("Message format is invalid")
+	ErrFrameTooLarge        = errors.New("ERROR: Frame is larger than the maximum frame size")
+	ErrInvalidChecksum      = errors.New("ERROR: Frame checksum does not match its payload")
+	ErrStreamStopped        = errors.New("ERROR: Stream is stopped")
 )
 
 type Stream struct {
@@ -56,8 +75,11 @@ type Stream struct {
 	remoteAddr multiaddr.Multiaddr
 	stream     net.Stream
 	dataCh     chan []byte
-	quitRdCh   chan bool
-	quitWrCh   chan bool
+	quitCh     chan bool
+	stopOnce   *sync.Once
+	outbound   bool
+	hello      *HelloMsg
+	handshaked chan bool //closed once the handshake is completed
 }
 
 func NewStream(s net.Stream, node *Node) *Stream {
@@ -66,8 +88,11 @@ func NewStream(s net.Stream, node *Node) *Stream {
 		s.Conn().RemoteMultiaddr(),
 		s,
 		make(chan []byte, 5), //TODO: Redefine the size of the channel
-		make(chan bool, 1),   //two channels to stop
-		make(chan bool, 1),
+		make(chan bool),      //closed to stop both loops
+		&sync.Once{},
+		false,
+		nil,
+		make(chan bool),
 	}
 }
 
@@ -76,133 +101,202 @@ func (s *Stream) Start() {
 	s.startLoop(rw)
 }
 
+//StopStream closes the stream and removes the peer from the node. It can be called several times and from several
+//goroutines.
 func (s *Stream) StopStream() {
-	logger.Debug("Stream Terminated! Peer Addr:", s.remoteAddr)
-	s.quitRdCh <- true
-	s.quitWrCh <- true
-	s.stream.Close()
-	delete(s.node.streams, s.peerID)
-	s.node.peerList.DeletePeer(&Peer{s.peerID, s.remoteAddr})
+	s.stopOnce.Do(func() {
+		logger.Debug("Stream Terminated! Peer Addr:", s.remoteAddr)
+		close(s.quitCh)
+		s.stream.Close()
+		s.node.onStreamStopped(s)
+	})
 }
 
-func (s *Stream) Send(data []byte) {
-	s.dataCh <- data
+//isStopped returns true if the stream is stopped or stopping
+func (s *Stream) isStopped() bool {
+	select {
+	case <-s.quitCh:
+		return true
+	default:
+		return false
+	}
 }
 
-func (s *Stream) startLoop(rw *bufio.ReadWriter) {
-	go s.readLoop(rw)
-	go s.writeLoop(rw)
+//Send queues data to be written to the stream. It returns ErrFrameTooLarge if data is larger than the maximum frame
+//size, and ErrStreamStopped if the stream is stopped.
+func (s *Stream) Send(data []byte) error {
+	if len(data) > s.node.maxFrameSize {
+		return ErrFrameTooLarge
+	}
+	select {
+	case <-s.quitCh:
+		return ErrStreamStopped
+	default:
+	}
+	select {
+	case s.dataCh <- data:
+		return nil
+	case <-s.quitCh:
+		return ErrStreamStopped
+	}
 }
 
-func readMsg(rw *bufio.ReadWriter) ([]byte, error) {
-	var bytes []byte
-	for {
-		b, err := rw.ReadByte()
+//isHandshaked returns true once the handshake with the peer is completed. Only then can messages other than the
+//handshake be sent to the peer.
+func (s *Stream) isHandshaked() bool {
+	select {
+	case <-s.handshaked:
+		return true
+	default:
+		return false
+	}
+}
 
-		if err != nil {
-			return bytes, err
-		}
-		bytes = append(bytes, b)
-		if containEndingBytes(bytes) {
-			return bytes, nil
-		}
+//waitForHandshake disconnects the peer if the handshake is not completed within timeout
+func (s *Stream) waitForHandshake(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-s.handshaked:
+	case <-s.quitCh:
+	case <-timer.C:
+		s.disconnect(ErrHandshakeTimeout)
 	}
 }
 
-func (s *Stream) read(rw *bufio.ReadWriter) {
-	//read stream with delimiter
-	bytes, err := readMsg(rw)
+func (s *Stream) startLoop(rw *bufio.ReadWriter) {
+	go s.readLoop(rw)
+	go s.writeLoop(rw)
+}
 
+//readFrame reads a frame made of the varint encoded length of the payload, the payload and the CRC-32 checksum of the
+//payload, and returns the payload
+func readFrame(r *bufio.Reader, maxFrameSize int) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
 	if err != nil {
-		logger.Warn(err)
+		return nil, err
+	}
+	if length == 0 {
+		return nil, ErrInvalidMessageFormat
+	}
+	if length > uint64(maxFrameSize) {
+		return nil, ErrFrameTooLarge
 	}
 
-	//TODO: How to verify the integrity of the received message
-	if len(bytes) > 1 {
-		s.parseData(bytes)
-	} else {
-		logger.Debug("Read less than 1 byte. Stop Reading...")
-		//stop the stream
-		s.StopStream()
+	frame := make([]byte, length+checksumLength)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
 	}
+	data := frame[:length]
+	if binary.BigEndian.Uint32(frame[length:]) != crc32.ChecksumIEEE(data) {
+		return nil, ErrInvalidChecksum
+	}
+	return data, nil
+}
 
+//encodeFrame returns the frame carrying data
+func encodeFrame(data []byte) []byte {
+	frame := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data)+checksumLength)
+	frame = frame[:binary.PutUvarint(frame, uint64(len(data)))]
+	frame = append(frame, data...)
+	checksum := make([]byte, checksumLength)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(data))
+	return append(frame, checksum...)
 }
 
 func (s *Stream) readLoop(rw *bufio.ReadWriter) {
 	for {
 		select {
-		case <-s.quitRdCh:
+		case <-s.quitCh:
 			logger.Debug("Stream ReadLoop Terminated!")
 			return
 		default:
-			s.read(rw)
+			data, err := readFrame(rw.Reader, s.node.maxFrameSize)
+			if err != nil {
+				s.disconnect(err)
+				if err == ErrFrameTooLarge || err == ErrInvalidChecksum || err == ErrInvalidMessageFormat {
+					s.node.misbehave(s.peerID, MalformedMessagePenalty, "sent a malformed frame")
+				}
+				return
+			}
+			s.parseData(data)
 		}
 	}
 }
 
-func encodeMessage(data []byte) []byte {
-	ret := append(startBytes, data...)
-	ret = append(ret, endBytes...)
-	return ret
-}
-
-func decodeMessage(data []byte) ([]byte, error) {
-	if !(containStartingBytes(data) && containEndingBytes(data)) {
-		return nil, ErrInvalidMessageFormat
-	}
-	return data[2 : len(data)-3], nil
-}
-
-func containStartingBytes(data []byte) bool {
-	if len(data) < len(startBytes) {
-		return false
+//disconnect stops the stream after a read or write error or a protocol violation, unless the stream is already stopping
+func (s *Stream) disconnect(err error) {
+	if s.isStopped() {
+		logger.Debug("Stream: Already stopped. Peer Addr:", s.remoteAddr)
+		return
 	}
-	return reflect.DeepEqual(data[0:len(startBytes)], startBytes)
-}
-
-func containEndingBytes(data []byte) bool {
-	if len(data) < len(endBytes) {
-		return false
+	if err == io.EOF {
+		logger.Debug("Stream: Peer closed the stream. Peer Addr:", s.remoteAddr)
+	} else {
+		logger.Warn("Stream: Disconnecting peer ", s.remoteAddr, ": ", err)
 	}
-	return reflect.DeepEqual(data[(len(data)-len(endBytes)):], endBytes)
+	s.StopStream()
 }
 
-func (s *Stream) writeLoop(rw *bufio.ReadWriter) error {
-	var mutex = &sync.Mutex{}
+//writeLoop writes the queued messages to the stream. The stream is stopped if a write fails, so that the peer is
+//removed.
+func (s *Stream) writeLoop(rw *bufio.ReadWriter) {
 	for {
 		select {
 		case data := <-s.dataCh:
-			mutex.Lock()
-			//attach a delimiter byte of 0x00 to the end of the message
-			rw.WriteString(string(encodeMessage(data)))
-			rw.Flush()
-			mutex.Unlock()
-		case <-s.quitWrCh:
+			if err := writeFrame(rw.Writer, data); err != nil {
+				s.disconnect(err)
+				return
+			}
+		case <-s.quitCh:
 			logger.Debug("Stream Write Terminated!")
-			return nil
+			return
 		}
 	}
-	return nil
+}
+
+//writeFrame writes and flushes the frame carrying data
+func writeFrame(w *bufio.Writer, data []byte) error {
+	if _, err := w.Write(encodeFrame(data)); err != nil {
+		return err
+	}
+	return w.Flush()
 }
 
 //should parse and relay
 func (s *Stream) parseData(data []byte) {
 
-	dataDecoded, err := decodeMessage(data)
-	if err != nil {
-		logger.Warn(err)
-		return
-	}
-
 	dmpb := &networkpb.Dapmsg{}
 	//unmarshal byte to proto
-	if err := proto.Unmarshal(dataDecoded, dmpb); err != nil {
+	if err := proto.Unmarshal(data, dmpb); err != nil {
 		logger.Info(err)
+		s.node.misbehave(s.peerID, MalformedMessagePenalty, "sent a malformed message")
+		return
 	}
 
 	dm := &DapMsg{}
 	dm.FromProto(dmpb)
 
+	if s.hello == nil {
+		s.handshake(dm)
+		return
+	}
+
+	//broadcasts are signed by the node creating them. The signature is verified before the message is cached, so that
+	//a forged copy does not hide the original message, and before its origin is used to penalize peers.
+	if dm.IsSigned() {
+		if err := dm.VerifySignature(); err != nil {
+			logger.Warn("Stream: Received ", dm.GetCmd(), " with an invalid signature from:", s.remoteAddr)
+			s.node.misbehave(s.peerID, InvalidSignaturePenalty, "relayed a message with an invalid signature")
+			return
+		}
+	}
+
+	if s.node.isNetworkRadiation(*dm) {
+		logger.Debug("Stream: Already received ", dm.GetCmd(), " with key ", dm.GetKey(), " before from:", s.remoteAddr)
+		return
+	}
+
 	switch dm.GetCmd() {
 	case SyncBlock:
 		logger.Debug("Stream: Received ", SyncBlock, " command from:", dm.key)
@@ -215,9 +309,85 @@ func (s *Stream) parseData(data []byte) {
 		s.node.sendRequestedBlock(dm.GetData(), s.peerID)
 	case BroadcastTx:
 		logger.Debug("Stream: Received ", BroadcastTx, " command from:", s.remoteAddr)
-		s.node.addTxToPool(dm.GetData())
+		s.node.addTxToPool(dm.GetData(), s.peerID)
+	case TxInv:
+		logger.Debug("Stream: Received ", TxInv, " command from:", s.remoteAddr)
+		s.node.txInvHandler(dm.GetData(), s.peerID)
+	case GetTx:
+		logger.Debug("Stream: Received ", GetTx, " command from:", s.remoteAddr)
+		s.node.getTxHandler(dm.GetData(), s.peerID)
+	case GetTip:
+		logger.Debug("Stream: Received ", GetTip, " command from:", s.remoteAddr)
+		s.node.sendTip(s.peerID)
+	case Tip:
+		logger.Debug("Stream: Received ", Tip, " command from:", s.remoteAddr)
+		s.node.tipHandler(dm.GetData(), s.peerID)
+	case GetHeaders:
+		logger.Debug("Stream: Received ", GetHeaders, " command from:", s.remoteAddr)
+		s.node.sendHeaders(dm.GetData(), s.peerID)
+	case Headers:
+		logger.Debug("Stream: Received ", Headers, " command from:", s.remoteAddr)
+		s.node.headersHandler(dm.GetData(), s.peerID)
+	case GetBodies:
+		logger.Debug("Stream: Received ", GetBodies, " command from:", s.remoteAddr)
+		s.node.sendBodies(dm.GetData(), s.peerID)
+	case Bodies:
+		logger.Debug("Stream: Received ", Bodies, " command from:", s.remoteAddr)
+		s.node.bodiesHandler(dm.GetData(), s.peerID)
+	case GetBlocks:
+		logger.Debug("Stream: Received ", GetBlocks, " command from:", s.remoteAddr)
+		s.node.sendBlocks(dm.GetData(), s.peerID)
+	case Blocks:
+		logger.Debug("Stream: Received ", Blocks, " command from:", s.remoteAddr)
+		s.node.blocksHandler(dm.GetData(), s.peerID)
+	case Hello, HelloAck:
+		logger.Debug("Stream: Received ", dm.GetCmd(), " command after the handshake from:", s.remoteAddr)
 	default:
 		logger.Debug("Received invalid command from:", s.remoteAddr)
 	}
 
 }
+
+//sendHello sends the Hello of the node with the Hello or HelloAck command
+func (s *Stream) sendHello(cmd string) {
+	data, err := s.node.prepareData(NewHelloMsg(s.node.bc).ToProto(), cmd, Unicast)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	if err := s.Send(data); err != nil {
+		logger.Debug("Stream: Unable to send ", cmd, " to ", s.remoteAddr, ": ", err)
+	}
+}
+
+//handshake expects the first message of the peer to be its Hello, or its HelloAck if the node opened the stream. The
+//peer is disconnected if it has a different genesis block or protocol major version.
+func (s *Stream) handshake(dm *DapMsg) {
+	expected := Hello
+	if s.outbound {
+		expected = HelloAck
+	}
+	if dm.GetCmd() != expected {
+		s.disconnect(ErrHandshakeRequired)
+		return
+	}
+
+	hello, err := getFromProtoHelloMsg(dm.GetData())
+	if err != nil {
+		s.disconnect(err)
+		return
+	}
+	if err := hello.verify(NewHelloMsg(s.node.bc)); err != nil {
+		s.disconnect(err)
+		return
+	}
+
+	s.hello = hello
+	if !s.outbound {
+		s.sendHello(HelloAck)
+	}
+	//the HelloAck is queued first, so the peer receives it before any broadcast
+	close(s.handshaked)
+	s.node.onHandshake(s, hello)
+}
+
@@ -0,0 +1,159 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-peer"
+	logger "github.com/sirupsen/logrus"
+)
+
+//streamEventQueueSize is the number of events buffered for every subscriber
+const streamEventQueueSize = 64
+
+//StreamEventType is the lifecycle event of a stream
+type StreamEventType int
+
+const (
+	StreamConnected StreamEventType = iota
+	StreamDisconnected
+)
+
+var (
+	ErrStreamExists     = errors.New("ERROR: A stream to the peer already exists")
+	ErrPeerNotConnected = errors.New("ERROR: Peer is not connected")
+)
+
+//StreamEvent notifies subscribers that a stream to a peer was connected or disconnected
+type StreamEvent struct {
+	Type     StreamEventType
+	PeerID   peer.ID
+	Outbound bool
+}
+
+//StreamRegistry holds the streams of the node, at most one per peer. It can be used from several goroutines.
+type StreamRegistry struct {
+	streams     map[peer.ID]*Stream
+	subscribers map[chan StreamEvent]bool
+	mutex       *sync.RWMutex
+}
+
+func NewStreamRegistry() *StreamRegistry {
+	return &StreamRegistry{
+		streams:     make(map[peer.ID]*Stream),
+		subscribers: make(map[chan StreamEvent]bool),
+		mutex:       &sync.RWMutex{},
+	}
+}
+
+//Add registers a stream and notifies subscribers that the peer is connected. It returns ErrStreamExists if the peer
+//already has a stream.
+func (r *StreamRegistry) Add(s *Stream) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.streams[s.peerID]; ok {
+		return ErrStreamExists
+	}
+	r.streams[s.peerID] = s
+	r.publish(StreamEvent{StreamConnected, s.peerID, s.outbound})
+	return nil
+}
+
+//Remove unregisters a stream and notifies subscribers that the peer is disconnected. It returns false if the stream is
+//not registered, for example because it was already removed.
+func (r *StreamRegistry) Remove(s *Stream) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.streams[s.peerID] != s {
+		return false
+	}
+	delete(r.streams, s.peerID)
+	r.publish(StreamEvent{StreamDisconnected, s.peerID, s.outbound})
+	return true
+}
+
+//Get returns the stream to a peer
+func (r *StreamRegistry) Get(pid peer.ID) (*Stream, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	s, ok := r.streams[pid]
+	return s, ok
+}
+
+func (r *StreamRegistry) IsConnected(pid peer.ID) bool {
+	_, ok := r.Get(pid)
+	return ok
+}
+
+//GetAll returns a snapshot of the registered streams
+func (r *StreamRegistry) GetAll() []*Stream {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	streams := make([]*Stream, 0, len(r.streams))
+	for _, s := range r.streams {
+		streams = append(streams, s)
+	}
+	return streams
+}
+
+//Count returns the number of streams opened by the node if outbound is true, and by peers otherwise
+func (r *StreamRegistry) Count(outbound bool) int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	count := 0
+	for _, s := range r.streams {
+		if s.outbound == outbound {
+			count++
+		}
+	}
+	return count
+}
+
+//Subscribe returns a channel receiving the lifecycle events of the streams from now on. Events are dropped for
+//subscribers that fall behind.
+func (r *StreamRegistry) Subscribe() chan StreamEvent {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ch := make(chan StreamEvent, streamEventQueueSize)
+	r.subscribers[ch] = true
+	return ch
+}
+
+//Unsubscribe stops sending events to ch and closes it
+func (r *StreamRegistry) Unsubscribe(ch chan StreamEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.subscribers[ch] {
+		delete(r.subscribers, ch)
+		close(ch)
+	}
+}
+
+//publish sends an event to the subscribers. The caller must hold the lock so that events are delivered in order.
+func (r *StreamRegistry) publish(event StreamEvent) {
+	for ch := range r.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logger.Warn("StreamRegistry: Subscriber is full. Event of peer ", event.PeerID, " is not delivered")
+		}
+	}
+}
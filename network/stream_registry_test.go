@@ -0,0 +1,132 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStream(pid peer.ID, outbound bool) *Stream {
+	return &Stream{
+		node:       NewNode(nil),
+		peerID:     pid,
+		dataCh:     make(chan []byte, 1),
+		quitCh:     make(chan bool),
+		stopOnce:   &sync.Once{},
+		outbound:   outbound,
+		handshaked: make(chan bool),
+	}
+}
+
+func TestStreamRegistry_AddRemove(t *testing.T) {
+	r := NewStreamRegistry()
+	events := r.Subscribe()
+	s1 := newTestStream("peer1", true)
+	s2 := newTestStream("peer2", false)
+
+	assert.Nil(t, r.Add(s1))
+	assert.Nil(t, r.Add(s2))
+	//only one stream is kept per peer
+	assert.Equal(t, ErrStreamExists, r.Add(newTestStream("peer1", false)))
+	assert.True(t, r.IsConnected("peer1"))
+	assert.Equal(t, 1, r.Count(true))
+	assert.Equal(t, 1, r.Count(false))
+	assert.ElementsMatch(t, []*Stream{s1, s2}, r.GetAll())
+
+	//a stream replaced by another one is not removed
+	assert.False(t, r.Remove(newTestStream("peer1", true)))
+	assert.True(t, r.Remove(s1))
+	assert.False(t, r.Remove(s1))
+	_, ok := r.Get("peer1")
+	assert.False(t, ok)
+
+	assert.Equal(t, StreamEvent{StreamConnected, "peer1", true}, <-events)
+	assert.Equal(t, StreamEvent{StreamConnected, "peer2", false}, <-events)
+	assert.Equal(t, StreamEvent{StreamDisconnected, "peer1", true}, <-events)
+	assert.Len(t, events, 0)
+
+	r.Unsubscribe(events)
+	_, open := <-events
+	assert.False(t, open)
+	assert.True(t, r.Remove(s2))
+}
+
+func TestStreamRegistry_Concurrency(t *testing.T) {
+	r := NewStreamRegistry()
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			s := newTestStream(pid, false)
+			r.Add(s)
+			r.GetAll()
+			r.Count(false)
+			r.Remove(s)
+		}(peer.ID([]byte{byte(i)}))
+	}
+	wg.Wait()
+	assert.Len(t, r.GetAll(), 0)
+}
+
+func TestStream_SendStopped(t *testing.T) {
+	s := newTestStream("peer1", true)
+	assert.Nil(t, s.Send([]byte{1}))
+	close(s.quitCh)
+	assert.Equal(t, ErrStreamStopped, s.Send([]byte{2}))
+}
+
+func TestStream_SendTooLarge(t *testing.T) {
+	s := newTestStream("peer1", true)
+	s.node.SetMaxFrameSize(4)
+	assert.Equal(t, ErrFrameTooLarge, s.Send([]byte{1, 2, 3, 4, 5}))
+	assert.Len(t, s.dataCh, 0)
+	assert.Nil(t, s.Send([]byte{1, 2, 3, 4}))
+}
+
+func TestNode_unicastDisconnectedPeer(t *testing.T) {
+	n := FakeNodeWithPidAndAddr(nil, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	pid, _ := peer.IDB58Decode("QmWvMUMBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ")
+
+	assert.Equal(t, ErrPeerNotConnected, n.unicast([]byte{1}, pid))
+	assert.Equal(t, ErrPeerNotConnected, n.RequestTipUnicast(pid))
+
+	s := newTestStream(pid, true)
+	assert.Nil(t, n.streams.Add(s))
+	assert.Nil(t, n.unicast([]byte{1}, pid))
+	n.streams.Remove(s)
+	assert.Equal(t, ErrPeerNotConnected, n.unicast([]byte{1}, pid))
+}
+
+func TestNode_broadcastSkipsPendingHandshakes(t *testing.T) {
+	n := FakeNodeWithPidAndAddr(nil, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	s1 := newTestStream("peer1", true)
+	s2 := newTestStream("peer2", false)
+	assert.Nil(t, n.streams.Add(s1))
+	assert.Nil(t, n.streams.Add(s2))
+	close(s1.handshaked)
+
+	n.broadcast([]byte{1})
+	assert.Len(t, s1.dataCh, 1)
+	assert.Len(t, s2.dataCh, 0)
+}
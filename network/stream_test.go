@@ -19,128 +19,97 @@
 package network
 
 import (
+	"bufio"
+	"bytes"
+	"io"
 	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
-func TestStream_decode(t *testing.T){
-	tests := []struct{
-		name 		string
-		input 		[]byte
-		retData 	[]byte
-		retErr 		error
+func TestStream_encodeFrame(t *testing.T) {
+	frame := encodeFrame([]byte{0x7F, 0x7F, 0x00})
+	assert.Equal(t, []byte{0x03, 0x7F, 0x7F, 0x00, 0x08, 0x4B, 0x93, 0x46}, frame)
+}
+
+func TestStream_readFrame(t *testing.T) {
+	large := bytes.Repeat([]byte{0x55}, 300)
+	corrupted := encodeFrame([]byte{0x55, 0x44})
+	corrupted[1] = 0x56
+
+	tests := []struct {
+		name    string
+		input   []byte
+		retData []byte
+		retErr  error
 	}{
 		{
-			name: 		"CorrectData",
-			input: 		[]byte{0x7E,0x7E,0x55,0x44,0x7F,0x7F,0x00},
-			retData:   	[]byte{0x55,0x44},
-			retErr: 	nil,
+			name:    "CorrectData",
+			input:   encodeFrame([]byte{0x55, 0x44}),
+			retData: []byte{0x55, 0x44},
+			retErr:  nil,
 		},
 		{
-			name: 		"IncorrectStartingByte",
-			input: 		[]byte{0x7E,0x55,0x44,0x7F,0x7F,0x00},
-			retData:   	nil,
-			retErr: 	ErrInvalidMessageFormat,
+			name:    "FormerDelimiters",
+			input:   encodeFrame([]byte{0x7E, 0x7E, 0x7F, 0x7F, 0x00, 0x55}),
+			retData: []byte{0x7E, 0x7E, 0x7F, 0x7F, 0x00, 0x55},
+			retErr:  nil,
 		},
 		{
-			name: 		"IncorrectEndingingByte",
-			input: 		[]byte{0x7E,0x7E,0x55,0x44,0x7F,0x7F,0x01},
-			retData:   	nil,
-			retErr: 	ErrInvalidMessageFormat,
+			name:    "MultiByteLength",
+			input:   encodeFrame(large),
+			retData: large,
+			retErr:  nil,
 		},
 		{
-			name: 		"IncorrectData",
-			input: 		[]byte{0x55,0x44},
-			retData:   	nil,
-			retErr: 	ErrInvalidMessageFormat,
-		},
-	}
-
-	for _,tt := range tests{
-		t.Run(tt.name,func(t *testing.T){
-			ret,err := decodeMessage(tt.input)
-			assert.Equal(t,tt.retData,ret)
-			assert.Equal(t,tt.retErr,err)
-		})
-	}
-}
-
-func TestStream_containStartingBytes(t *testing.T){
-	tests := []struct{
-		name 		string
-		input 		[]byte
-		expected 	bool
-	}{
-		{
-			name:		"containAtBeginning",
-			input:		[]byte{0x7E,0x7E,0x7F},
-			expected:	true,
+			name:    "TooLarge",
+			input:   encodeFrame(append(large, large...)),
+			retData: nil,
+			retErr:  ErrFrameTooLarge,
 		},
 		{
-			name:		"containAtTheEnd",
-			input:		[]byte{0x7F,0x7E,0x7E},
-			expected:	false,
+			name:    "InvalidChecksum",
+			input:   corrupted,
+			retData: nil,
+			retErr:  ErrInvalidChecksum,
 		},
 		{
-			name:		"containInTheMiddle",
-			input:		[]byte{0x7F,0x7E,0x7E,0x7F},
-			expected:	false,
+			name:    "EmptyFrame",
+			input:   encodeFrame([]byte{}),
+			retData: nil,
+			retErr:  ErrInvalidMessageFormat,
 		},
 		{
-			name:		"NotContaining",
-			input:		[]byte{0x7F,0x7F},
-			expected:	false,
+			name:    "Truncated",
+			input:   encodeFrame([]byte{0x55, 0x44})[:4],
+			retData: nil,
+			retErr:  io.ErrUnexpectedEOF,
 		},
 		{
-			name:		"EmptyInput",
-			input:		[]byte{},
-			expected:	false,
+			name:    "NoData",
+			input:   []byte{},
+			retData: nil,
+			retErr:  io.EOF,
 		},
 	}
 
-	for _,tt := range tests{
-		t.Run(tt.name,func(t *testing.T){
-			assert.Equal(t,tt.expected,containStartingBytes(tt.input))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret, err := readFrame(bufio.NewReader(bytes.NewReader(tt.input)), 500)
+			assert.Equal(t, tt.retData, ret)
+			assert.Equal(t, tt.retErr, err)
 		})
 	}
 }
 
-func TestStream_containEndingBytes(t *testing.T){
-	tests := []struct{
-		name 		string
-		input 		[]byte
-		expected 	bool
-	}{
-		{
-			name:		"containAtBeginning",
-			input:		[]byte{0x7F,0x7F,0x00,0x00},
-			expected:	false,
-		},
-		{
-			name:		"containAtTheEnd",
-			input:		[]byte{0x33,0x33,0x7F,0x7F,0x00,},
-			expected:	true,
-		},
-		{
-			name:		"containInTheMiddle",
-			input:		[]byte{0x33,0x33,0x7F,0x7F,0x00,0x33,0x33},
-			expected:	false,
-		},
-		{
-			name:		"NotContaining",
-			input:		[]byte{0xDF,0x23},
-			expected:	false,
-		},
-		{
-			name:		"EmptyInput",
-			input:		[]byte{},
-			expected:	false,
-		},
-	}
+func TestStream_readFrameSequence(t *testing.T) {
+	input := append(encodeFrame([]byte{0x01}), encodeFrame([]byte{0x02, 0x03})...)
+	r := bufio.NewReader(bytes.NewReader(input))
 
-	for _,tt := range tests{
-		t.Run(tt.name,func(t *testing.T){
-			assert.Equal(t,tt.expected,containEndingBytes(tt.input))
-		})
-	}
-}
\ No newline at end of file
+	data, err := readFrame(r, DefaultMaxFrameSize)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x01}, data)
+	data, err = readFrame(r, DefaultMaxFrameSize)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x02, 0x03}, data)
+}
@@ -0,0 +1,539 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dappley/go-dappley/core"
+	"github.com/libp2p/go-libp2p-peer"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	syncTickInterval     = time.Second
+	tipRequestInterval   = 10 * time.Second
+	headerRequestTimeout = 10 * time.Second
+	bodyRequestTimeout   = 10 * time.Second
+	//maxHeadersPerRequest is the maximum number of headers requested from or sent to a peer at once
+	maxHeadersPerRequest = 128
+	//maxBodiesPerRequest is the maximum number of blocks requested from or sent to a peer at once
+	maxBodiesPerRequest = 16
+	//maxBodiesInFlightPerPeer is the maximum number of blocks requested from a peer and not received yet
+	maxBodiesInFlightPerPeer = 32
+	//bodyDownloadWindow is how many validated headers ahead of the blockchain tail have their blocks downloaded
+	bodyDownloadWindow = 256
+	//maxPendingHeaders is how many validated headers ahead of the blockchain tail are kept before pausing the header
+	//download
+	maxPendingHeaders = 1024
+)
+
+var (
+	ErrBlockNotValidated = errors.New("ERROR: Block did not pass consensus validation")
+	ErrBlocksNotMerged   = errors.New("ERROR: Blocks are not the tail of the blockchain after merging them")
+)
+
+//SyncProgress reports how far the node is in synchronizing its blockchain with its peers
+type SyncProgress struct {
+	Syncing       bool
+	StartHeight   uint64
+	CurrentHeight uint64
+	HeaderHeight  uint64
+	TargetHeight  uint64
+	PeerCount     int
+}
+
+//syncRequester sends the requests of the SyncManager to peers
+type syncRequester interface {
+	RequestTipBroadcast() error
+	RequestHeadersUnicast(startHeight uint64, count uint64, pid peer.ID) error
+	RequestBodiesUnicast(hashes [][]byte, pid peer.ID) error
+	misbehave(pid peer.ID, penalty int, reason string)
+}
+
+type bodyRequest struct {
+	pid         peer.ID
+	header      *core.Block
+	requestedAt time.Time
+	batch       uint64 //identifies the request sent to pid for the block
+}
+
+//SyncManager synchronizes the blockchain with peers that have a higher chain tip. It first downloads and validates the
+//chain of headers from a single peer, then downloads the blocks of the validated headers in parallel from all peers
+//that have them and adds them to the blockchain in order.
+type SyncManager struct {
+	bc                *core.Blockchain
+	requester         syncRequester
+	peerHeights       map[peer.ID]uint64
+	syncing           bool
+	syncPeer          peer.ID
+	startHeight       uint64
+	targetHeight      uint64
+	headerStart       uint64
+	headerRequestedAt time.Time
+	lastHeader        *core.Block
+	headers           []*core.Block
+	bodies            map[string]*core.Block
+	bodyRequests      map[string]*bodyRequest
+	nextBatch         uint64
+	requests          []func()
+	exitCh            chan bool
+	mutex             *sync.Mutex
+}
+
+func NewSyncManager(bc *core.Blockchain, requester syncRequester) *SyncManager {
+	return &SyncManager{
+		bc:           bc,
+		requester:    requester,
+		peerHeights:  make(map[peer.ID]uint64),
+		bodies:       make(map[string]*core.Block),
+		bodyRequests: make(map[string]*bodyRequest),
+		exitCh:       make(chan bool, 1),
+		mutex:        &sync.Mutex{},
+	}
+}
+
+//Start starts asking peers for their chain tips and retrying the requests that timed out
+func (m *SyncManager) Start() {
+	go func() {
+		ticker := time.NewTicker(syncTickInterval)
+		defer ticker.Stop()
+		lastTipRequest := time.Time{}
+		for {
+			select {
+			case <-m.exitCh:
+				return
+			case now := <-ticker.C:
+				if now.Sub(lastTipRequest) >= tipRequestInterval {
+					m.requester.RequestTipBroadcast()
+					lastTipRequest = now
+				}
+				m.checkTimeouts(now)
+			}
+		}
+	}()
+}
+
+func (m *SyncManager) Stop() {
+	m.exitCh <- true
+}
+
+//GetProgress returns the progress of the current synchronization
+func (m *SyncManager) GetProgress() SyncProgress {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	progress := SyncProgress{
+		Syncing:       m.syncing,
+		StartHeight:   m.startHeight,
+		CurrentHeight: m.bc.GetMaxHeight(),
+		PeerCount:     len(m.peerHeights),
+	}
+	progress.HeaderHeight = progress.CurrentHeight
+	if m.lastHeader != nil && m.lastHeader.GetHeight() > progress.HeaderHeight {
+		progress.HeaderHeight = m.lastHeader.GetHeight()
+	}
+	progress.TargetHeight = progress.CurrentHeight
+	if m.syncing {
+		progress.TargetHeight = m.targetHeight
+	}
+	for _, height := range m.peerHeights {
+		if height > progress.TargetHeight {
+			progress.TargetHeight = height
+		}
+	}
+	return progress
+}
+
+//OnTip records the height of the chain tip of a peer and starts synchronizing if it is higher than the blockchain
+func (m *SyncManager) OnTip(pid peer.ID, height uint64) {
+	m.mutex.Lock()
+	m.peerHeights[pid] = height
+	m.update()
+	m.sendRequests()
+}
+
+//OnHeaders validates the headers received from the peer the node is synchronizing with
+func (m *SyncManager) OnHeaders(pid peer.ID, headers []*core.Block) {
+	m.mutex.Lock()
+	if !m.syncing || pid != m.syncPeer || m.headerRequestedAt.IsZero() {
+		m.mutex.Unlock()
+		return
+	}
+	m.headerRequestedAt = time.Time{}
+
+	if len(headers) == 0 {
+		if m.lastHeader == nil {
+			logger.Warn("SyncManager: ", pid, " has no headers from height ", m.headerStart)
+			m.dropSyncPeer()
+		} else {
+			//the peer has no block above the last header
+			m.targetHeight = m.lastHeader.GetHeight()
+		}
+	} else if !m.linkHeaders(headers) {
+		logger.Warn("SyncManager: Received invalid headers from ", pid)
+		m.dropSyncPeer()
+	}
+
+	m.update()
+	m.sendRequests()
+}
+
+//OnBodies stores the requested blocks received from a peer and adds them to the blockchain in order
+func (m *SyncManager) OnBodies(pid peer.ID, blocks []*core.Block) {
+	m.mutex.Lock()
+	answered := make(map[uint64]bool)
+	for _, blk := range blocks {
+		key := string(blk.GetHash())
+		request, ok := m.bodyRequests[key]
+		if !ok || request.pid != pid {
+			continue
+		}
+		answered[request.batch] = true
+		delete(m.bodyRequests, key)
+		if !matchesHeader(blk, request.header) {
+			logger.Warn("SyncManager: Received a block that does not match its header from ", pid)
+			continue
+		}
+		m.bodies[key] = blk
+	}
+	//peers only send the blocks that fit in a frame, so the blocks left out of a response are requested again
+	for key, request := range m.bodyRequests {
+		if request.pid == pid && answered[request.batch] {
+			delete(m.bodyRequests, key)
+		}
+	}
+	m.update()
+	m.sendRequests()
+}
+
+//RemovePeer forgets a disconnected peer and requests the blocks it did not send from other peers
+func (m *SyncManager) RemovePeer(pid peer.ID) {
+	m.mutex.Lock()
+	delete(m.peerHeights, pid)
+	if m.syncing && pid == m.syncPeer {
+		m.reset()
+	}
+	for key, request := range m.bodyRequests {
+		if request.pid == pid {
+			delete(m.bodyRequests, key)
+		}
+	}
+	m.update()
+	m.sendRequests()
+}
+
+func (m *SyncManager) checkTimeouts(now time.Time) {
+	m.mutex.Lock()
+	if m.syncing && !m.headerRequestedAt.IsZero() && now.Sub(m.headerRequestedAt) >= headerRequestTimeout {
+		logger.Warn("SyncManager: Header request to ", m.syncPeer, " timed out")
+		m.dropSyncPeer()
+	}
+	for key, request := range m.bodyRequests {
+		if now.Sub(request.requestedAt) >= bodyRequestTimeout {
+			delete(m.bodyRequests, key)
+		}
+	}
+	m.update()
+	m.sendRequests()
+}
+
+//sendRequests unlocks the manager and sends the requests queued while it was locked, so that a slow peer does not
+//block the manager
+func (m *SyncManager) sendRequests() {
+	requests := m.requests
+	m.requests = nil
+	m.mutex.Unlock()
+	for _, request := range requests {
+		request()
+	}
+}
+
+func (m *SyncManager) update() {
+	if !m.syncing {
+		m.startSync()
+		if !m.syncing {
+			return
+		}
+	}
+	if height := m.peerHeights[m.syncPeer]; height > m.targetHeight {
+		m.targetHeight = height
+	}
+	m.applyBodies()
+	if !m.syncing {
+		return
+	}
+	if m.headerRequestedAt.IsZero() && m.lastHeader != nil && m.lastHeader.GetHeight() < m.targetHeight &&
+		len(m.headers) < maxPendingHeaders {
+		m.requestHeaders()
+	}
+	m.requestBodies()
+
+	if m.headerRequestedAt.IsZero() && len(m.headers) == 0 && m.lastHeader != nil &&
+		m.lastHeader.GetHeight() >= m.targetHeight {
+		logger.Info("SyncManager: Synchronized to height ", m.bc.GetMaxHeight())
+		m.reset()
+	}
+}
+
+//startSync starts downloading headers from the peer with the highest chain tip if it is higher than the blockchain
+func (m *SyncManager) startSync() {
+	height := m.bc.GetMaxHeight()
+	var bestPeer peer.ID
+	bestHeight := height
+	for pid, peerHeight := range m.peerHeights {
+		if peerHeight > bestHeight {
+			bestPeer = pid
+			bestHeight = peerHeight
+		}
+	}
+	if bestHeight == height {
+		return
+	}
+
+	logger.Info("SyncManager: Synchronizing from height ", height, " to ", bestHeight, " with ", bestPeer)
+	m.reset()
+	m.syncing = true
+	m.syncPeer = bestPeer
+	m.startHeight = height
+	m.targetHeight = bestHeight
+	m.headerStart = height + 1
+	m.requestHeaders()
+}
+
+func (m *SyncManager) reset() {
+	m.syncing = false
+	m.syncPeer = ""
+	m.headerRequestedAt = time.Time{}
+	m.lastHeader = nil
+	m.headers = nil
+	m.bodies = make(map[string]*core.Block)
+	m.bodyRequests = make(map[string]*bodyRequest)
+}
+
+func (m *SyncManager) dropSyncPeer() {
+	delete(m.peerHeights, m.syncPeer)
+	m.reset()
+}
+
+func (m *SyncManager) requestHeaders() {
+	start := m.headerStart
+	if m.lastHeader != nil {
+		start = m.lastHeader.GetHeight() + 1
+	}
+	pid := m.syncPeer
+	m.headerRequestedAt = time.Now()
+	m.requests = append(m.requests, func() {
+		m.requester.RequestHeadersUnicast(start, maxHeadersPerRequest, pid)
+	})
+}
+
+//linkHeaders appends the headers to the validated header chain. Before the first headers are linked, the headers are
+//requested from lower heights until they link to a block of the blockchain.
+func (m *SyncManager) linkHeaders(headers []*core.Block) bool {
+	parent := m.lastHeader
+	if parent == nil {
+		var err error
+		parent, err = m.bc.GetBlockByHash(headers[0].GetPrevHash())
+		if err != nil {
+			//the chain of the peer forked below the requested height
+			if m.headerStart <= 1 {
+				return false
+			}
+			if m.headerStart > maxHeadersPerRequest {
+				m.headerStart -= maxHeadersPerRequest
+			} else {
+				m.headerStart = 1
+			}
+			m.requestHeaders()
+			return true
+		}
+	}
+
+	for _, header := range headers {
+		if !m.verifyHeader(header, parent) {
+			return false
+		}
+		if !m.bc.IsInBlockchain(header.GetHash()) {
+			m.headers = append(m.headers, header)
+		}
+		parent = header
+	}
+	m.lastHeader = parent
+	return true
+}
+
+func (m *SyncManager) verifyHeader(header *core.Block, parent *core.Block) bool {
+	if !parent.IsParentBlock(header) {
+		return false
+	}
+	if !bytes.Equal(header.GetHash(), header.CalculateHash()) {
+		return false
+	}
+	if m.bc.GetConsensus() != nil && !m.bc.GetConsensus().VerifyBlock(header) {
+		return false
+	}
+	return true
+}
+
+//requestBodies requests the blocks of the next headers from the peers whose chain tips are high enough, spreading
+//the requests over the peers with the fewest blocks in flight
+func (m *SyncManager) requestBodies() {
+	inFlight := make(map[peer.ID]int)
+	for _, request := range m.bodyRequests {
+		inFlight[request.pid]++
+	}
+
+	batches := make(map[peer.ID][][]byte)
+	window := m.headers
+	if len(window) > bodyDownloadWindow {
+		window = window[:bodyDownloadWindow]
+	}
+	now := time.Now()
+	for _, header := range window {
+		key := string(header.GetHash())
+		if _, ok := m.bodies[key]; ok {
+			continue
+		}
+		if _, ok := m.bodyRequests[key]; ok {
+			continue
+		}
+		pid, ok := m.pickBodyPeer(header.GetHeight(), inFlight)
+		if !ok {
+			break
+		}
+		inFlight[pid]++
+		batches[pid] = append(batches[pid], header.GetHash())
+		m.bodyRequests[key] = &bodyRequest{pid, header, now}
+	}
+
+	for pid, hashes := range batches {
+		for len(hashes) > 0 {
+			n := len(hashes)
+			if n > maxBodiesPerRequest {
+				n = maxBodiesPerRequest
+			}
+			batch, target := hashes[:n], pid
+			for _, hash := range batch {
+				m.bodyRequests[string(hash)].batch = m.nextBatch
+			}
+			m.nextBatch++
+			m.requests = append(m.requests, func() {
+				m.requester.RequestBodiesUnicast(batch, target)
+			})
+			hashes = hashes[n:]
+		}
+	}
+}
+
+func (m *SyncManager) pickBodyPeer(height uint64, inFlight map[peer.ID]int) (peer.ID, bool) {
+	var bestPeer peer.ID
+	found := false
+	for pid, peerHeight := range m.peerHeights {
+		if peerHeight < height || inFlight[pid] >= maxBodiesInFlightPerPeer {
+			continue
+		}
+		if !found || inFlight[pid] < inFlight[bestPeer] {
+			bestPeer = pid
+			found = true
+		}
+	}
+	return bestPeer, found
+}
+
+//applyBodies adds the downloaded blocks following the blockchain tail to the blockchain. Blocks of a fork are only
+//merged once the fork is higher than the blockchain.
+func (m *SyncManager) applyBodies() {
+	for len(m.headers) > 0 && m.bc.IsInBlockchain(m.headers[0].GetHash()) {
+		delete(m.bodies, string(m.headers[0].GetHash()))
+		delete(m.bodyRequests, string(m.headers[0].GetHash()))
+		m.headers = m.headers[1:]
+	}
+
+	var blks []*core.Block
+	for _, header := range m.headers {
+		blk, ok := m.bodies[string(header.GetHash())]
+		if !ok {
+			break
+		}
+		blks = append(blks, blk)
+	}
+	if len(blks) == 0 {
+		return
+	}
+
+	last := blks[len(blks)-1]
+	extendsTail := bytes.Equal(blks[0].GetPrevHash(), m.bc.GetTailBlockHash())
+	if !extendsTail && last.GetHeight() <= m.bc.GetMaxHeight() && len(blks) < bodyDownloadWindow {
+		return
+	}
+
+	if err := addBlocksToBlockchain(m.bc, blks); err != nil {
+		if err == core.ErrInvalidForkTransactions {
+			//the blocks match the headers served by the sync peer, so it is the one vouching for them
+			pid := m.syncPeer
+			m.requests = append(m.requests, func() {
+				m.requester.misbehave(pid, InvalidBlockPenalty, "sent blocks with invalid transactions")
+			})
+		}
+		m.dropSyncPeer()
+		return
+	}
+
+	for _, blk := range blks {
+		delete(m.bodies, string(blk.GetHash()))
+	}
+	m.headers = m.headers[len(blks):]
+}
+
+//addBlocksToBlockchain validates blks, which follow each other starting from a block of the blockchain, and merges
+//them into the blockchain. It returns core.ErrInvalidForkTransactions if their transactions cannot pass verification,
+//and ErrBlocksNotMerged if the blocks are not the tail of the blockchain afterwards.
+func addBlocksToBlockchain(bc *core.Blockchain, blks []*core.Block) error {
+	forkBlks := make([]*core.Block, len(blks))
+	for i, blk := range blks {
+		if bc.GetConsensus() != nil && !bc.GetConsensus().Validate(blk) {
+			logger.Warn("Node: Block at height ", blk.GetHeight(), " did not pass consensus validation")
+			return ErrBlockNotValidated
+		}
+		forkBlks[len(blks)-1-i] = blk
+	}
+	if err := bc.MergeFork(forkBlks); err != nil {
+		logger.Warn("Node: Unable to merge the blocks up to height ", blks[len(blks)-1].GetHeight(), ": ", err)
+		return err
+	}
+
+	last := blks[len(blks)-1]
+	if !bytes.Equal(bc.GetTailBlockHash(), last.GetHash()) {
+		logger.Warn("Node: Unable to add the blocks up to height ", last.GetHeight(), " to the blockchain")
+		return ErrBlocksNotMerged
+	}
+	return nil
+}
+
+//matchesHeader returns true if blk has the validated header and its transactions match the merkle root of the header
+func matchesHeader(blk *core.Block, header *core.Block) bool {
+	return blk.VerifyHash() &&
+		blk.GetHeight() == header.GetHeight() &&
+		bytes.Equal(blk.GetPrevHash(), header.GetPrevHash()) &&
+		bytes.Equal(blk.GetSign(), header.GetSign())
+}
@@ -0,0 +1,344 @@
+// Copyright (C) 2018 go-dappley authors
+//
+// This file is part of the go-dappley library.
+//
+// the go-dappley library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-dappley library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-dappley library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dappley/go-dappley/consensus"
+	"github.com/dappley/go-dappley/core"
+	"github.com/dappley/go-dappley/core/pb"
+	"github.com/dappley/go-dappley/storage"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type headersRequest struct {
+	startHeight uint64
+	count       uint64
+	pid         peer.ID
+}
+
+type bodiesRequest struct {
+	hashes [][]byte
+	pid    peer.ID
+}
+
+//fakeSyncRequester records the requests of a SyncManager instead of sending them to peers
+type fakeSyncRequester struct {
+	headersRequests []headersRequest
+	bodiesRequests  []bodiesRequest
+	penalties       map[peer.ID]int
+}
+
+func (r *fakeSyncRequester) RequestTipBroadcast() error { return nil }
+
+func (r *fakeSyncRequester) RequestHeadersUnicast(startHeight uint64, count uint64, pid peer.ID) error {
+	r.headersRequests = append(r.headersRequests, headersRequest{startHeight, count, pid})
+	return nil
+}
+
+func (r *fakeSyncRequester) RequestBodiesUnicast(hashes [][]byte, pid peer.ID) error {
+	r.bodiesRequests = append(r.bodiesRequests, bodiesRequest{hashes, pid})
+	return nil
+}
+
+func (r *fakeSyncRequester) misbehave(pid peer.ID, penalty int, reason string) {
+	r.penalties[pid] += penalty
+}
+
+//serveSyncRequests answers the recorded requests from the blockchain of server until no request is left and returns
+//the peers blocks were requested from
+func serveSyncRequests(m *SyncManager, r *fakeSyncRequester, server *Node) map[peer.ID]bool {
+	bodyPeers := make(map[peer.ID]bool)
+	for len(r.headersRequests) > 0 || len(r.bodiesRequests) > 0 {
+		if len(r.headersRequests) > 0 {
+			request := r.headersRequests[0]
+			r.headersRequests = r.headersRequests[1:]
+			m.OnHeaders(request.pid, getHeadersFromProto(server.getHeaders(request.startHeight, request.count)))
+			continue
+		}
+		request := r.bodiesRequests[0]
+		r.bodiesRequests = r.bodiesRequests[1:]
+		bodyPeers[request.pid] = true
+		m.OnBodies(request.pid, getBlocksFromProto(server.getBodies(request.hashes)))
+	}
+	return bodyPeers
+}
+
+func newSyncingNode(bc *core.Blockchain) (*SyncManager, *fakeSyncRequester) {
+	requester := &fakeSyncRequester{penalties: make(map[peer.ID]int)}
+	return NewSyncManager(bc, requester), requester
+}
+
+func createEmptyBlockchain() *core.Blockchain {
+	return core.CreateBlockchain(core.NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj"), storage.NewRamStorage(), nil)
+}
+
+//generateValidBlockchain returns a blockchain of size blocks holding coinbase transactions to producer that pass
+//verification
+func generateValidBlockchain(size int, producer string) *core.Blockchain {
+	bc := createEmptyBlockchain()
+	for i := 0; i < size; i++ {
+		tailBlk, _ := bc.GetTailBlock()
+		cbtx := core.NewCoinbaseTX(producer, "", bc.GetMaxHeight()+1, 0)
+		b := core.NewBlock([]*core.Transaction{&cbtx}, tailBlk)
+		b.SetHash(b.CalculateHash())
+		bc.AddBlockToTail(b)
+	}
+	return bc
+}
+
+func TestSyncManager_Sync(t *testing.T) {
+	remote := generateValidBlockchain(maxHeadersPerRequest+22, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	bc := createEmptyBlockchain()
+	m, requester := newSyncingNode(bc)
+
+	m.OnTip(peer.ID("peer1"), remote.GetMaxHeight())
+	m.OnTip(peer.ID("peer2"), remote.GetMaxHeight())
+	progress := m.GetProgress()
+	assert.True(t, progress.Syncing)
+	assert.Equal(t, remote.GetMaxHeight(), progress.TargetHeight)
+	assert.Equal(t, 2, progress.PeerCount)
+	assert.Len(t, requester.headersRequests, 1)
+	assert.Equal(t, uint64(1), requester.headersRequests[0].startHeight)
+
+	bodyPeers := serveSyncRequests(m, requester, server)
+
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+	//blocks are downloaded from both peers
+	assert.Len(t, bodyPeers, 2)
+	progress = m.GetProgress()
+	assert.False(t, progress.Syncing)
+	assert.Equal(t, remote.GetMaxHeight(), progress.CurrentHeight)
+}
+
+func TestSyncManager_SyncSmallFrames(t *testing.T) {
+	remote := generateValidBlockchain(40, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	//frames only fit the first three blocks of a batch
+	var hashes [][]byte
+	frameSize := messageOverhead
+	for height := uint64(1); height <= maxBodiesPerRequest; height++ {
+		blk, err := remote.GetBlockByHeight(height)
+		assert.Nil(t, err)
+		hashes = append(hashes, blk.GetHash())
+		if height <= 3 {
+			frameSize += getEncodedBlockSize(blk.ToProto().(*corepb.Block))
+		}
+	}
+	server.SetMaxFrameSize(frameSize)
+	assert.Len(t, server.getBodies(hashes), 3)
+
+	bc := createEmptyBlockchain()
+	m, requester := newSyncingNode(bc)
+	m.OnTip(peer.ID("peer1"), remote.GetMaxHeight())
+	serveSyncRequests(m, requester, server)
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+}
+
+func TestSyncManager_SyncFork(t *testing.T) {
+	remote := generateValidBlockchain(maxHeadersPerRequest+22, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	bc := generateValidBlockchain(maxHeadersPerRequest+2, "1MeSBgufmzwpiJNLemUe1emxAussBnz7a7")
+	m, requester := newSyncingNode(bc)
+
+	m.OnTip(peer.ID("peer1"), remote.GetMaxHeight())
+	assert.Equal(t, bc.GetMaxHeight()+1, requester.headersRequests[0].startHeight)
+
+	//the headers don't link to the blockchain, so they are requested again from lower heights
+	m.OnHeaders(peer.ID("peer1"), getHeadersFromProto(server.getHeaders(bc.GetMaxHeight()+1, maxHeadersPerRequest)))
+	assert.Len(t, requester.headersRequests, 2)
+	assert.Equal(t, uint64(3), requester.headersRequests[1].startHeight)
+
+	requester.headersRequests = requester.headersRequests[1:]
+	serveSyncRequests(m, requester, server)
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+	assert.False(t, m.GetProgress().Syncing)
+}
+
+func TestSyncManager_InvalidHeaders(t *testing.T) {
+	remote := generateValidBlockchain(10, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := createEmptyBlockchain()
+	m, requester := newSyncingNode(bc)
+
+	m.OnTip(peer.ID("peer1"), remote.GetMaxHeight())
+	assert.Len(t, requester.headersRequests, 1)
+
+	//headers that do not follow each other
+	block1, _ := remote.GetBlockByHeight(1)
+	block3, _ := remote.GetBlockByHeight(3)
+	m.OnHeaders(peer.ID("peer1"), []*core.Block{block1, block3})
+
+	progress := m.GetProgress()
+	assert.False(t, progress.Syncing)
+	assert.Equal(t, 0, progress.PeerCount)
+	assert.Len(t, requester.bodiesRequests, 0)
+}
+
+func TestSyncManager_RemovePeer(t *testing.T) {
+	remote := generateValidBlockchain(10, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	bc := createEmptyBlockchain()
+	m, requester := newSyncingNode(bc)
+
+	m.OnTip(peer.ID("peer1"), remote.GetMaxHeight())
+	m.OnTip(peer.ID("peer2"), remote.GetMaxHeight())
+	syncPeer := requester.headersRequests[0].pid
+	otherPeer := peer.ID("peer1")
+	if syncPeer == otherPeer {
+		otherPeer = peer.ID("peer2")
+	}
+
+	//the synchronization restarts with the other peer
+	m.RemovePeer(syncPeer)
+	assert.Len(t, requester.headersRequests, 2)
+	assert.Equal(t, otherPeer, requester.headersRequests[1].pid)
+
+	requester.headersRequests = requester.headersRequests[1:]
+	serveSyncRequests(m, requester, server)
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+}
+
+func TestNode_GetBlocks(t *testing.T) {
+	remote := generateValidBlockchain(MaxGetBlocksCount+22, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	bc := generateValidBlockchain(5, "1MeSBgufmzwpiJNLemUe1emxAussBnz7a7")
+	n := FakeNodeWithPidAndAddr(bc, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10001")
+
+	getBlocks := func() []*core.Block {
+		var locator [][]byte
+		for _, hash := range bc.GetBlockLocator() {
+			locator = append(locator, hash)
+		}
+		return getBlocksFromProto(server.getBlocks(locator, MaxGetBlocksCount))
+	}
+
+	//the blocks follow the genesis block, the only block both blockchains have in common
+	blocks := getBlocks()
+	assert.Len(t, blocks, MaxGetBlocksCount)
+	assert.Equal(t, uint64(1), blocks[0].GetHeight())
+	assert.True(t, n.addBlocks(blocks, peer.ID("peer1")))
+	assert.Equal(t, uint64(MaxGetBlocksCount), bc.GetMaxHeight())
+
+	blocks = getBlocks()
+	assert.Len(t, blocks, 22)
+	assert.True(t, n.addBlocks(blocks, peer.ID("peer1")))
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+
+	assert.Len(t, getBlocks(), 0)
+}
+
+func TestNode_GetBlocksFrameSize(t *testing.T) {
+	remote := generateValidBlockchain(10, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	server := FakeNodeWithPidAndAddr(remote, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10000")
+	genesis, err := remote.GetBlockByHeight(0)
+	assert.Nil(t, err)
+
+	//the response is cut to the blocks that fit in a frame
+	frameSize := messageOverhead
+	for height := uint64(1); height <= 2; height++ {
+		blk, err := remote.GetBlockByHeight(height)
+		assert.Nil(t, err)
+		frameSize += getEncodedBlockSize(blk.ToProto().(*corepb.Block))
+	}
+	server.SetMaxFrameSize(frameSize)
+	blocks := getBlocksFromProto(server.getBlocks([][]byte{genesis.GetHash()}, MaxGetBlocksCount))
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, uint64(1), blocks[0].GetHeight())
+}
+
+func TestNode_AddBlocksInvalid(t *testing.T) {
+	remote := generateValidBlockchain(10, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	bc := generateValidBlockchain(8, "1MeSBgufmzwpiJNLemUe1emxAussBnz7a7")
+	n := FakeNodeWithPidAndAddr(bc, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10001")
+	tailHash := bc.GetTailBlockHash()
+
+	var blocks []*core.Block
+	for height := uint64(1); height <= 10; height++ {
+		blk, _ := remote.GetBlockByHeight(height)
+		blocks = append(blocks, blk)
+	}
+
+	//blocks that do not follow each other
+	assert.False(t, n.addBlocks([]*core.Block{blocks[0], blocks[2]}, peer.ID("peer1")))
+	//blocks that do not follow a block of the blockchain
+	assert.False(t, n.addBlocks(blocks[1:], peer.ID("peer1")))
+	//a fork that is not higher than the blockchain
+	assert.False(t, n.addBlocks(blocks[:8], peer.ID("peer1")))
+	assert.Equal(t, tailHash, bc.GetTailBlockHash())
+	//only the blocks that do not follow each other are invalid
+	assert.Equal(t, InvalidBlockPenalty, n.GetPeerList().GetScore(peer.ID("peer1")))
+
+	assert.True(t, n.addBlocks(blocks, peer.ID("peer1")))
+	assert.Equal(t, remote.GetTailBlockHash(), bc.GetTailBlockHash())
+}
+
+func TestNode_AddBlocksInvalidTransactions(t *testing.T) {
+	bc := generateValidBlockchain(3, "16PencPNnF8CiSx2EBGEd1axhf7vuHCouj")
+	n := FakeNodeWithPidAndAddr(bc, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10001")
+	tail, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+
+	//a block spending outputs that do not exist
+	blk := core.NewBlock([]*core.Transaction{core.MockTransaction()}, tail)
+	blk.SetHash(blk.CalculateHash())
+
+	assert.False(t, n.addBlocks([]*core.Block{blk}, peer.ID("peer1")))
+	assert.Equal(t, tail.GetHash(), bc.GetTailBlockHash())
+	assert.Equal(t, InvalidBlockPenalty, n.GetPeerList().GetScore(peer.ID("peer1")))
+}
+
+func TestNode_AddBlocksReceivedByBroadcast(t *testing.T) {
+	producer := "1ArH9WoB9F7i6qoJiAi7McZMFVQSsBKXZR"
+	key := "5a66b0fdb69c99935783059bb200e86e97b506ae443a62febd7d0750cd7fac55"
+	dpos := consensus.NewDpos()
+	dpos.SetDynasty(consensus.NewDynastyWithProducers([]string{producer}))
+	dpos.SetTargetBit(0)
+	bc := core.CreateBlockchain(core.NewAddress(producer), storage.NewRamStorage(), dpos)
+	n := FakeNodeWithPidAndAddr(bc, "QmWsMUDBeWxwU4R5ukBiKmSiGT8cDqmkfrXCb2qTVHpofJ", "/ip4/127.0.0.1/tcp/10001")
+
+	//signed blocks of consecutive time slots
+	newBlock := func(data string, timestamp int64, parent *core.Block) *core.Block {
+		cbtx := core.NewCoinbaseTX(producer, data, parent.GetHeight()+1, 0)
+		blk := core.FakeNewBlockWithTimestamp(timestamp, []*core.Transaction{&cbtx}, parent)
+		blk.SetHash(blk.CalculateHash())
+		blk.SignBlock(key, blk.GetHash())
+		return blk
+	}
+	genesis, err := bc.GetTailBlock()
+	assert.Nil(t, err)
+	now := time.Now().Unix()
+	blk1 := newBlock("", now, genesis)
+	blk2 := newBlock("", now+1, blk1)
+
+	//the second block is broadcast before the node has its parent, so it is validated but not added
+	assert.Nil(t, bc.GetBlockPool().Push(blk2, peer.ID("peer1")))
+	assert.Equal(t, genesis.GetHash(), bc.GetTailBlockHash())
+
+	//the same block passes validation again when it is synced
+	assert.True(t, n.addBlocks([]*core.Block{blk1, blk2}, peer.ID("peer2")))
+	assert.Equal(t, blk2.GetHash(), bc.GetTailBlockHash())
+	assert.True(t, dpos.Validate(blk2))
+
+	//another block of a time slot that already has a block in the blockchain is rejected
+	assert.False(t, dpos.Validate(newBlock("double mint", now+1, blk1)))
+}
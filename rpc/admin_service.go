@@ -19,9 +19,11 @@ package rpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/dappley/go-dappley/network"
 	"github.com/dappley/go-dappley/rpc/pb"
+	"github.com/libp2p/go-libp2p-peer"
 )
 
 type AdminRpcService struct{
@@ -38,3 +40,51 @@ func (adminRpcService *AdminRpcService) RpcAddPeer(ctx context.Context, in *rpcp
 		Status: status,
 	}, nil
 }
+
+//RpcBanPeer disconnects a peer and refuses to connect to it for the requested duration
+func (adminRpcService *AdminRpcService) RpcBanPeer(ctx context.Context, in *rpcpb.BanPeerRequest) (*rpcpb.BanPeerResponse, error) {
+	duration := network.DefaultBanDuration
+	if in.Duration > 0 {
+		duration = time.Duration(in.Duration) * time.Second
+	}
+	status := "success"
+	pid, err := peer.IDB58Decode(in.PeerId)
+	if err == nil {
+		err = adminRpcService.node.BanPeer(pid, duration)
+	}
+	if err != nil {
+		status = err.Error()
+	}
+	return &rpcpb.BanPeerResponse{
+		Status: status,
+	}, nil
+}
+
+//RpcUnbanPeer allows connecting to a banned peer again
+func (adminRpcService *AdminRpcService) RpcUnbanPeer(ctx context.Context, in *rpcpb.UnbanPeerRequest) (*rpcpb.UnbanPeerResponse, error) {
+	status := "success"
+	pid, err := peer.IDB58Decode(in.PeerId)
+	if err == nil {
+		err = adminRpcService.node.UnbanPeer(pid)
+	}
+	if err != nil {
+		status = err.Error()
+	}
+	return &rpcpb.UnbanPeerResponse{
+		Status: status,
+	}, nil
+}
+
+//RpcGetBannedPeers returns the banned peers and the time their bans end
+func (adminRpcService *AdminRpcService) RpcGetBannedPeers(ctx context.Context, in *rpcpb.GetBannedPeersRequest) (*rpcpb.GetBannedPeersResponse, error) {
+	bannedPeers := []*rpcpb.BannedPeer{}
+	for pid, until := range adminRpcService.node.GetBannedPeers() {
+		bannedPeers = append(bannedPeers, &rpcpb.BannedPeer{
+			PeerId: peer.IDB58Encode(pid),
+			Until:  until.Unix(),
+		})
+	}
+	return &rpcpb.GetBannedPeersResponse{
+		BannedPeers: bannedPeers,
+	}, nil
+}
@@ -18,4 +18,10 @@ const (
 
 	// GetBlocksCountOverflow get blocks count over the max num
 	GetBlocksCountOverflow uint32 = 5
+
+	// TransactionNotFound transaction not found in blockchain
+	TransactionNotFound uint32 = 6
+
+	// TransactionRejected transaction conflicts with or duplicates a transaction in the pool
+	TransactionRejected uint32 = 7
 )
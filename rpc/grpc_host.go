@@ -25,6 +25,7 @@ import (
 
 	"golang.org/x/net/context"
 	"github.com/dappley/go-dappley/rpc/pb"
+	"github.com/dappley/go-dappley/logic"
 	"github.com/dappley/go-dappley/network"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -43,10 +44,16 @@ type Server struct{
 	srv 		*grpc.Server
 	node 		*network.Node
 	password	string
+	faucet		*logic.Faucet
 }
 
 func NewGrpcServer(node *network.Node, adminPassword string) *Server{
-	return &Server{grpc.NewServer(),node,adminPassword}
+	return &Server{grpc.NewServer(),node,adminPassword,nil}
+}
+
+// EnableFaucet lets RpcAddBalance pay from the faucet. It must be called before Start.
+func (s *Server) EnableFaucet(faucet *logic.Faucet) {
+	s.faucet = faucet
 }
 
 func (s *Server) Start(port uint32) {
@@ -60,7 +67,7 @@ func (s *Server) Start(port uint32) {
 		}
 
 		srv := grpc.NewServer(grpc.UnaryInterceptor(s.AuthInterceptor))
-		rpcpb.RegisterRpcServiceServer(srv, &RpcService{s.node})
+		rpcpb.RegisterRpcServiceServer(srv, &RpcService{s.node, s.faucet})
 		rpcpb.RegisterAdminServiceServer(srv, &AdminRpcService{s.node})
 		if err := srv.Serve(lis); err != nil {
 			logger.Fatalf("failed to serve: %s", err)
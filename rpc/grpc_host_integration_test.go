@@ -34,6 +34,8 @@ import (
 	"github.com/dappley/go-dappley/client"
 	"github.com/dappley/go-dappley/logic"
 	"github.com/dappley/go-dappley/consensus"
+	"github.com/dappley/go-dappley/core"
+	"github.com/dappley/go-dappley/core/pb"
 	logger "github.com/sirupsen/logrus"
 	"strings"
 )
@@ -136,4 +138,145 @@ func TestRpcSend(t *testing.T) {
 	assert.Equal(t, common.NewAmount(7), receiverBalance)
 
 	client.RemoveWalletFile()
-}
\ No newline at end of file
+}
+
+func TestRpcGetTransactionProof(t *testing.T) {
+	store := storage.NewRamStorage()
+	defer store.Close()
+
+	bc := core.CreateBlockchain(core.NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj"), store, nil)
+	node := network.FakeNodeWithPidAndAddr(bc, "a", "b")
+
+	// Start a grpc server
+	server := NewGrpcServer(node, "temp")
+	server.Start(defaultRpcPort + 2) // use a different port as other integration tests
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := grpc.Dial(fmt.Sprint(":", defaultRpcPort+2), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	c := rpcpb.NewRpcServiceClient(conn)
+
+	genesis, err := bc.GetBlockByHeight(0)
+	assert.Nil(t, err)
+	tx := genesis.GetTransactions()[0]
+
+	response, err := c.RpcGetTransactionProof(context.Background(), &rpcpb.GetTransactionProofRequest{Txid: tx.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, OK, response.ErrorCode)
+	assert.Equal(t, genesis.GetHash(), core.Hash(response.BlockHeader.Hash))
+	assert.True(t, core.VerifyMerkleBranch(tx.Hash(), int(response.TxIndex), response.MerkleBranch, response.BlockHeader.MerkleRoot))
+
+	response, err = c.RpcGetTransactionProof(context.Background(), &rpcpb.GetTransactionProofRequest{Txid: []byte("unknown")})
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionNotFound, response.ErrorCode)
+}
+
+func TestRpcAddBalance(t *testing.T) {
+	store := storage.NewRamStorage()
+	defer store.Close()
+
+	faucet, err := logic.NewFaucet("bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e", common.NewAmount(0))
+	assert.Nil(t, err)
+	allocations := []core.GenesisAllocation{{faucet.GetAddress(), common.NewAmount(100)}}
+	bc := core.CreateBlockchainWithAllocations(core.NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj"), allocations, store, nil)
+	node := network.FakeNodeWithPidAndAddr(bc, "a", "b")
+
+	// Start a grpc server without faucet and one with faucet
+	server := NewGrpcServer(node, "temp")
+	server.Start(defaultRpcPort + 3) // use a different port as other integration tests
+	defer server.Stop()
+	faucetServer := NewGrpcServer(node, "temp")
+	faucetServer.EnableFaucet(faucet)
+	faucetServer.Start(defaultRpcPort + 4)
+	defer faucetServer.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	request := &rpcpb.AddBalanceRequest{Address: "1MeSBgufmzwpiJNLemUe1emxAussBnz7a7", Amount: common.NewAmount(15).Bytes()}
+
+	conn, err := grpc.Dial(fmt.Sprint(":", defaultRpcPort+3), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	response, err := rpcpb.NewRpcServiceClient(conn).RpcAddBalance(context.Background(), request)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(response.Message, "not enabled"))
+
+	faucetConn, err := grpc.Dial(fmt.Sprint(":", defaultRpcPort+4), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	defer faucetConn.Close()
+	response, err = rpcpb.NewRpcServiceClient(faucetConn).RpcAddBalance(context.Background(), request)
+	assert.Nil(t, err)
+	assert.Equal(t, "Add balance succeed!", response.Message)
+
+	// The faucet pays through a signed transaction spending its genesis allocation
+	var txs []core.Transaction
+	bc.GetTxPool().Traverse(func(tx core.Transaction) bool {
+		txs = append(txs, tx)
+		return true
+	})
+	if assert.Len(t, txs, 1) {
+		assert.True(t, txs[0].Verify(core.LoadUTXOIndex(store), 1, time.Now().Unix()))
+	}
+}
+
+func TestRpcSubscribeTransactions(t *testing.T) {
+	store := storage.NewRamStorage()
+	defer store.Close()
+
+	keyPair, err := core.NewKeyPairFromHex("bb23d2ff19f5b16955e8a24dca34dd520980fe3bddca2b3e1b56663f0ec1aa7e")
+	assert.Nil(t, err)
+	from := keyPair.GenerateAddress()
+	allocations := []core.GenesisAllocation{{from, common.NewAmount(100)}}
+	bc := core.CreateBlockchainWithAllocations(core.NewAddress("16PencPNnF8CiSx2EBGEd1axhf7vuHCouj"), allocations, store, nil)
+	node := network.FakeNodeWithPidAndAddr(bc, "a", "b")
+
+	server := NewGrpcServer(node, "temp")
+	server.Start(defaultRpcPort + 5) // use a different port as other integration tests
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := grpc.Dial(fmt.Sprint(":", defaultRpcPort+5), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	c := rpcpb.NewRpcServiceClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.RpcSubscribeTransactions(ctx, &rpcpb.SubscribeTransactionsRequest{})
+	assert.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// Transactions admitted to the pool are streamed to subscribers
+	tx, err := core.NewUTXOTransaction(store, from, core.NewAddress("1MeSBgufmzwpiJNLemUe1emxAussBnz7a7"), common.NewAmount(15), *keyPair, bc, 1)
+	assert.Nil(t, err)
+	sendResponse, err := c.RpcSendTransaction(context.Background(), &rpcpb.SendTransactionRequest{Transaction: tx.ToProto().(*corepb.Transaction)})
+	assert.Nil(t, err)
+	assert.Equal(t, OK, sendResponse.ErrorCode)
+
+	response, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, tx.ID, response.Transaction.ID)
+
+	// Rejected transactions are counted but not streamed
+	sendResponse, err = c.RpcSendTransaction(context.Background(), &rpcpb.SendTransactionRequest{Transaction: tx.ToProto().(*corepb.Transaction)})
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionRejected, sendResponse.ErrorCode)
+
+	stats, err := c.RpcGetTxPoolStats(context.Background(), &rpcpb.GetTxPoolStatsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), stats.Size)
+	assert.Equal(t, uint64(1), stats.Admitted)
+	assert.Equal(t, uint64(1), stats.Rejected)
+}
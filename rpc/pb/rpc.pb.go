@@ -435,6 +435,123 @@ func (m *AddPeerRequest) GetFullAddress() string {
 	return ""
 }
 
+type BanPeerRequest struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	Duration             int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanPeerRequest) Reset()         { *m = BanPeerRequest{} }
+func (m *BanPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*BanPeerRequest) ProtoMessage()    {}
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{9}
+}
+
+func (m *BanPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanPeerRequest.Unmarshal(m, b)
+}
+func (m *BanPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *BanPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanPeerRequest.Merge(m, src)
+}
+func (m *BanPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_BanPeerRequest.Size(m)
+}
+func (m *BanPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanPeerRequest proto.InternalMessageInfo
+
+func (m *BanPeerRequest) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *BanPeerRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type UnbanPeerRequest struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnbanPeerRequest) Reset()         { *m = UnbanPeerRequest{} }
+func (m *UnbanPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*UnbanPeerRequest) ProtoMessage()    {}
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{10}
+}
+
+func (m *UnbanPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanPeerRequest.Unmarshal(m, b)
+}
+func (m *UnbanPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *UnbanPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanPeerRequest.Merge(m, src)
+}
+func (m *UnbanPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_UnbanPeerRequest.Size(m)
+}
+func (m *UnbanPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanPeerRequest proto.InternalMessageInfo
+
+func (m *UnbanPeerRequest) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+type GetBannedPeersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBannedPeersRequest) Reset()         { *m = GetBannedPeersRequest{} }
+func (m *GetBannedPeersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBannedPeersRequest) ProtoMessage()    {}
+func (*GetBannedPeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{11}
+}
+
+func (m *GetBannedPeersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBannedPeersRequest.Unmarshal(m, b)
+}
+func (m *GetBannedPeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBannedPeersRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBannedPeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBannedPeersRequest.Merge(m, src)
+}
+func (m *GetBannedPeersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBannedPeersRequest.Size(m)
+}
+func (m *GetBannedPeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBannedPeersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBannedPeersRequest proto.InternalMessageInfo
+
 type CreateWalletResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -447,7 +564,7 @@ func (m *CreateWalletResponse) Reset()         { *m = CreateWalletResponse{} }
 func (m *CreateWalletResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateWalletResponse) ProtoMessage()    {}
 func (*CreateWalletResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{9}
+	return fileDescriptor_c6f7014334e4682f, []int{12}
 }
 
 func (m *CreateWalletResponse) XXX_Unmarshal(b []byte) error {
@@ -493,7 +610,7 @@ func (m *AddProducerResponse) Reset()         { *m = AddProducerResponse{} }
 func (m *AddProducerResponse) String() string { return proto.CompactTextString(m) }
 func (*AddProducerResponse) ProtoMessage()    {}
 func (*AddProducerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{10}
+	return fileDescriptor_c6f7014334e4682f, []int{13}
 }
 
 func (m *AddProducerResponse) XXX_Unmarshal(b []byte) error {
@@ -533,7 +650,7 @@ func (m *GetBalanceResponse) Reset()         { *m = GetBalanceResponse{} }
 func (m *GetBalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBalanceResponse) ProtoMessage()    {}
 func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{11}
+	return fileDescriptor_c6f7014334e4682f, []int{14}
 }
 
 func (m *GetBalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -579,7 +696,7 @@ func (m *AddBalanceResponse) Reset()         { *m = AddBalanceResponse{} }
 func (m *AddBalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*AddBalanceResponse) ProtoMessage()    {}
 func (*AddBalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{12}
+	return fileDescriptor_c6f7014334e4682f, []int{15}
 }
 
 func (m *AddBalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -618,7 +735,7 @@ func (m *SendResponse) Reset()         { *m = SendResponse{} }
 func (m *SendResponse) String() string { return proto.CompactTextString(m) }
 func (*SendResponse) ProtoMessage()    {}
 func (*SendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{13}
+	return fileDescriptor_c6f7014334e4682f, []int{16}
 }
 
 func (m *SendResponse) XXX_Unmarshal(b []byte) error {
@@ -657,7 +774,7 @@ func (m *GetPeerInfoResponse) Reset()         { *m = GetPeerInfoResponse{} }
 func (m *GetPeerInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPeerInfoResponse) ProtoMessage()    {}
 func (*GetPeerInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{14}
+	return fileDescriptor_c6f7014334e4682f, []int{17}
 }
 
 func (m *GetPeerInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -698,7 +815,7 @@ func (m *GetBlockchainInfoResponse) Reset()         { *m = GetBlockchainInfoResp
 func (m *GetBlockchainInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockchainInfoResponse) ProtoMessage()    {}
 func (*GetBlockchainInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{15}
+	return fileDescriptor_c6f7014334e4682f, []int{18}
 }
 
 func (m *GetBlockchainInfoResponse) XXX_Unmarshal(b []byte) error {
@@ -751,7 +868,7 @@ func (m *AddPeerResponse) Reset()         { *m = AddPeerResponse{} }
 func (m *AddPeerResponse) String() string { return proto.CompactTextString(m) }
 func (*AddPeerResponse) ProtoMessage()    {}
 func (*AddPeerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{16}
+	return fileDescriptor_c6f7014334e4682f, []int{19}
 }
 
 func (m *AddPeerResponse) XXX_Unmarshal(b []byte) error {
@@ -779,6 +896,170 @@ func (m *AddPeerResponse) GetStatus() string {
 	return ""
 }
 
+type BanPeerResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanPeerResponse) Reset()         { *m = BanPeerResponse{} }
+func (m *BanPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*BanPeerResponse) ProtoMessage()    {}
+func (*BanPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{20}
+}
+
+func (m *BanPeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanPeerResponse.Unmarshal(m, b)
+}
+func (m *BanPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanPeerResponse.Marshal(b, m, deterministic)
+}
+func (m *BanPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanPeerResponse.Merge(m, src)
+}
+func (m *BanPeerResponse) XXX_Size() int {
+	return xxx_messageInfo_BanPeerResponse.Size(m)
+}
+func (m *BanPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanPeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanPeerResponse proto.InternalMessageInfo
+
+func (m *BanPeerResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type UnbanPeerResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnbanPeerResponse) Reset()         { *m = UnbanPeerResponse{} }
+func (m *UnbanPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*UnbanPeerResponse) ProtoMessage()    {}
+func (*UnbanPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{21}
+}
+
+func (m *UnbanPeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanPeerResponse.Unmarshal(m, b)
+}
+func (m *UnbanPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanPeerResponse.Marshal(b, m, deterministic)
+}
+func (m *UnbanPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanPeerResponse.Merge(m, src)
+}
+func (m *UnbanPeerResponse) XXX_Size() int {
+	return xxx_messageInfo_UnbanPeerResponse.Size(m)
+}
+func (m *UnbanPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanPeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanPeerResponse proto.InternalMessageInfo
+
+func (m *UnbanPeerResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type BannedPeer struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BannedPeer) Reset()         { *m = BannedPeer{} }
+func (m *BannedPeer) String() string { return proto.CompactTextString(m) }
+func (*BannedPeer) ProtoMessage()    {}
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{22}
+}
+
+func (m *BannedPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BannedPeer.Unmarshal(m, b)
+}
+func (m *BannedPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BannedPeer.Marshal(b, m, deterministic)
+}
+func (m *BannedPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BannedPeer.Merge(m, src)
+}
+func (m *BannedPeer) XXX_Size() int {
+	return xxx_messageInfo_BannedPeer.Size(m)
+}
+func (m *BannedPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BannedPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BannedPeer proto.InternalMessageInfo
+
+func (m *BannedPeer) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type GetBannedPeersResponse struct {
+	BannedPeers          []*BannedPeer `protobuf:"bytes,1,rep,name=bannedPeers,proto3" json:"bannedPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetBannedPeersResponse) Reset()         { *m = GetBannedPeersResponse{} }
+func (m *GetBannedPeersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBannedPeersResponse) ProtoMessage()    {}
+func (*GetBannedPeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{23}
+}
+
+func (m *GetBannedPeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBannedPeersResponse.Unmarshal(m, b)
+}
+func (m *GetBannedPeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBannedPeersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBannedPeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBannedPeersResponse.Merge(m, src)
+}
+func (m *GetBannedPeersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBannedPeersResponse.Size(m)
+}
+func (m *GetBannedPeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBannedPeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBannedPeersResponse proto.InternalMessageInfo
+
+func (m *GetBannedPeersResponse) GetBannedPeers() []*BannedPeer {
+	if m != nil {
+		return m.BannedPeers
+	}
+	return nil
+}
+
 type GetWalletAddressResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	Address              []string `protobuf:"bytes,2,rep,name=address,proto3" json:"address,omitempty"`
@@ -792,7 +1073,7 @@ func (m *GetWalletAddressResponse) Reset()         { *m = GetWalletAddressRespon
 func (m *GetWalletAddressResponse) String() string { return proto.CompactTextString(m) }
 func (*GetWalletAddressResponse) ProtoMessage()    {}
 func (*GetWalletAddressResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{17}
+	return fileDescriptor_c6f7014334e4682f, []int{24}
 }
 
 func (m *GetWalletAddressResponse) XXX_Unmarshal(b []byte) error {
@@ -845,7 +1126,7 @@ func (m *GetVersionRequest) Reset()         { *m = GetVersionRequest{} }
 func (m *GetVersionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetVersionRequest) ProtoMessage()    {}
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{18}
+	return fileDescriptor_c6f7014334e4682f, []int{25}
 }
 
 func (m *GetVersionRequest) XXX_Unmarshal(b []byte) error {
@@ -886,7 +1167,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{19}
+	return fileDescriptor_c6f7014334e4682f, []int{26}
 }
 
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
@@ -939,7 +1220,7 @@ func (m *GetUTXORequest) Reset()         { *m = GetUTXORequest{} }
 func (m *GetUTXORequest) String() string { return proto.CompactTextString(m) }
 func (*GetUTXORequest) ProtoMessage()    {}
 func (*GetUTXORequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{20}
+	return fileDescriptor_c6f7014334e4682f, []int{27}
 }
 
 func (m *GetUTXORequest) XXX_Unmarshal(b []byte) error {
@@ -980,7 +1261,7 @@ func (m *GetUTXOResponse) Reset()         { *m = GetUTXOResponse{} }
 func (m *GetUTXOResponse) String() string { return proto.CompactTextString(m) }
 func (*GetUTXOResponse) ProtoMessage()    {}
 func (*GetUTXOResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{21}
+	return fileDescriptor_c6f7014334e4682f, []int{28}
 }
 
 func (m *GetUTXOResponse) XXX_Unmarshal(b []byte) error {
@@ -1036,7 +1317,7 @@ func (m *UTXO) Reset()         { *m = UTXO{} }
 func (m *UTXO) String() string { return proto.CompactTextString(m) }
 func (*UTXO) ProtoMessage()    {}
 func (*UTXO) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{22}
+	return fileDescriptor_c6f7014334e4682f, []int{29}
 }
 
 func (m *UTXO) XXX_Unmarshal(b []byte) error {
@@ -1097,7 +1378,7 @@ func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
 func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlocksRequest) ProtoMessage()    {}
 func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{23}
+	return fileDescriptor_c6f7014334e4682f, []int{30}
 }
 
 func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
@@ -1144,7 +1425,7 @@ func (m *GetBlocksResponse) Reset()         { *m = GetBlocksResponse{} }
 func (m *GetBlocksResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlocksResponse) ProtoMessage()    {}
 func (*GetBlocksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{24}
+	return fileDescriptor_c6f7014334e4682f, []int{31}
 }
 
 func (m *GetBlocksResponse) XXX_Unmarshal(b []byte) error {
@@ -1190,7 +1471,7 @@ func (m *GetBlockByHashRequest) Reset()         { *m = GetBlockByHashRequest{} }
 func (m *GetBlockByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHashRequest) ProtoMessage()    {}
 func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{25}
+	return fileDescriptor_c6f7014334e4682f, []int{32}
 }
 
 func (m *GetBlockByHashRequest) XXX_Unmarshal(b []byte) error {
@@ -1230,7 +1511,7 @@ func (m *GetBlockByHashResponse) Reset()         { *m = GetBlockByHashResponse{}
 func (m *GetBlockByHashResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHashResponse) ProtoMessage()    {}
 func (*GetBlockByHashResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{26}
+	return fileDescriptor_c6f7014334e4682f, []int{33}
 }
 
 func (m *GetBlockByHashResponse) XXX_Unmarshal(b []byte) error {
@@ -1276,7 +1557,7 @@ func (m *GetBlockByHeightRequest) Reset()         { *m = GetBlockByHeightRequest
 func (m *GetBlockByHeightRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHeightRequest) ProtoMessage()    {}
 func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{27}
+	return fileDescriptor_c6f7014334e4682f, []int{34}
 }
 
 func (m *GetBlockByHeightRequest) XXX_Unmarshal(b []byte) error {
@@ -1316,7 +1597,7 @@ func (m *GetBlockByHeightResponse) Reset()         { *m = GetBlockByHeightRespon
 func (m *GetBlockByHeightResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockByHeightResponse) ProtoMessage()    {}
 func (*GetBlockByHeightResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{28}
+	return fileDescriptor_c6f7014334e4682f, []int{35}
 }
 
 func (m *GetBlockByHeightResponse) XXX_Unmarshal(b []byte) error {
@@ -1362,7 +1643,7 @@ func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{}
 func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionRequest) ProtoMessage()    {}
 func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{29}
+	return fileDescriptor_c6f7014334e4682f, []int{36}
 }
 
 func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -1401,7 +1682,7 @@ func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse
 func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionResponse) ProtoMessage()    {}
 func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c6f7014334e4682f, []int{30}
+	return fileDescriptor_c6f7014334e4682f, []int{37}
 }
 
 func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
@@ -1429,80 +1710,612 @@ func (m *SendTransactionResponse) GetErrorCode() uint32 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*CreateWalletRequest)(nil), "rpcpb.CreateWalletRequest")
-	proto.RegisterType((*AddProducerRequest)(nil), "rpcpb.AddProducerRequest")
-	proto.RegisterType((*GetBalanceRequest)(nil), "rpcpb.GetBalanceRequest")
-	proto.RegisterType((*AddBalanceRequest)(nil), "rpcpb.AddBalanceRequest")
-	proto.RegisterType((*SendRequest)(nil), "rpcpb.SendRequest")
-	proto.RegisterType((*GetWalletAddressRequest)(nil), "rpcpb.GetWalletAddressRequest")
-	proto.RegisterType((*GetPeerInfoRequest)(nil), "rpcpb.GetPeerInfoRequest")
-	proto.RegisterType((*GetBlockchainInfoRequest)(nil), "rpcpb.GetBlockchainInfoRequest")
-	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
-	proto.RegisterType((*CreateWalletResponse)(nil), "rpcpb.CreateWalletResponse")
-	proto.RegisterType((*AddProducerResponse)(nil), "rpcpb.AddProducerResponse")
-	proto.RegisterType((*GetBalanceResponse)(nil), "rpcpb.GetBalanceResponse")
-	proto.RegisterType((*AddBalanceResponse)(nil), "rpcpb.AddBalanceResponse")
-	proto.RegisterType((*SendResponse)(nil), "rpcpb.SendResponse")
-	proto.RegisterType((*GetPeerInfoResponse)(nil), "rpcpb.GetPeerInfoResponse")
-	proto.RegisterType((*GetBlockchainInfoResponse)(nil), "rpcpb.GetBlockchainInfoResponse")
-	proto.RegisterType((*AddPeerResponse)(nil), "rpcpb.AddPeerResponse")
-	proto.RegisterType((*GetWalletAddressResponse)(nil), "rpcpb.GetWalletAddressResponse")
-	proto.RegisterType((*GetVersionRequest)(nil), "rpcpb.GetVersionRequest")
-	proto.RegisterType((*GetVersionResponse)(nil), "rpcpb.GetVersionResponse")
-	proto.RegisterType((*GetUTXORequest)(nil), "rpcpb.GetUTXORequest")
-	proto.RegisterType((*GetUTXOResponse)(nil), "rpcpb.GetUTXOResponse")
-	proto.RegisterType((*UTXO)(nil), "rpcpb.UTXO")
-	proto.RegisterType((*GetBlocksRequest)(nil), "rpcpb.GetBlocksRequest")
-	proto.RegisterType((*GetBlocksResponse)(nil), "rpcpb.GetBlocksResponse")
-	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
-	proto.RegisterType((*GetBlockByHashResponse)(nil), "rpcpb.GetBlockByHashResponse")
-	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
-	proto.RegisterType((*GetBlockByHeightResponse)(nil), "rpcpb.GetBlockByHeightResponse")
-	proto.RegisterType((*SendTransactionRequest)(nil), "rpcpb.SendTransactionRequest")
-	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+type GetTransactionProofRequest struct {
+	Txid                 []byte   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// RpcServiceClient is the client API for RpcService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RpcServiceClient interface {
-	RpcGetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
-	RpcCreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error)
-	RpcAddProducer(ctx context.Context, in *AddProducerRequest, opts ...grpc.CallOption) (*AddProducerResponse, error)
-	RpcGetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
-	RpcAddBalance(ctx context.Context, in *AddBalanceRequest, opts ...grpc.CallOption) (*AddBalanceResponse, error)
-	RpcGetWalletAddress(ctx context.Context, in *GetWalletAddressRequest, opts ...grpc.CallOption) (*GetWalletAddressResponse, error)
-	RpcSend(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
-	RpcGetPeerInfo(ctx context.Context, in *GetPeerInfoRequest, opts ...grpc.CallOption) (*GetPeerInfoResponse, error)
-	RpcGetBlockchainInfo(ctx context.Context, in *GetBlockchainInfoRequest, opts ...grpc.CallOption) (*GetBlockchainInfoResponse, error)
-	RpcGetUTXO(ctx context.Context, in *GetUTXORequest, opts ...grpc.CallOption) (*GetUTXOResponse, error)
-	RpcGetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error)
-	RpcGetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*GetBlockByHashResponse, error)
-	RpcGetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*GetBlockByHeightResponse, error)
-	RpcSendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+func (m *GetTransactionProofRequest) Reset()         { *m = GetTransactionProofRequest{} }
+func (m *GetTransactionProofRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionProofRequest) ProtoMessage()    {}
+func (*GetTransactionProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{38}
 }
 
-type rpcServiceClient struct {
-	cc *grpc.ClientConn
+func (m *GetTransactionProofRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionProofRequest.Unmarshal(m, b)
 }
-
-func NewRpcServiceClient(cc *grpc.ClientConn) RpcServiceClient {
-	return &rpcServiceClient{cc}
+func (m *GetTransactionProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionProofRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionProofRequest.Merge(m, src)
+}
+func (m *GetTransactionProofRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionProofRequest.Size(m)
+}
+func (m *GetTransactionProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionProofRequest.DiscardUnknown(m)
 }
 
-func (c *rpcServiceClient) RpcGetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
-	out := new(GetVersionResponse)
-	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetVersion", in, out, opts...)
-	if err != nil {
+var xxx_messageInfo_GetTransactionProofRequest proto.InternalMessageInfo
+
+func (m *GetTransactionProofRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+type GetTransactionProofResponse struct {
+	ErrorCode            uint32           `protobuf:"varint,1,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	BlockHeader          *pb1.BlockHeader `protobuf:"bytes,2,opt,name=blockHeader,proto3" json:"blockHeader,omitempty"`
+	TxIndex              uint32           `protobuf:"varint,3,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	MerkleBranch         [][]byte         `protobuf:"bytes,4,rep,name=merkleBranch,proto3" json:"merkleBranch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetTransactionProofResponse) Reset()         { *m = GetTransactionProofResponse{} }
+func (m *GetTransactionProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionProofResponse) ProtoMessage()    {}
+func (*GetTransactionProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{39}
+}
+
+func (m *GetTransactionProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionProofResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionProofResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionProofResponse.Merge(m, src)
+}
+func (m *GetTransactionProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionProofResponse.Size(m)
+}
+func (m *GetTransactionProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionProofResponse proto.InternalMessageInfo
+
+func (m *GetTransactionProofResponse) GetErrorCode() uint32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *GetTransactionProofResponse) GetBlockHeader() *pb1.BlockHeader {
+	if m != nil {
+		return m.BlockHeader
+	}
+	return nil
+}
+
+func (m *GetTransactionProofResponse) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *GetTransactionProofResponse) GetMerkleBranch() [][]byte {
+	if m != nil {
+		return m.MerkleBranch
+	}
+	return nil
+}
+
+type SubscribeTransactionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeTransactionsRequest) Reset()         { *m = SubscribeTransactionsRequest{} }
+func (m *SubscribeTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTransactionsRequest) ProtoMessage()    {}
+func (*SubscribeTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{40}
+}
+
+func (m *SubscribeTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeTransactionsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTransactionsRequest.Merge(m, src)
+}
+func (m *SubscribeTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeTransactionsRequest.Size(m)
+}
+func (m *SubscribeTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTransactionsRequest proto.InternalMessageInfo
+
+type SubscribeTransactionsResponse struct {
+	Transaction          *pb1.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SubscribeTransactionsResponse) Reset()         { *m = SubscribeTransactionsResponse{} }
+func (m *SubscribeTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTransactionsResponse) ProtoMessage()    {}
+func (*SubscribeTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{41}
+}
+
+func (m *SubscribeTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeTransactionsResponse.Unmarshal(m, b)
+}
+func (m *SubscribeTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTransactionsResponse.Merge(m, src)
+}
+func (m *SubscribeTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeTransactionsResponse.Size(m)
+}
+func (m *SubscribeTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTransactionsResponse proto.InternalMessageInfo
+
+func (m *SubscribeTransactionsResponse) GetTransaction() *pb1.Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type GetTxPoolStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxPoolStatsRequest) Reset()         { *m = GetTxPoolStatsRequest{} }
+func (m *GetTxPoolStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxPoolStatsRequest) ProtoMessage()    {}
+func (*GetTxPoolStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{42}
+}
+
+func (m *GetTxPoolStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxPoolStatsRequest.Unmarshal(m, b)
+}
+func (m *GetTxPoolStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxPoolStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxPoolStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxPoolStatsRequest.Merge(m, src)
+}
+func (m *GetTxPoolStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxPoolStatsRequest.Size(m)
+}
+func (m *GetTxPoolStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxPoolStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxPoolStatsRequest proto.InternalMessageInfo
+
+type GetTxPoolStatsResponse struct {
+	Size                 uint32   `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Admitted             uint64   `protobuf:"varint,2,opt,name=admitted,proto3" json:"admitted,omitempty"`
+	Rejected             uint64   `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Expired              uint64   `protobuf:"varint,4,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxPoolStatsResponse) Reset()         { *m = GetTxPoolStatsResponse{} }
+func (m *GetTxPoolStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxPoolStatsResponse) ProtoMessage()    {}
+func (*GetTxPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{43}
+}
+
+func (m *GetTxPoolStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxPoolStatsResponse.Unmarshal(m, b)
+}
+func (m *GetTxPoolStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxPoolStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxPoolStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxPoolStatsResponse.Merge(m, src)
+}
+func (m *GetTxPoolStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxPoolStatsResponse.Size(m)
+}
+func (m *GetTxPoolStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxPoolStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxPoolStatsResponse proto.InternalMessageInfo
+
+func (m *GetTxPoolStatsResponse) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *GetTxPoolStatsResponse) GetAdmitted() uint64 {
+	if m != nil {
+		return m.Admitted
+	}
+	return 0
+}
+
+func (m *GetTxPoolStatsResponse) GetRejected() uint64 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
+func (m *GetTxPoolStatsResponse) GetExpired() uint64 {
+	if m != nil {
+		return m.Expired
+	}
+	return 0
+}
+
+type GetGossipStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGossipStatsRequest) Reset()         { *m = GetGossipStatsRequest{} }
+func (m *GetGossipStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGossipStatsRequest) ProtoMessage()    {}
+func (*GetGossipStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{44}
+}
+
+func (m *GetGossipStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGossipStatsRequest.Unmarshal(m, b)
+}
+func (m *GetGossipStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGossipStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGossipStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGossipStatsRequest.Merge(m, src)
+}
+func (m *GetGossipStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGossipStatsRequest.Size(m)
+}
+func (m *GetGossipStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGossipStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGossipStatsRequest proto.InternalMessageInfo
+
+type GetGossipStatsResponse struct {
+	Stats                []*GossipStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetGossipStatsResponse) Reset()         { *m = GetGossipStatsResponse{} }
+func (m *GetGossipStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGossipStatsResponse) ProtoMessage()    {}
+func (*GetGossipStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{45}
+}
+
+func (m *GetGossipStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGossipStatsResponse.Unmarshal(m, b)
+}
+func (m *GetGossipStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGossipStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGossipStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGossipStatsResponse.Merge(m, src)
+}
+func (m *GetGossipStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGossipStatsResponse.Size(m)
+}
+func (m *GetGossipStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGossipStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGossipStatsResponse proto.InternalMessageInfo
+
+func (m *GetGossipStatsResponse) GetStats() []*GossipStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type GossipStats struct {
+	Command              string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Hits                 uint64   `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses               uint64   `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Size                 uint32   `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GossipStats) Reset()         { *m = GossipStats{} }
+func (m *GossipStats) String() string { return proto.CompactTextString(m) }
+func (*GossipStats) ProtoMessage()    {}
+func (*GossipStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{46}
+}
+
+func (m *GossipStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipStats.Unmarshal(m, b)
+}
+func (m *GossipStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipStats.Marshal(b, m, deterministic)
+}
+func (m *GossipStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipStats.Merge(m, src)
+}
+func (m *GossipStats) XXX_Size() int {
+	return xxx_messageInfo_GossipStats.Size(m)
+}
+func (m *GossipStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipStats proto.InternalMessageInfo
+
+func (m *GossipStats) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *GossipStats) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *GossipStats) GetMisses() uint64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *GossipStats) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type GetSyncProgressRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSyncProgressRequest) Reset()         { *m = GetSyncProgressRequest{} }
+func (m *GetSyncProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSyncProgressRequest) ProtoMessage()    {}
+func (*GetSyncProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{47}
+}
+
+func (m *GetSyncProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSyncProgressRequest.Unmarshal(m, b)
+}
+func (m *GetSyncProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSyncProgressRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSyncProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSyncProgressRequest.Merge(m, src)
+}
+func (m *GetSyncProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSyncProgressRequest.Size(m)
+}
+func (m *GetSyncProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSyncProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSyncProgressRequest proto.InternalMessageInfo
+
+type GetSyncProgressResponse struct {
+	Syncing              bool     `protobuf:"varint,1,opt,name=syncing,proto3" json:"syncing,omitempty"`
+	StartHeight          uint64   `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	CurrentHeight        uint64   `protobuf:"varint,3,opt,name=currentHeight,proto3" json:"currentHeight,omitempty"`
+	HeaderHeight         uint64   `protobuf:"varint,4,opt,name=headerHeight,proto3" json:"headerHeight,omitempty"`
+	TargetHeight         uint64   `protobuf:"varint,5,opt,name=targetHeight,proto3" json:"targetHeight,omitempty"`
+	Peers                uint32   `protobuf:"varint,6,opt,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSyncProgressResponse) Reset()         { *m = GetSyncProgressResponse{} }
+func (m *GetSyncProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSyncProgressResponse) ProtoMessage()    {}
+func (*GetSyncProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6f7014334e4682f, []int{48}
+}
+
+func (m *GetSyncProgressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSyncProgressResponse.Unmarshal(m, b)
+}
+func (m *GetSyncProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSyncProgressResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSyncProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSyncProgressResponse.Merge(m, src)
+}
+func (m *GetSyncProgressResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSyncProgressResponse.Size(m)
+}
+func (m *GetSyncProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSyncProgressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSyncProgressResponse proto.InternalMessageInfo
+
+func (m *GetSyncProgressResponse) GetSyncing() bool {
+	if m != nil {
+		return m.Syncing
+	}
+	return false
+}
+
+func (m *GetSyncProgressResponse) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetSyncProgressResponse) GetCurrentHeight() uint64 {
+	if m != nil {
+		return m.CurrentHeight
+	}
+	return 0
+}
+
+func (m *GetSyncProgressResponse) GetHeaderHeight() uint64 {
+	if m != nil {
+		return m.HeaderHeight
+	}
+	return 0
+}
+
+func (m *GetSyncProgressResponse) GetTargetHeight() uint64 {
+	if m != nil {
+		return m.TargetHeight
+	}
+	return 0
+}
+
+func (m *GetSyncProgressResponse) GetPeers() uint32 {
+	if m != nil {
+		return m.Peers
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CreateWalletRequest)(nil), "rpcpb.CreateWalletRequest")
+	proto.RegisterType((*AddProducerRequest)(nil), "rpcpb.AddProducerRequest")
+	proto.RegisterType((*GetBalanceRequest)(nil), "rpcpb.GetBalanceRequest")
+	proto.RegisterType((*AddBalanceRequest)(nil), "rpcpb.AddBalanceRequest")
+	proto.RegisterType((*SendRequest)(nil), "rpcpb.SendRequest")
+	proto.RegisterType((*GetWalletAddressRequest)(nil), "rpcpb.GetWalletAddressRequest")
+	proto.RegisterType((*GetPeerInfoRequest)(nil), "rpcpb.GetPeerInfoRequest")
+	proto.RegisterType((*GetBlockchainInfoRequest)(nil), "rpcpb.GetBlockchainInfoRequest")
+	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
+	proto.RegisterType((*BanPeerRequest)(nil), "rpcpb.BanPeerRequest")
+	proto.RegisterType((*UnbanPeerRequest)(nil), "rpcpb.UnbanPeerRequest")
+	proto.RegisterType((*GetBannedPeersRequest)(nil), "rpcpb.GetBannedPeersRequest")
+	proto.RegisterType((*CreateWalletResponse)(nil), "rpcpb.CreateWalletResponse")
+	proto.RegisterType((*AddProducerResponse)(nil), "rpcpb.AddProducerResponse")
+	proto.RegisterType((*GetBalanceResponse)(nil), "rpcpb.GetBalanceResponse")
+	proto.RegisterType((*AddBalanceResponse)(nil), "rpcpb.AddBalanceResponse")
+	proto.RegisterType((*SendResponse)(nil), "rpcpb.SendResponse")
+	proto.RegisterType((*GetPeerInfoResponse)(nil), "rpcpb.GetPeerInfoResponse")
+	proto.RegisterType((*GetBlockchainInfoResponse)(nil), "rpcpb.GetBlockchainInfoResponse")
+	proto.RegisterType((*AddPeerResponse)(nil), "rpcpb.AddPeerResponse")
+	proto.RegisterType((*BanPeerResponse)(nil), "rpcpb.BanPeerResponse")
+	proto.RegisterType((*UnbanPeerResponse)(nil), "rpcpb.UnbanPeerResponse")
+	proto.RegisterType((*BannedPeer)(nil), "rpcpb.BannedPeer")
+	proto.RegisterType((*GetBannedPeersResponse)(nil), "rpcpb.GetBannedPeersResponse")
+	proto.RegisterType((*GetWalletAddressResponse)(nil), "rpcpb.GetWalletAddressResponse")
+	proto.RegisterType((*GetVersionRequest)(nil), "rpcpb.GetVersionRequest")
+	proto.RegisterType((*GetVersionResponse)(nil), "rpcpb.GetVersionResponse")
+	proto.RegisterType((*GetUTXORequest)(nil), "rpcpb.GetUTXORequest")
+	proto.RegisterType((*GetUTXOResponse)(nil), "rpcpb.GetUTXOResponse")
+	proto.RegisterType((*UTXO)(nil), "rpcpb.UTXO")
+	proto.RegisterType((*GetBlocksRequest)(nil), "rpcpb.GetBlocksRequest")
+	proto.RegisterType((*GetBlocksResponse)(nil), "rpcpb.GetBlocksResponse")
+	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
+	proto.RegisterType((*GetBlockByHashResponse)(nil), "rpcpb.GetBlockByHashResponse")
+	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
+	proto.RegisterType((*GetBlockByHeightResponse)(nil), "rpcpb.GetBlockByHeightResponse")
+	proto.RegisterType((*SendTransactionRequest)(nil), "rpcpb.SendTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+	proto.RegisterType((*GetTransactionProofRequest)(nil), "rpcpb.GetTransactionProofRequest")
+	proto.RegisterType((*GetTransactionProofResponse)(nil), "rpcpb.GetTransactionProofResponse")
+	proto.RegisterType((*SubscribeTransactionsRequest)(nil), "rpcpb.SubscribeTransactionsRequest")
+	proto.RegisterType((*SubscribeTransactionsResponse)(nil), "rpcpb.SubscribeTransactionsResponse")
+	proto.RegisterType((*GetTxPoolStatsRequest)(nil), "rpcpb.GetTxPoolStatsRequest")
+	proto.RegisterType((*GetTxPoolStatsResponse)(nil), "rpcpb.GetTxPoolStatsResponse")
+	proto.RegisterType((*GetGossipStatsRequest)(nil), "rpcpb.GetGossipStatsRequest")
+	proto.RegisterType((*GetGossipStatsResponse)(nil), "rpcpb.GetGossipStatsResponse")
+	proto.RegisterType((*GossipStats)(nil), "rpcpb.GossipStats")
+	proto.RegisterType((*GetSyncProgressRequest)(nil), "rpcpb.GetSyncProgressRequest")
+	proto.RegisterType((*GetSyncProgressResponse)(nil), "rpcpb.GetSyncProgressResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RpcServiceClient is the client API for RpcService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RpcServiceClient interface {
+	RpcGetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	RpcCreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error)
+	RpcAddProducer(ctx context.Context, in *AddProducerRequest, opts ...grpc.CallOption) (*AddProducerResponse, error)
+	RpcGetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	RpcAddBalance(ctx context.Context, in *AddBalanceRequest, opts ...grpc.CallOption) (*AddBalanceResponse, error)
+	RpcGetWalletAddress(ctx context.Context, in *GetWalletAddressRequest, opts ...grpc.CallOption) (*GetWalletAddressResponse, error)
+	RpcSend(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	RpcGetPeerInfo(ctx context.Context, in *GetPeerInfoRequest, opts ...grpc.CallOption) (*GetPeerInfoResponse, error)
+	RpcGetBlockchainInfo(ctx context.Context, in *GetBlockchainInfoRequest, opts ...grpc.CallOption) (*GetBlockchainInfoResponse, error)
+	RpcGetUTXO(ctx context.Context, in *GetUTXORequest, opts ...grpc.CallOption) (*GetUTXOResponse, error)
+	RpcGetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (*GetBlocksResponse, error)
+	RpcGetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*GetBlockByHashResponse, error)
+	RpcGetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*GetBlockByHeightResponse, error)
+	RpcSendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	RpcGetTransactionProof(ctx context.Context, in *GetTransactionProofRequest, opts ...grpc.CallOption) (*GetTransactionProofResponse, error)
+	RpcGetTxPoolStats(ctx context.Context, in *GetTxPoolStatsRequest, opts ...grpc.CallOption) (*GetTxPoolStatsResponse, error)
+	RpcSubscribeTransactions(ctx context.Context, in *SubscribeTransactionsRequest, opts ...grpc.CallOption) (RpcService_RpcSubscribeTransactionsClient, error)
+	RpcGetSyncProgress(ctx context.Context, in *GetSyncProgressRequest, opts ...grpc.CallOption) (*GetSyncProgressResponse, error)
+	RpcGetGossipStats(ctx context.Context, in *GetGossipStatsRequest, opts ...grpc.CallOption) (*GetGossipStatsResponse, error)
+}
+
+type rpcServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRpcServiceClient(cc *grpc.ClientConn) RpcServiceClient {
+	return &rpcServiceClient{cc}
+}
+
+func (c *rpcServiceClient) RpcGetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetVersion", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
 	return out, nil
@@ -1625,6 +2438,74 @@ func (c *rpcServiceClient) RpcSendTransaction(ctx context.Context, in *SendTrans
 	return out, nil
 }
 
+func (c *rpcServiceClient) RpcGetTransactionProof(ctx context.Context, in *GetTransactionProofRequest, opts ...grpc.CallOption) (*GetTransactionProofResponse, error) {
+	out := new(GetTransactionProofResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetTransactionProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcServiceClient) RpcGetTxPoolStats(ctx context.Context, in *GetTxPoolStatsRequest, opts ...grpc.CallOption) (*GetTxPoolStatsResponse, error) {
+	out := new(GetTxPoolStatsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetTxPoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcServiceClient) RpcSubscribeTransactions(ctx context.Context, in *SubscribeTransactionsRequest, opts ...grpc.CallOption) (RpcService_RpcSubscribeTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RpcService_serviceDesc.Streams[0], "/rpcpb.RpcService/RpcSubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rpcServiceRpcSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RpcService_RpcSubscribeTransactionsClient interface {
+	Recv() (*SubscribeTransactionsResponse, error)
+	grpc.ClientStream
+}
+
+type rpcServiceRpcSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rpcServiceRpcSubscribeTransactionsClient) Recv() (*SubscribeTransactionsResponse, error) {
+	m := new(SubscribeTransactionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rpcServiceClient) RpcGetSyncProgress(ctx context.Context, in *GetSyncProgressRequest, opts ...grpc.CallOption) (*GetSyncProgressResponse, error) {
+	out := new(GetSyncProgressResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetSyncProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcServiceClient) RpcGetGossipStats(ctx context.Context, in *GetGossipStatsRequest, opts ...grpc.CallOption) (*GetGossipStatsResponse, error) {
+	out := new(GetGossipStatsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.RpcService/RpcGetGossipStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RpcServiceServer is the server API for RpcService service.
 type RpcServiceServer interface {
 	RpcGetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
@@ -1641,6 +2522,11 @@ type RpcServiceServer interface {
 	RpcGetBlockByHash(context.Context, *GetBlockByHashRequest) (*GetBlockByHashResponse, error)
 	RpcGetBlockByHeight(context.Context, *GetBlockByHeightRequest) (*GetBlockByHeightResponse, error)
 	RpcSendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	RpcGetTransactionProof(context.Context, *GetTransactionProofRequest) (*GetTransactionProofResponse, error)
+	RpcGetTxPoolStats(context.Context, *GetTxPoolStatsRequest) (*GetTxPoolStatsResponse, error)
+	RpcSubscribeTransactions(*SubscribeTransactionsRequest, RpcService_RpcSubscribeTransactionsServer) error
+	RpcGetSyncProgress(context.Context, *GetSyncProgressRequest) (*GetSyncProgressResponse, error)
+	RpcGetGossipStats(context.Context, *GetGossipStatsRequest) (*GetGossipStatsResponse, error)
 }
 
 func RegisterRpcServiceServer(s *grpc.Server, srv RpcServiceServer) {
@@ -1899,6 +2785,99 @@ func _RpcService_RpcSendTransaction_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RpcService_RpcGetTransactionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServiceServer).RpcGetTransactionProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.RpcService/RpcGetTransactionProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServiceServer).RpcGetTransactionProof(ctx, req.(*GetTransactionProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcService_RpcGetTxPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServiceServer).RpcGetTxPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.RpcService/RpcGetTxPoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServiceServer).RpcGetTxPoolStats(ctx, req.(*GetTxPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcService_RpcSubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RpcServiceServer).RpcSubscribeTransactions(m, &rpcServiceRpcSubscribeTransactionsServer{stream})
+}
+
+type RpcService_RpcSubscribeTransactionsServer interface {
+	Send(*SubscribeTransactionsResponse) error
+	grpc.ServerStream
+}
+
+type rpcServiceRpcSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rpcServiceRpcSubscribeTransactionsServer) Send(m *SubscribeTransactionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RpcService_RpcGetSyncProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServiceServer).RpcGetSyncProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.RpcService/RpcGetSyncProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServiceServer).RpcGetSyncProgress(ctx, req.(*GetSyncProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RpcService_RpcGetGossipStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGossipStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServiceServer).RpcGetGossipStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.RpcService/RpcGetGossipStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServiceServer).RpcGetGossipStats(ctx, req.(*GetGossipStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RpcService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.RpcService",
 	HandlerType: (*RpcServiceServer)(nil),
@@ -1959,8 +2938,30 @@ var _RpcService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RpcSendTransaction",
 			Handler:    _RpcService_RpcSendTransaction_Handler,
 		},
+		{
+			MethodName: "RpcGetTransactionProof",
+			Handler:    _RpcService_RpcGetTransactionProof_Handler,
+		},
+		{
+			MethodName: "RpcGetTxPoolStats",
+			Handler:    _RpcService_RpcGetTxPoolStats_Handler,
+		},
+		{
+			MethodName: "RpcGetSyncProgress",
+			Handler:    _RpcService_RpcGetSyncProgress_Handler,
+		},
+		{
+			MethodName: "RpcGetGossipStats",
+			Handler:    _RpcService_RpcGetGossipStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RpcSubscribeTransactions",
+			Handler:       _RpcService_RpcSubscribeTransactions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/dappley/go-dappley/rpc/pb/rpc.proto",
 }
 
@@ -1969,6 +2970,9 @@ var _RpcService_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type AdminServiceClient interface {
 	RpcAddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error)
+	RpcBanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error)
+	RpcUnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerResponse, error)
+	RpcGetBannedPeers(ctx context.Context, in *GetBannedPeersRequest, opts ...grpc.CallOption) (*GetBannedPeersResponse, error)
 }
 
 type adminServiceClient struct {
@@ -1988,9 +2992,39 @@ func (c *adminServiceClient) RpcAddPeer(ctx context.Context, in *AddPeerRequest,
 	return out, nil
 }
 
+func (c *adminServiceClient) RpcBanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerResponse, error) {
+	out := new(BanPeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RpcBanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RpcUnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerResponse, error) {
+	out := new(UnbanPeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RpcUnbanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RpcGetBannedPeers(ctx context.Context, in *GetBannedPeersRequest, opts ...grpc.CallOption) (*GetBannedPeersResponse, error) {
+	out := new(GetBannedPeersResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/RpcGetBannedPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 type AdminServiceServer interface {
 	RpcAddPeer(context.Context, *AddPeerRequest) (*AddPeerResponse, error)
+	RpcBanPeer(context.Context, *BanPeerRequest) (*BanPeerResponse, error)
+	RpcUnbanPeer(context.Context, *UnbanPeerRequest) (*UnbanPeerResponse, error)
+	RpcGetBannedPeers(context.Context, *GetBannedPeersRequest) (*GetBannedPeersResponse, error)
 }
 
 func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
@@ -2015,6 +3049,60 @@ func _AdminService_RpcAddPeer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_RpcBanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RpcBanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RpcBanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RpcBanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RpcUnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RpcUnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RpcUnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RpcUnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RpcGetBannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannedPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RpcGetBannedPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RpcGetBannedPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RpcGetBannedPeers(ctx, req.(*GetBannedPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.AdminService",
 	HandlerType: (*AdminServiceServer)(nil),
@@ -2023,6 +3111,18 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RpcAddPeer",
 			Handler:    _AdminService_RpcAddPeer_Handler,
 		},
+		{
+			MethodName: "RpcBanPeer",
+			Handler:    _AdminService_RpcBanPeer_Handler,
+		},
+		{
+			MethodName: "RpcUnbanPeer",
+			Handler:    _AdminService_RpcUnbanPeer_Handler,
+		},
+		{
+			MethodName: "RpcGetBannedPeers",
+			Handler:    _AdminService_RpcGetBannedPeers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/dappley/go-dappley/rpc/pb/rpc.proto",
@@ -2033,78 +3133,112 @@ func init() {
 }
 
 var fileDescriptor_c6f7014334e4682f = []byte{
-	// 1168 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x4f, 0x1b, 0x47,
-	0x10, 0xc7, 0x18, 0x1c, 0x18, 0x1b, 0x28, 0x6b, 0x62, 0xcc, 0x95, 0x10, 0xba, 0x6d, 0x25, 0xda,
-	0xaa, 0x46, 0xa1, 0xad, 0x78, 0xea, 0x03, 0xa0, 0x06, 0x68, 0x88, 0x82, 0x2e, 0x49, 0x83, 0x14,
-	0xf5, 0x61, 0x7d, 0xb7, 0xe0, 0x53, 0xec, 0xbb, 0xeb, 0xde, 0x9a, 0x82, 0xd4, 0x97, 0x3e, 0xf7,
-	0x5b, 0xf4, 0x83, 0xf4, 0xb3, 0x55, 0xfb, 0xe7, 0xf6, 0x76, 0xcf, 0x07, 0xb8, 0x4a, 0x9f, 0xee,
-	0x76, 0x66, 0xe7, 0xb7, 0xb3, 0x33, 0xb3, 0xbf, 0x19, 0xe8, 0x5d, 0x45, 0x7c, 0x30, 0xee, 0xf7,
-	0x82, 0x64, 0xb4, 0x1b, 0x92, 0x34, 0x1d, 0xd2, 0xdb, 0xdd, 0xab, 0xe4, 0xdb, 0xfc, 0x97, 0xa5,
-	0xc1, 0x6e, 0xda, 0x17, 0x9f, 0x5e, 0xca, 0x12, 0x9e, 0xa0, 0x79, 0x96, 0x06, 0x69, 0xdf, 0xdb,
-	0xbf, 0xdf, 0x2c, 0xa6, 0xfc, 0xf7, 0x84, 0x7d, 0x10, 0xa6, 0x29, 0xa5, 0x6c, 0x18, 0x65, 0x5c,
-	0xd9, 0x7b, 0xcf, 0xee, 0x37, 0x0c, 0x12, 0x46, 0x85, 0x55, 0x7f, 0x98, 0x04, 0x1f, 0xb4, 0xc9,
-	0xfe, 0x74, 0x26, 0x9c, 0x91, 0x38, 0x23, 0x01, 0x8f, 0x92, 0x58, 0x19, 0xe2, 0x53, 0x68, 0x1f,
-	0x31, 0x4a, 0x38, 0x7d, 0x47, 0x86, 0x43, 0xca, 0x7d, 0xfa, 0xdb, 0x98, 0x66, 0x1c, 0x21, 0x98,
-	0x8b, 0xc9, 0x88, 0x76, 0x6b, 0xdb, 0xb5, 0x9d, 0x45, 0x5f, 0xfe, 0xa3, 0x2d, 0x80, 0x94, 0x64,
-	0x59, 0x3a, 0x60, 0x24, 0xa3, 0xdd, 0x59, 0xa9, 0xb1, 0x24, 0xf8, 0x10, 0xd0, 0x41, 0x18, 0x9e,
-	0xb3, 0x24, 0x1c, 0x07, 0x94, 0xdd, 0x87, 0xd4, 0x85, 0x47, 0x24, 0x0c, 0x19, 0xcd, 0x32, 0x0d,
-	0x93, 0x2f, 0x31, 0x81, 0xd5, 0x63, 0xca, 0x0f, 0xc9, 0x90, 0xc4, 0x01, 0xfd, 0x08, 0x67, 0xec,
-	0x23, 0xea, 0xee, 0x11, 0x3f, 0xc1, 0xea, 0x41, 0x18, 0x96, 0x8e, 0xb0, 0xb6, 0xd7, 0x9c, 0xed,
-	0xa8, 0x03, 0x0d, 0x32, 0x4a, 0xc6, 0x31, 0x97, 0x87, 0xb4, 0x7c, 0xbd, 0xc2, 0x11, 0x34, 0x5f,
-	0xd3, 0x38, 0xb4, 0x7c, 0xbc, 0x64, 0xc9, 0x28, 0xf7, 0x51, 0xfc, 0xa3, 0x65, 0x98, 0xe5, 0x89,
-	0xf6, 0x6d, 0x96, 0x27, 0x16, 0x54, 0xdd, 0x86, 0x12, 0x77, 0x51, 0xd1, 0x4f, 0x09, 0x1f, 0x74,
-	0xe7, 0xd4, 0x5d, 0x0a, 0x09, 0x7e, 0x09, 0xeb, 0xc7, 0x94, 0x2b, 0xc1, 0x81, 0x72, 0xeb, 0x63,
-	0xf2, 0xb4, 0x06, 0xe8, 0x98, 0xf2, 0x73, 0x4a, 0xd9, 0x69, 0x7c, 0x99, 0x68, 0x24, 0xec, 0x41,
-	0x57, 0x44, 0x5e, 0xd4, 0x54, 0x30, 0x20, 0x51, 0x6c, 0xeb, 0xf6, 0x60, 0x59, 0x64, 0x96, 0x16,
-	0x59, 0xdd, 0x86, 0xe6, 0xe5, 0x78, 0x38, 0x3c, 0x70, 0x62, 0x66, 0x8b, 0xf0, 0xcf, 0xb0, 0xe6,
-	0x16, 0x56, 0x96, 0x26, 0xb1, 0x4a, 0xcc, 0x88, 0x66, 0x19, 0xb9, 0xca, 0x9d, 0xce, 0x97, 0xf7,
-	0x54, 0xc5, 0x2e, 0xb4, 0x9d, 0xca, 0x7a, 0x08, 0x0a, 0x3f, 0x97, 0x57, 0x34, 0x39, 0x7e, 0xf0,
-	0x68, 0x37, 0xc9, 0x75, 0x93, 0xe4, 0x9e, 0x2c, 0xe9, 0xa9, 0x71, 0xf0, 0x0e, 0xb4, 0x54, 0x51,
-	0x4c, 0xe1, 0x61, 0xdb, 0x49, 0x82, 0x36, 0xd8, 0x85, 0x05, 0x41, 0x06, 0x67, 0x51, 0xc6, 0xa5,
-	0x45, 0x73, 0xaf, 0xdd, 0xd3, 0x44, 0x91, 0xf6, 0x7b, 0xe7, 0x9a, 0x27, 0x7c, 0xb3, 0x09, 0xff,
-	0x59, 0x83, 0x8d, 0x8a, 0xbc, 0x69, 0xb8, 0x2f, 0x60, 0x89, 0x93, 0x68, 0x28, 0xb5, 0x27, 0x24,
-	0x1b, 0x48, 0xcc, 0x96, 0xef, 0x0a, 0x45, 0x32, 0x25, 0x97, 0x9c, 0xd0, 0xe8, 0x6a, 0xa0, 0x42,
-	0x30, 0xe7, 0xdb, 0x22, 0xb4, 0x09, 0x8b, 0xa9, 0x8e, 0xbe, 0x78, 0x4f, 0xf5, 0x9d, 0x45, 0xbf,
-	0x10, 0xe0, 0xaf, 0x60, 0xc5, 0x94, 0x87, 0x3e, 0xb8, 0x03, 0x8d, 0x8c, 0x13, 0x3e, 0xce, 0x4b,
-	0x43, 0xaf, 0x70, 0x2c, 0xab, 0xac, 0x54, 0xca, 0xff, 0xad, 0x32, 0xea, 0xf6, 0xeb, 0x14, 0xb5,
-	0xce, 0xa2, 0x6b, 0xc2, 0xe9, 0x0b, 0x7a, 0xab, 0x3d, 0xb3, 0x24, 0x78, 0x5f, 0xf2, 0xc9, 0x2f,
-	0x94, 0x65, 0x51, 0x12, 0xe7, 0xc5, 0x8b, 0xa1, 0x25, 0xc9, 0x4f, 0x8b, 0xf5, 0x69, 0x8e, 0x0c,
-	0xff, 0x21, 0x2b, 0xc8, 0x18, 0x6a, 0x17, 0x37, 0x61, 0x91, 0x32, 0x96, 0xb0, 0xa3, 0x24, 0x54,
-	0x4e, 0x2e, 0xf9, 0x85, 0x60, 0x02, 0x77, 0x76, 0x12, 0x57, 0x64, 0x24, 0xa3, 0xec, 0x9a, 0xb2,
-	0x7c, 0x93, 0x62, 0x27, 0x57, 0x88, 0xbf, 0x86, 0xe5, 0x63, 0xca, 0xdf, 0xbe, 0xb9, 0x78, 0xf5,
-	0x20, 0x41, 0xe1, 0xbf, 0x6a, 0xb0, 0x62, 0x36, 0x4f, 0xe5, 0xe7, 0x67, 0x30, 0x3f, 0xe6, 0x37,
-	0x89, 0x0a, 0x66, 0x73, 0xaf, 0xd9, 0x93, 0xfd, 0xaa, 0x27, 0x11, 0x94, 0x06, 0xed, 0x43, 0x4b,
-	0xe7, 0x9f, 0x84, 0x79, 0xce, 0x45, 0x2d, 0x8a, 0x46, 0x92, 0xf6, 0x7b, 0x87, 0x85, 0xce, 0x77,
-	0x36, 0x62, 0x06, 0x73, 0x02, 0xc7, 0x7a, 0x51, 0x35, 0xfb, 0x45, 0x89, 0xfb, 0xa7, 0xe3, 0xfe,
-	0x30, 0x0a, 0x5e, 0xd0, 0x5b, 0x59, 0x91, 0x8a, 0x55, 0x5d, 0xa1, 0xa0, 0x35, 0x7e, 0x13, 0x85,
-	0x9a, 0x27, 0xe5, 0xbf, 0x88, 0x00, 0xbf, 0x39, 0x8d, 0x43, 0x7a, 0x23, 0x29, 0x72, 0xc9, 0xcf,
-	0x97, 0xf8, 0x02, 0x3e, 0xc9, 0x9f, 0x80, 0x21, 0xc6, 0x1d, 0x58, 0xc9, 0x38, 0x61, 0xdc, 0x54,
-	0xb9, 0x88, 0x5b, 0x7d, 0xa7, 0xe5, 0x97, 0xc5, 0xc8, 0x83, 0x85, 0x11, 0xb9, 0x39, 0x32, 0xaf,
-	0x7f, 0xde, 0x37, 0x6b, 0x7c, 0xa1, 0xda, 0x91, 0x46, 0x9e, 0x2a, 0xb8, 0x5f, 0x42, 0x43, 0x06,
-	0x24, 0x8f, 0xee, 0x92, 0x13, 0x33, 0x5f, 0x2b, 0xf1, 0x37, 0xf0, 0x38, 0x47, 0x3e, 0x94, 0x77,
-	0xb6, 0x18, 0x7d, 0x50, 0xbc, 0x54, 0xf9, 0x8f, 0xdf, 0x43, 0xa7, 0xbc, 0x79, 0x2a, 0x5f, 0x3e,
-	0x87, 0x79, 0x79, 0x9c, 0xbc, 0xd7, 0x84, 0x2b, 0x4a, 0x87, 0x9f, 0xc9, 0xee, 0x92, 0x83, 0xcb,
-	0xf7, 0x9e, 0xfb, 0xd2, 0x81, 0xc6, 0x40, 0x71, 0x42, 0x4d, 0x72, 0x82, 0x5e, 0xe1, 0x5f, 0x8b,
-	0x5e, 0x51, 0x98, 0xfc, 0x7f, 0x1e, 0xbd, 0x82, 0x8e, 0x60, 0xd1, 0x37, 0xc5, 0xb0, 0x92, 0x3b,
-	0xf4, 0x03, 0x34, 0xad, 0x11, 0xc6, 0x30, 0xa4, 0x06, 0xb1, 0x0d, 0xec, 0x7d, 0x78, 0x1f, 0xd6,
-	0x27, 0x00, 0xa7, 0x71, 0x77, 0xef, 0x9f, 0x05, 0x00, 0x3f, 0x0d, 0x5e, 0x53, 0x76, 0x1d, 0x05,
-	0x14, 0x3d, 0x87, 0x25, 0x3f, 0x0d, 0x0a, 0x5e, 0x40, 0x5d, 0xfd, 0x74, 0x26, 0x38, 0xc6, 0xdb,
-	0xa8, 0xd0, 0xa8, 0x23, 0xf1, 0x0c, 0x3a, 0x83, 0x15, 0x3f, 0x0d, 0xec, 0xf6, 0x88, 0x3c, 0xbd,
-	0xbf, 0x62, 0x18, 0xf3, 0x3e, 0xad, 0xd4, 0x19, 0xb4, 0x53, 0x58, 0xf6, 0xd3, 0xc0, 0x6a, 0x90,
-	0x28, 0x3f, 0x7c, 0x72, 0x1c, 0xf3, 0xbc, 0x2a, 0x95, 0x81, 0x32, 0x17, 0xd4, 0x2d, 0xcf, 0xbe,
-	0xa0, 0x3b, 0x31, 0xd9, 0x17, 0x2c, 0xf5, 0x47, 0x83, 0x53, 0xb4, 0x4e, 0x83, 0x33, 0x31, 0x79,
-	0x79, 0x1b, 0x15, 0x1a, 0x83, 0x73, 0x01, 0x6d, 0xe5, 0x8f, 0xd3, 0x31, 0xd0, 0x56, 0x71, 0x76,
-	0xd5, 0x54, 0xe4, 0x3d, 0xbd, 0x53, 0x6f, 0x90, 0xbf, 0x87, 0x47, 0x32, 0xb1, 0x71, 0x88, 0x90,
-	0xde, 0x6d, 0x8d, 0x73, 0x5e, 0xdb, 0x91, 0x95, 0x42, 0x6d, 0x35, 0x6e, 0x64, 0x85, 0xa1, 0x34,
-	0x51, 0x99, 0x50, 0x57, 0xf4, 0x79, 0x3c, 0x83, 0xde, 0xc3, 0x9a, 0x0e, 0xb5, 0xd3, 0xba, 0x91,
-	0xe5, 0x7b, 0xe5, 0x30, 0xe6, 0x6d, 0xdf, 0xbd, 0xc1, 0x80, 0xff, 0x28, 0xcb, 0x56, 0x77, 0x05,
-	0xf4, 0xb8, 0xb0, 0xb0, 0x5a, 0x8a, 0xd7, 0x29, 0x8b, 0x8d, 0xf9, 0x11, 0xb4, 0x2c, 0xdf, 0x32,
-	0xb4, 0x5e, 0x3a, 0xd2, 0x04, 0xba, 0x3b, 0xa9, 0x30, 0x20, 0x3e, 0xac, 0x5a, 0x20, 0x8a, 0xb7,
-	0xd0, 0x66, 0xc9, 0xc0, 0xe1, 0x3e, 0xef, 0xc9, 0x1d, 0xda, 0xc9, 0x7a, 0x70, 0xb8, 0xc7, 0xae,
-	0x87, 0x2a, 0x1e, 0xf3, 0x9e, 0xde, 0xa9, 0x37, 0xc8, 0x6f, 0x01, 0xe9, 0x7a, 0xb0, 0x58, 0x02,
-	0x3d, 0xb1, 0xca, 0x60, 0x92, 0x8e, 0xbc, 0xad, 0xbb, 0xd4, 0x39, 0xec, 0xde, 0x4b, 0x68, 0x1d,
-	0x84, 0xa3, 0x28, 0xce, 0x19, 0x44, 0x25, 0x46, 0x4f, 0x4b, 0x26, 0x31, 0xee, 0x70, 0x6d, 0x12,
-	0x53, 0x1a, 0xaa, 0xf0, 0xcc, 0x61, 0xe3, 0xef, 0xd9, 0xfa, 0xc9, 0xd9, 0xbb, 0x7e, 0x43, 0xce,
-	0x14, 0xdf, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x37, 0x9f, 0x3b, 0x44, 0x9a, 0x0e, 0x00, 0x00,
+	// 1698 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x4f, 0x1b, 0x47,
+	0x17, 0xff, 0x8c, 0x0d, 0x09, 0xc7, 0x06, 0xc2, 0x42, 0x8c, 0xd9, 0x00, 0x21, 0x93, 0x7c, 0x12,
+	0x5f, 0xa2, 0xcf, 0x24, 0xa4, 0x11, 0x52, 0xa5, 0x3e, 0x00, 0x6d, 0x08, 0x4d, 0xa2, 0xa0, 0x25,
+	0x17, 0xa4, 0xa8, 0x0f, 0xe3, 0xdd, 0xc1, 0xde, 0xc6, 0xde, 0xdd, 0xce, 0x8e, 0x53, 0xa8, 0xaa,
+	0x4a, 0x7d, 0xce, 0x7f, 0x51, 0xa9, 0xef, 0xfd, 0x77, 0xfa, 0xdf, 0x54, 0x73, 0xdd, 0xd9, 0x5d,
+	0x1b, 0xdc, 0xa6, 0x4f, 0xde, 0x39, 0xb7, 0x39, 0x73, 0xe6, 0x77, 0x2e, 0x63, 0x68, 0x77, 0x43,
+	0xd6, 0x1b, 0x76, 0xda, 0x7e, 0x3c, 0xd8, 0x0e, 0x70, 0x92, 0xf4, 0xc9, 0xc5, 0x76, 0x37, 0xfe,
+	0xbf, 0xfe, 0xa4, 0x89, 0xbf, 0x9d, 0x74, 0xf8, 0x4f, 0x3b, 0xa1, 0x31, 0x8b, 0x9d, 0x69, 0x9a,
+	0xf8, 0x49, 0xc7, 0xdd, 0xbd, 0x5c, 0x2d, 0x22, 0xec, 0xc7, 0x98, 0x7e, 0xe0, 0xaa, 0x09, 0x21,
+	0xb4, 0x1f, 0xa6, 0x4c, 0xea, 0xbb, 0x8f, 0x2e, 0x57, 0xf4, 0x63, 0x4a, 0xb8, 0x56, 0xa7, 0x1f,
+	0xfb, 0x1f, 0x94, 0xca, 0xee, 0x64, 0x2a, 0x8c, 0xe2, 0x28, 0xc5, 0x3e, 0x0b, 0xe3, 0x48, 0x2a,
+	0xa2, 0x23, 0x58, 0x3a, 0xa0, 0x04, 0x33, 0xf2, 0x0e, 0xf7, 0xfb, 0x84, 0x79, 0xe4, 0x87, 0x21,
+	0x49, 0x99, 0xe3, 0x40, 0x2d, 0xc2, 0x03, 0xd2, 0xaa, 0x6c, 0x56, 0xb6, 0x66, 0x3d, 0xf1, 0xed,
+	0x6c, 0x00, 0x24, 0x38, 0x4d, 0x93, 0x1e, 0xc5, 0x29, 0x69, 0x4d, 0x09, 0x8e, 0x45, 0x41, 0xfb,
+	0xe0, 0xec, 0x05, 0xc1, 0x31, 0x8d, 0x83, 0xa1, 0x4f, 0xe8, 0x65, 0x96, 0x5a, 0x70, 0x0d, 0x07,
+	0x01, 0x25, 0x69, 0xaa, 0xcc, 0xe8, 0x25, 0xc2, 0xb0, 0x78, 0x48, 0xd8, 0x3e, 0xee, 0xe3, 0xc8,
+	0x27, 0x9f, 0xe1, 0x8c, 0xbd, 0x45, 0x35, 0xbf, 0xc5, 0x37, 0xb0, 0xb8, 0x17, 0x04, 0x85, 0x2d,
+	0x2c, 0xf1, 0x4a, 0x4e, 0xdc, 0x69, 0xc2, 0x0c, 0x1e, 0xc4, 0xc3, 0x88, 0x89, 0x4d, 0x1a, 0x9e,
+	0x5a, 0xa1, 0x10, 0xea, 0x27, 0x24, 0x0a, 0x2c, 0x1f, 0xcf, 0x68, 0x3c, 0xd0, 0x3e, 0xf2, 0x6f,
+	0x67, 0x1e, 0xa6, 0x58, 0xac, 0x7c, 0x9b, 0x62, 0xb1, 0x65, 0xaa, 0x6a, 0x9b, 0xe2, 0x67, 0x91,
+	0xd1, 0x4f, 0x30, 0xeb, 0xb5, 0x6a, 0xf2, 0x2c, 0x19, 0x05, 0xbd, 0x84, 0x95, 0x43, 0xc2, 0x24,
+	0x61, 0x4f, 0xba, 0xf5, 0x39, 0xf7, 0xb4, 0x0c, 0xce, 0x21, 0x61, 0xc7, 0x84, 0xd0, 0xa3, 0xe8,
+	0x2c, 0x56, 0x96, 0x90, 0x0b, 0x2d, 0x1e, 0x79, 0x8e, 0x29, 0xbf, 0x87, 0xc3, 0xc8, 0xe6, 0xed,
+	0xc0, 0x3c, 0xbf, 0x59, 0x92, 0xdd, 0xea, 0x26, 0xd4, 0xcf, 0x86, 0xfd, 0xfe, 0x5e, 0x2e, 0x66,
+	0x36, 0x09, 0x7d, 0x0d, 0xf3, 0xfb, 0x38, 0xb2, 0x75, 0x9a, 0x30, 0x93, 0xf0, 0x4d, 0x03, 0x25,
+	0xae, 0x56, 0x8e, 0x0b, 0xd7, 0x83, 0x21, 0xc5, 0x1c, 0x94, 0xc2, 0xdb, 0xaa, 0x67, 0xd6, 0xe8,
+	0x3e, 0xdc, 0x78, 0x13, 0x75, 0x26, 0xb2, 0x83, 0x56, 0xe0, 0xa6, 0xc0, 0x4e, 0x14, 0x11, 0xe1,
+	0xab, 0x0e, 0x12, 0xfa, 0x16, 0x96, 0xf3, 0x18, 0x4f, 0x93, 0x38, 0x92, 0x18, 0x19, 0x90, 0x34,
+	0xc5, 0x5d, 0x1d, 0x3f, 0xbd, 0xbc, 0x04, 0xa0, 0xdb, 0xb0, 0x94, 0x03, 0xf9, 0x55, 0xa6, 0xd0,
+	0x53, 0x11, 0x6d, 0x03, 0xb7, 0x2b, 0xb7, 0xce, 0xe3, 0xad, 0x6a, 0xf0, 0xd6, 0x16, 0xd9, 0x35,
+	0xb1, 0x1d, 0xb4, 0x05, 0x0d, 0x89, 0xcf, 0x09, 0x3c, 0x5c, 0xca, 0xe1, 0x41, 0x29, 0x6c, 0xc3,
+	0x75, 0x1e, 0xd8, 0x17, 0x61, 0xca, 0x84, 0x46, 0x7d, 0x67, 0xa9, 0xad, 0x6a, 0x56, 0xd2, 0x69,
+	0x1f, 0xab, 0x92, 0xe5, 0x19, 0x21, 0xf4, 0x6b, 0x05, 0x56, 0x47, 0x40, 0x48, 0x99, 0xbb, 0x07,
+	0x73, 0x0c, 0x87, 0x7d, 0xc1, 0x7d, 0x86, 0xd3, 0x9e, 0xb0, 0xd9, 0xf0, 0xf2, 0x44, 0x8e, 0x2b,
+	0x51, 0xd6, 0x9e, 0x91, 0xb0, 0xdb, 0x93, 0x21, 0xa8, 0x79, 0x36, 0xc9, 0x59, 0x83, 0xd9, 0x44,
+	0x45, 0x9f, 0xa7, 0x76, 0x75, 0x6b, 0xd6, 0xcb, 0x08, 0xe8, 0x7f, 0xb0, 0x60, 0x90, 0xaa, 0x36,
+	0x6e, 0xc2, 0x4c, 0xca, 0x30, 0x1b, 0x6a, 0x94, 0xaa, 0x15, 0x17, 0x35, 0x00, 0xbd, 0x42, 0xf4,
+	0x01, 0x2c, 0x5a, 0x28, 0xbc, 0x42, 0xf8, 0x4b, 0x80, 0x0c, 0x83, 0x63, 0x41, 0xbf, 0x0c, 0xd3,
+	0xc3, 0x88, 0x85, 0x7d, 0x75, 0xcb, 0x72, 0x81, 0x5e, 0x42, 0xb3, 0x08, 0x61, 0xb5, 0xdb, 0x63,
+	0xa8, 0x77, 0x32, 0x72, 0xab, 0xb2, 0x59, 0xdd, 0xaa, 0xef, 0x2c, 0xb6, 0x45, 0xa7, 0x69, 0x67,
+	0x0a, 0x9e, 0x2d, 0x85, 0x22, 0x91, 0xd3, 0x85, 0xc2, 0xf1, 0xf7, 0xc0, 0x5f, 0xb5, 0x6b, 0x21,
+	0xaf, 0x2c, 0x34, 0xfc, 0x88, 0x19, 0x79, 0x4e, 0x2e, 0x54, 0xf0, 0x2d, 0x0a, 0xda, 0x15, 0xd5,
+	0xfb, 0x2d, 0xa1, 0x69, 0x18, 0x47, 0x3a, 0x5d, 0x11, 0x34, 0x44, 0xab, 0x51, 0x64, 0xb5, 0x5b,
+	0x8e, 0x86, 0x7e, 0x16, 0x49, 0x62, 0x14, 0x95, 0x8b, 0x6b, 0x30, 0x4b, 0x28, 0x8d, 0xe9, 0x41,
+	0x1c, 0x48, 0x27, 0xe7, 0xbc, 0x8c, 0x50, 0xb2, 0x3b, 0x55, 0xb6, 0xcb, 0x41, 0x97, 0x12, 0xfa,
+	0x91, 0x50, 0x2d, 0x24, 0x7b, 0x41, 0x9e, 0x88, 0xee, 0xc3, 0xfc, 0x21, 0x61, 0x6f, 0x5e, 0x9f,
+	0xbe, 0xba, 0xb2, 0x1d, 0xa0, 0x4f, 0x15, 0x58, 0x30, 0xc2, 0x13, 0xf9, 0x79, 0x07, 0xa6, 0x87,
+	0xec, 0x3c, 0x96, 0xc1, 0xac, 0xef, 0xd4, 0xd5, 0x9d, 0x09, 0x0b, 0x92, 0xe3, 0xec, 0x42, 0x43,
+	0x41, 0x1c, 0x07, 0x1a, 0xd6, 0x3c, 0xdd, 0x78, 0xdb, 0xe6, 0xd7, 0x9b, 0xf1, 0xbc, 0x9c, 0x20,
+	0xa2, 0x50, 0xe3, 0x76, 0xac, 0xa2, 0x51, 0xb1, 0x8b, 0x06, 0x3f, 0x7f, 0x32, 0xec, 0xf4, 0x43,
+	0xff, 0x39, 0xb9, 0x10, 0x49, 0x27, 0x7b, 0x58, 0x9e, 0xc8, 0x9b, 0x08, 0x3b, 0x0f, 0x03, 0xd5,
+	0x95, 0xc4, 0x37, 0x8f, 0x00, 0x3b, 0x3f, 0x8a, 0x02, 0x72, 0x2e, 0x1a, 0xd2, 0x9c, 0xa7, 0x97,
+	0xe8, 0x14, 0x6e, 0xe8, 0x2c, 0x37, 0x6d, 0x68, 0x0b, 0x16, 0x52, 0x86, 0x29, 0x33, 0x89, 0x2c,
+	0x11, 0xda, 0xf0, 0x8a, 0x64, 0x5e, 0xec, 0x07, 0xf8, 0xfc, 0xc0, 0x14, 0xb8, 0x69, 0xcf, 0xac,
+	0xd1, 0xa9, 0x6c, 0xfe, 0xca, 0xf2, 0x44, 0xc1, 0xfd, 0x2f, 0xcc, 0x88, 0x80, 0xe8, 0xe8, 0xce,
+	0xe5, 0x62, 0xe6, 0x29, 0x26, 0x7a, 0x20, 0x5b, 0x03, 0x5f, 0xec, 0x8b, 0x33, 0x5b, 0xfd, 0xb3,
+	0x97, 0x15, 0x23, 0xf1, 0x8d, 0xde, 0x43, 0xb3, 0x28, 0x3c, 0x91, 0x2f, 0x77, 0x61, 0x5a, 0x6c,
+	0x27, 0xce, 0x55, 0x72, 0x45, 0xf2, 0xd0, 0x23, 0xd1, 0xcb, 0xb5, 0x71, 0x51, 0xd2, 0xac, 0xbe,
+	0xd6, 0x13, 0x04, 0x61, 0xba, 0xe6, 0xa9, 0x15, 0xfa, 0x0e, 0x5a, 0x65, 0x95, 0x7f, 0xcf, 0xa3,
+	0x57, 0xd0, 0xe4, 0x8d, 0xe2, 0x75, 0x36, 0x1a, 0x6a, 0x87, 0x9e, 0x40, 0xdd, 0x1a, 0x18, 0x4d,
+	0x13, 0x50, 0x46, 0x6c, 0x05, 0x5b, 0x0e, 0xed, 0xc2, 0x4a, 0xc9, 0xe0, 0x24, 0xee, 0xa2, 0x87,
+	0xe0, 0x1e, 0x12, 0x66, 0xe9, 0x1d, 0xd3, 0x38, 0x3e, 0xb3, 0xae, 0x4a, 0xa0, 0xb4, 0x92, 0xa1,
+	0x14, 0xfd, 0x51, 0x81, 0x5b, 0x23, 0x55, 0x26, 0x0a, 0xcf, 0x13, 0xa8, 0x5b, 0xd9, 0xd4, 0x9a,
+	0xca, 0x9f, 0xcf, 0xce, 0x3a, 0x5b, 0xce, 0x4e, 0x8d, 0x6a, 0x2e, 0x35, 0x78, 0x49, 0x1a, 0x10,
+	0xfa, 0xa1, 0x4f, 0xf6, 0x29, 0x8e, 0x7c, 0x3e, 0xca, 0xf1, 0x1c, 0xc8, 0xd1, 0xd0, 0x06, 0xac,
+	0x9d, 0x0c, 0x3b, 0xa9, 0x4f, 0xc3, 0x0e, 0xb1, 0xfc, 0x36, 0xc3, 0xca, 0x5b, 0x58, 0x1f, 0xc3,
+	0x57, 0x67, 0xfa, 0x87, 0xb7, 0x22, 0xa7, 0xa3, 0xd7, 0xe7, 0xc7, 0x71, 0xdc, 0x3f, 0x61, 0x98,
+	0x99, 0x0d, 0x7f, 0x81, 0x66, 0x91, 0xa1, 0x76, 0x72, 0xa0, 0x96, 0x86, 0x3f, 0xe9, 0xc0, 0x89,
+	0x6f, 0x9e, 0xbf, 0x38, 0x18, 0x84, 0x8c, 0x91, 0x40, 0x75, 0x67, 0xb3, 0xe6, 0x3c, 0x4a, 0xbe,
+	0x27, 0x3e, 0xe7, 0x55, 0x25, 0x4f, 0xaf, 0x79, 0xd0, 0xc8, 0x79, 0x12, 0x52, 0x12, 0x88, 0x7a,
+	0x52, 0xf3, 0xf4, 0x52, 0x39, 0x76, 0x18, 0xa7, 0x69, 0x98, 0xe4, 0x1c, 0xdb, 0x87, 0x66, 0x91,
+	0xa1, 0x1c, 0xdb, 0x82, 0x69, 0xde, 0x6c, 0x75, 0x1b, 0x74, 0x54, 0x49, 0xb5, 0x45, 0xa5, 0x00,
+	0xea, 0x42, 0xdd, 0xa2, 0x72, 0x2f, 0xfc, 0x78, 0x30, 0xc0, 0x91, 0x6e, 0xc7, 0x7a, 0x29, 0x0a,
+	0x41, 0xc8, 0x52, 0x75, 0x26, 0xf1, 0xcd, 0x13, 0x72, 0x10, 0xa6, 0x29, 0x49, 0xd5, 0x69, 0xd4,
+	0xca, 0xc4, 0xa5, 0x96, 0xc5, 0x05, 0xb5, 0x84, 0xb3, 0x27, 0x17, 0x91, 0x7f, 0x4c, 0xe3, 0xae,
+	0x35, 0xa2, 0xa3, 0x3f, 0x2b, 0xb0, 0x52, 0x62, 0x65, 0x4d, 0x38, 0xbd, 0x88, 0xfc, 0x30, 0xea,
+	0x0a, 0x7f, 0xae, 0x7b, 0x7a, 0xc9, 0x07, 0x21, 0x51, 0x3a, 0xf3, 0x83, 0x90, 0x45, 0xe2, 0xb5,
+	0xdd, 0x1f, 0x52, 0x4a, 0x22, 0x2d, 0x23, 0x9d, 0xcc, 0x13, 0x39, 0x24, 0x7b, 0x02, 0xb6, 0x4a,
+	0x48, 0x06, 0x3f, 0x47, 0xe3, 0x32, 0x0c, 0xd3, 0x2e, 0xd1, 0x86, 0xa6, 0xa5, 0x8c, 0x4d, 0xe3,
+	0xf3, 0x4a, 0x22, 0x26, 0x8f, 0x19, 0x71, 0x68, 0xb9, 0xd8, 0xf9, 0xd4, 0x00, 0xf0, 0x12, 0xff,
+	0x84, 0xd0, 0x8f, 0xa1, 0x4f, 0x9c, 0xa7, 0x30, 0xe7, 0x25, 0x7e, 0xd6, 0xc9, 0x9d, 0x96, 0xbe,
+	0x99, 0xe2, 0x54, 0xe0, 0xae, 0x8e, 0xe0, 0xc8, 0xa0, 0xa0, 0xff, 0x38, 0x2f, 0x60, 0xc1, 0x4b,
+	0x7c, 0x7b, 0x66, 0x77, 0x5c, 0x25, 0x3f, 0xe2, 0xb1, 0xea, 0xde, 0x1a, 0xc9, 0x33, 0xd6, 0x8e,
+	0x60, 0xde, 0x4b, 0x7c, 0x6b, 0x6a, 0x77, 0xf4, 0xe6, 0xe5, 0xe7, 0xaa, 0xeb, 0x8e, 0x62, 0x19,
+	0x53, 0xe6, 0x80, 0x6a, 0x0e, 0xb7, 0x0f, 0x98, 0x7f, 0x51, 0xba, 0xab, 0x23, 0x38, 0x05, 0x3b,
+	0xd9, 0x3c, 0x6f, 0xec, 0x94, 0x5e, 0xa6, 0xee, 0xea, 0x08, 0x8e, 0xb1, 0x73, 0x0a, 0x4b, 0xd2,
+	0x9f, 0xdc, 0x8c, 0xe7, 0x6c, 0x64, 0x7b, 0x8f, 0x7a, 0x35, 0xba, 0xb7, 0xc7, 0xf2, 0x8d, 0xe5,
+	0x2f, 0xe0, 0x9a, 0xb8, 0x58, 0x9e, 0x1a, 0x4a, 0xda, 0x7a, 0xee, 0xba, 0x4b, 0x39, 0x5a, 0x21,
+	0xd4, 0xd6, 0x6b, 0xc2, 0xb1, 0xc2, 0x50, 0x78, 0x71, 0xba, 0xee, 0x28, 0x96, 0x31, 0xf5, 0x1e,
+	0x96, 0x55, 0xa8, 0x73, 0xef, 0x09, 0xc7, 0xf2, 0x7d, 0xe4, 0x63, 0xd5, 0xdd, 0x1c, 0x2f, 0x60,
+	0x8c, 0x7f, 0x25, 0x60, 0xab, 0xe6, 0x38, 0xe7, 0x66, 0xa6, 0x61, 0x0d, 0x81, 0x6e, 0xb3, 0x48,
+	0x36, 0xea, 0x07, 0xd0, 0xb0, 0x7c, 0x4b, 0x9d, 0x95, 0xc2, 0x96, 0x26, 0xd0, 0xad, 0x32, 0xc3,
+	0x18, 0xf1, 0x60, 0xd1, 0x32, 0x22, 0x27, 0x0d, 0x67, 0xad, 0xa0, 0x90, 0x9b, 0x56, 0xdc, 0xf5,
+	0x31, 0xdc, 0x32, 0x1e, 0x72, 0xd3, 0x82, 0x8d, 0x87, 0x51, 0x93, 0x87, 0x7b, 0x7b, 0x2c, 0xdf,
+	0x58, 0x7e, 0x03, 0x8e, 0xc2, 0x83, 0xd5, 0x61, 0x9c, 0x75, 0x0b, 0x06, 0xe5, 0x01, 0xc2, 0xdd,
+	0x18, 0xc7, 0x36, 0x66, 0x31, 0x34, 0xa5, 0xc3, 0xc5, 0x16, 0xee, 0xdc, 0xc9, 0x7c, 0x1a, 0x33,
+	0x11, 0xb8, 0xe8, 0x32, 0x91, 0x72, 0x9c, 0xad, 0x16, 0x67, 0xc7, 0xb9, 0xdc, 0x12, 0xdd, 0xf5,
+	0x31, 0x5c, 0x63, 0x33, 0x84, 0x16, 0x8f, 0xc6, 0xa8, 0x3e, 0xed, 0xdc, 0xd5, 0x87, 0xbe, 0xa4,
+	0xcb, 0xbb, 0xf7, 0x2e, 0x17, 0xd2, 0x1b, 0x3d, 0xac, 0xa8, 0xc0, 0x17, 0x1a, 0x88, 0x63, 0x79,
+	0x38, 0xa2, 0xe7, 0xb8, 0x1b, 0xe3, 0xd8, 0xe5, 0xa8, 0xd8, 0xed, 0xd1, 0x8a, 0x4a, 0xb9, 0x1f,
+	0xbb, 0xeb, 0x63, 0xb8, 0xda, 0xe6, 0xce, 0xef, 0x53, 0xd0, 0xd8, 0x0b, 0x06, 0x61, 0xa4, 0xfb,
+	0x81, 0x4c, 0x33, 0xf5, 0x20, 0x37, 0x69, 0x96, 0xff, 0x2b, 0xc9, 0x6d, 0x16, 0xc9, 0x85, 0x2c,
+	0x55, 0x8f, 0x74, 0xa3, 0x9e, 0xff, 0x57, 0xc9, 0x6d, 0x16, 0xc9, 0x85, 0x2c, 0x35, 0x0f, 0x77,
+	0x93, 0xa5, 0xc5, 0x3f, 0x94, 0xdc, 0x56, 0x99, 0x31, 0x22, 0x4b, 0xb3, 0x77, 0x75, 0x2e, 0x4b,
+	0x4b, 0x7f, 0x37, 0xb9, 0xeb, 0x63, 0xb8, 0xda, 0xe6, 0xfe, 0xcc, 0x6f, 0x53, 0xd5, 0x67, 0x2f,
+	0xde, 0x75, 0x66, 0xc4, 0x5b, 0xf5, 0xf1, 0x5f, 0x03, 0x00, 0x38, 0x69, 0x80, 0xa2, 0x60, 0x16,
+	0x00, 0x00,
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"sort"
 
 	"github.com/dappley/go-dappley/client"
 	"github.com/dappley/go-dappley/common"
@@ -45,7 +46,8 @@ const (
 )
 
 type RpcService struct {
-	node *network.Node
+	node   *network.Node
+	faucet *logic.Faucet
 }
 
 func (rpcService *RpcService) RpcGetVersion(ctx context.Context, in *rpcpb.GetVersionRequest) (*rpcpb.GetVersionResponse, error) {
@@ -399,33 +401,23 @@ func (rpcSerivce *RpcService) RpcGetWalletAddress(ctx context.Context, in *rpcpb
 	}
 }
 
+// RpcAddBalance pays the requested amount from the faucet of the node. The faucet is only enabled on development nodes.
 func (rpcSerivce *RpcService) RpcAddBalance(ctx context.Context, in *rpcpb.AddBalanceRequest) (*rpcpb.AddBalanceResponse, error) {
+	if rpcSerivce.faucet == nil {
+		return &rpcpb.AddBalanceResponse{Message: "Add balance failed, the faucet is not enabled on this node"}, nil
+	}
+
 	sendToAddress := core.NewAddress(in.Address)
 	sendAmount := common.NewAmountFromBytes(in.Amount)
 	if sendAmount.Validate() != nil || sendAmount.IsZero() {
 		return &rpcpb.AddBalanceResponse{Message: "Invalid send amount (must be >0)"}, nil
 	}
 
-	fl := storage.NewFileLoader(client.GetWalletFilePath())
-	wm := client.NewWalletManager(fl)
-	err := wm.LoadFromFile()
+	err := rpcSerivce.faucet.Send(sendToAddress, sendAmount, rpcSerivce.node)
 	if err != nil {
-		return &rpcpb.AddBalanceResponse{Message: "Error loading local wallets"}, err
-	}
-
-	receiverWallet := wm.GetWalletByAddress(sendToAddress)
-	if receiverWallet == nil {
-		return &rpcpb.AddBalanceResponse{Message: "Address not found in the wallet!"}, nil
-	} else {
-		err = logic.AddBalance(sendToAddress, sendAmount, rpcSerivce.node.GetBlockchain())
-		if err != nil {
-			return &rpcpb.AddBalanceResponse{Message: "Add balance failed, " + err.Error()}, nil
-		} else {
-			addBalanceResponse := rpcpb.AddBalanceResponse{}
-			addBalanceResponse.Message = "Add balance succeed!"
-			return &addBalanceResponse, nil
-		}
+		return &rpcpb.AddBalanceResponse{Message: "Add balance failed, " + err.Error()}, nil
 	}
+	return &rpcpb.AddBalanceResponse{Message: "Add balance succeed!"}, nil
 }
 
 func (rpcService *RpcService) RpcGetUTXO(ctx context.Context, in *rpcpb.GetUTXORequest) (*rpcpb.GetUTXOResponse, error) {
@@ -470,47 +462,25 @@ func (rpcService *RpcService) RpcGetUTXO(ctx context.Context, in *rpcpb.GetUTXOR
 
 // RpcGetBlocks Get blocks in blockchain from head to tail
 func (rpcService *RpcService) RpcGetBlocks(ctx context.Context, in *rpcpb.GetBlocksRequest) (*rpcpb.GetBlocksResponse, error) {
-	block := rpcService.findBlockInRequestHash(in.StartBlockHashs)
-
-	// Reach the blockchain's tail
-	if block.GetHeight() >= rpcService.node.GetBlockchain().GetMaxHeight() {
-		return &rpcpb.GetBlocksResponse{ErrorCode: OK}, nil
+	var locator []core.Hash
+	for _, hash := range in.StartBlockHashs {
+		locator = append(locator, hash)
 	}
+	block := rpcService.node.GetBlockchain().FindCommonBlock(locator)
 
-	var blocks []*core.Block
 	maxBlockCount := in.MaxCount
 	if maxBlockCount > MaxGetBlocksCount {
 		maxBlockCount = MaxGetBlocksCount
 	}
 
-	block, err := rpcService.node.GetBlockchain().GetBlockByHeight(block.GetHeight() + 1)
-	for i := int32(0); i < maxBlockCount && err == nil; i++ {
-		blocks = append(blocks, block)
-		block, err = rpcService.node.GetBlockchain().GetBlockByHeight(block.GetHeight() + 1)
-	}
-
 	result := &rpcpb.GetBlocksResponse{ErrorCode: OK}
-
-	for _, block = range blocks {
+	for _, block = range rpcService.node.GetBlockchain().GetBlocksAfter(block, int(maxBlockCount)) {
 		result.Blocks = append(result.Blocks, block.ToProto().(*corepb.Block))
 	}
 
 	return result, nil
 }
 
-func (rpcService *RpcService) findBlockInRequestHash(startBlockHashs [][]byte) *core.Block {
-	for _, hash := range startBlockHashs {
-		// hash in blockchain, return
-		if block, err := rpcService.node.GetBlockchain().GetBlockByHash(hash); err == nil {
-			return block
-		}
-	}
-
-	// Return Genesis Block
-	block, _ := rpcService.node.GetBlockchain().GetBlockByHeight(0)
-	return block
-}
-
 // RpcGetBlockByHash Get single block in blockchain by hash
 func (rpcService *RpcService) RpcGetBlockByHash(ctx context.Context, in *rpcpb.GetBlockByHashRequest) (*rpcpb.GetBlockByHashResponse, error) {
 	block, err := rpcService.node.GetBlockchain().GetBlockByHash(in.Hash)
@@ -533,6 +503,96 @@ func (rpcService *RpcService) RpcGetBlockByHeight(ctx context.Context, in *rpcpb
 	return &rpcpb.GetBlockByHeightResponse{ErrorCode: OK, Block: block.ToProto().(*corepb.Block)}, nil
 }
 
+// RpcGetTransactionProof Get the header of the block containing a transaction and the Merkle branch proving its inclusion
+func (rpcService *RpcService) RpcGetTransactionProof(ctx context.Context, in *rpcpb.GetTransactionProofRequest) (*rpcpb.GetTransactionProofResponse, error) {
+	txIndex, err := core.GetTxIndex(rpcService.node.GetBlockchain().GetDb(), in.Txid)
+	if err != nil {
+		return &rpcpb.GetTransactionProofResponse{ErrorCode: TransactionNotFound}, nil
+	}
+
+	block, err := rpcService.node.GetBlockchain().GetBlockByHash(txIndex.BlockId)
+	if err != nil {
+		return &rpcpb.GetTransactionProofResponse{ErrorCode: BlockNotFound}, nil
+	}
+
+	index, branch, err := block.GetTransactionProof(in.Txid)
+	if err != nil {
+		return &rpcpb.GetTransactionProofResponse{ErrorCode: TransactionNotFound}, nil
+	}
+
+	return &rpcpb.GetTransactionProofResponse{
+		ErrorCode:    OK,
+		BlockHeader:  block.GetHeader().ToProto().(*corepb.BlockHeader),
+		TxIndex:      uint32(index),
+		MerkleBranch: branch,
+	}, nil
+}
+
+// RpcGetTxPoolStats reports the size of the transaction pool and how many transactions it admitted, rejected and expired
+func (rpcService *RpcService) RpcGetTxPoolStats(ctx context.Context, in *rpcpb.GetTxPoolStatsRequest) (*rpcpb.GetTxPoolStatsResponse, error) {
+	txPool := rpcService.node.GetBlockchain().GetTxPool()
+	stats := txPool.GetStats()
+	return &rpcpb.GetTxPoolStatsResponse{
+		Size:     uint32(txPool.Len()),
+		Admitted: stats.Admitted,
+		Rejected: stats.Rejected,
+		Expired:  stats.Expired,
+	}, nil
+}
+
+// RpcGetGossipStats reports how many gossiped messages were ignored because the node received them recently
+func (rpcService *RpcService) RpcGetGossipStats(ctx context.Context, in *rpcpb.GetGossipStatsRequest) (*rpcpb.GetGossipStatsResponse, error) {
+	cacheStats := rpcService.node.GetDapMsgCacheStats()
+	commands := make([]string, 0, len(cacheStats))
+	for cmd := range cacheStats {
+		commands = append(commands, cmd)
+	}
+	sort.Strings(commands)
+
+	response := &rpcpb.GetGossipStatsResponse{}
+	for _, cmd := range commands {
+		response.Stats = append(response.Stats, &rpcpb.GossipStats{
+			Command: cmd,
+			Hits:    cacheStats[cmd].Hits,
+			Misses:  cacheStats[cmd].Misses,
+			Size:    uint32(cacheStats[cmd].Size),
+		})
+	}
+	return response, nil
+}
+
+// RpcGetSyncProgress reports how far the node is in synchronizing its blockchain with its peers
+func (rpcService *RpcService) RpcGetSyncProgress(ctx context.Context, in *rpcpb.GetSyncProgressRequest) (*rpcpb.GetSyncProgressResponse, error) {
+	progress := rpcService.node.GetSyncManager().GetProgress()
+	return &rpcpb.GetSyncProgressResponse{
+		Syncing:       progress.Syncing,
+		StartHeight:   progress.StartHeight,
+		CurrentHeight: progress.CurrentHeight,
+		HeaderHeight:  progress.HeaderHeight,
+		TargetHeight:  progress.TargetHeight,
+		Peers:         uint32(progress.PeerCount),
+	}, nil
+}
+
+// RpcSubscribeTransactions streams the transactions admitted to the pool until the client cancels the call
+func (rpcService *RpcService) RpcSubscribeTransactions(in *rpcpb.SubscribeTransactionsRequest, stream rpcpb.RpcService_RpcSubscribeTransactionsServer) error {
+	txPool := rpcService.node.GetBlockchain().GetTxPool()
+	txCh := txPool.Subscribe()
+	defer txPool.Unsubscribe(txCh)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case tx := <-txCh:
+			err := stream.Send(&rpcpb.SubscribeTransactionsResponse{Transaction: tx.ToProto().(*corepb.Transaction)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (rpcService *RpcService) RpcSendTransaction(ctx context.Context, in *rpcpb.SendTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
 	tx := core.Transaction{nil, nil, nil, 0}
 	tx.FromProto(in.Transaction)
@@ -541,14 +601,15 @@ func (rpcService *RpcService) RpcSendTransaction(ctx context.Context, in *rpcpb.
 		return &rpcpb.SendTransactionResponse{ErrorCode: InvalidTransaction}, nil
 	}
 
-	//TODO Check double spend in transaction pool
-	utxoIndex := core.LoadUTXOIndex(rpcService.node.GetBlockchain().GetDb())
-	if tx.Verify(utxoIndex, 0) == false {
+	// The transaction may spend the outputs of transactions in the pool. The node relays it once it is admitted.
+	err := rpcService.node.GetBlockchain().GetTxPool().VerifyAndPush(tx)
+	if err == core.ErrInvalidTransaction {
 		return &rpcpb.SendTransactionResponse{ErrorCode: InvalidTransaction}, nil
 	}
-
-	rpcService.node.GetBlockchain().GetTxPool().Push(tx)
-	rpcService.node.TxBroadcast(&tx)
+	if err != nil {
+		logger.Warn(err)
+		return &rpcpb.SendTransactionResponse{ErrorCode: TransactionRejected}, nil
+	}
 
 	return &rpcpb.SendTransactionResponse{ErrorCode: OK}, nil
 }
@@ -35,6 +35,9 @@ type Storage interface {
 
 	Del(key []byte) error
 
+	// GetByPrefix returns the values of all keys starting with prefix, ordered by key.
+	GetByPrefix(prefix []byte) ([][]byte, error)
+
 	// EnableBatch enable batch write.
 	EnableBatch()
 
@@ -24,6 +24,7 @@ import (
 
 	logger "github.com/sirupsen/logrus"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var (
@@ -77,9 +78,26 @@ func (ldb *LevelDB) Put(key []byte, val []byte) error {
 }
 
 func (ldb *LevelDB) Del(key []byte) error {
+	if ldb.batch != nil {
+		ldb.batch.Delete(key)
+		return nil
+	}
 	return ldb.db.Delete(key, nil)
 }
 
+func (ldb *LevelDB) GetByPrefix(prefix []byte) ([][]byte, error) {
+	iter := ldb.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	var values [][]byte
+	for iter.Next() {
+		val := make([]byte, len(iter.Value()))
+		copy(val, iter.Value())
+		values = append(values, val)
+	}
+	return values, iter.Error()
+}
+
 func (ldb *LevelDB) EnableBatch() {
 	ldb.batch = new(leveldb.Batch)
 }
@@ -115,6 +115,46 @@ func TestLevelDB_BatchWrite(t *testing.T) {
 	ldb.DisableBatch()
 }
 
+func TestLevelDB_BatchDel(t *testing.T) {
+	ldb := OpenDatabase(testDbFile)
+	defer ldb.Close()
+
+	ldb.Put([]byte("1"), []byte("a"))
+
+	ldb.EnableBatch()
+	ldb.Del([]byte("1"))
+
+	// Not deleted from storage before flushing
+	v1, err := ldb.Get([]byte("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a"), v1)
+
+	err = ldb.Flush()
+	assert.Nil(t, err)
+
+	_, err = ldb.Get([]byte("1"))
+	assert.Equal(t, ErrKeyInvalid, err)
+
+	ldb.DisableBatch()
+}
+
+func TestLevelDB_GetByPrefix(t *testing.T) {
+	ldb := OpenDatabase(testDbFile)
+	defer ldb.Close()
+
+	ldb.Put([]byte("ab2"), []byte("2"))
+	ldb.Put([]byte("ab1"), []byte("1"))
+	ldb.Put([]byte("b"), []byte("3"))
+
+	values, err := ldb.GetByPrefix([]byte("ab"))
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, values)
+
+	values, err = ldb.GetByPrefix([]byte("x"))
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+}
+
 func setup() {
 	cleanUpDatabase()
 }
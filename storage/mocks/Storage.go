@@ -84,6 +84,29 @@ func (_m *Storage) Get(key []byte) ([]byte, error) {
 	return r0, r1
 }
 
+// GetByPrefix provides a mock function with given fields: prefix
+func (_m *Storage) GetByPrefix(prefix []byte) ([][]byte, error) {
+	ret := _m.Called(prefix)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func([]byte) [][]byte); ok {
+		r0 = rf(prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Put provides a mock function with given fields: key, val
 func (_m *Storage) Put(key []byte, val []byte) error {
 	ret := _m.Called(key, val)
@@ -19,6 +19,8 @@
 package storage
 
 import (
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -27,6 +29,7 @@ type RamStorage struct {
 	isBatchEnabled bool
 	batchLock      sync.Mutex
 	batchData      map[string][]byte
+	batchDeletes   map[string]bool
 }
 
 func NewRamStorage() *RamStorage {
@@ -34,6 +37,7 @@ func NewRamStorage() *RamStorage {
 		data:           new(sync.Map),
 		isBatchEnabled: false,
 		batchData:      make(map[string][]byte),
+		batchDeletes:   make(map[string]bool),
 	}
 }
 
@@ -50,6 +54,7 @@ func (rs *RamStorage) Put(key []byte, val []byte) error {
 		rs.batchLock.Lock()
 		defer rs.batchLock.Unlock()
 		rs.batchData[string(key)] = val
+		delete(rs.batchDeletes, string(key))
 		return nil
 	}
 	rs.data.Store(string(key), val)
@@ -57,10 +62,36 @@ func (rs *RamStorage) Put(key []byte, val []byte) error {
 }
 
 func (rs *RamStorage) Del(key []byte) error {
-	rs.data.Delete(key)
+	if rs.isBatchEnabled {
+		rs.batchLock.Lock()
+		defer rs.batchLock.Unlock()
+		rs.batchDeletes[string(key)] = true
+		delete(rs.batchData, string(key))
+		return nil
+	}
+	rs.data.Delete(string(key))
 	return nil
 }
 
+func (rs *RamStorage) GetByPrefix(prefix []byte) ([][]byte, error) {
+	var keys []string
+	rs.data.Range(func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), string(prefix)) {
+			keys = append(keys, key.(string))
+		}
+		return true
+	})
+	sort.Strings(keys)
+
+	var values [][]byte
+	for _, key := range keys {
+		if value, ok := rs.data.Load(key); ok {
+			values = append(values, value.([]byte))
+		}
+	}
+	return values, nil
+}
+
 func (rs *RamStorage) Close() error {
 	rs.data.Range(func(key, value interface{}) bool {
 		rs.data.Delete(key)
@@ -83,11 +114,16 @@ func (rs *RamStorage) Flush() error {
 		return nil
 	}
 
+	for k := range rs.batchDeletes {
+		rs.data.Delete(k)
+	}
+
 	for k, v := range rs.batchData {
 		rs.data.Store(string(k), v)
 	}
 
 	rs.batchData = make(map[string][]byte)
+	rs.batchDeletes = make(map[string]bool)
 
 	return nil
 }
@@ -97,5 +133,6 @@ func (rs *RamStorage) DisableBatch() {
 	rs.batchLock.Lock()
 	defer rs.batchLock.Unlock()
 	rs.batchData = make(map[string][]byte)
+	rs.batchDeletes = make(map[string]bool)
 	rs.isBatchEnabled = false
 }
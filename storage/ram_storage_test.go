@@ -82,6 +82,18 @@ func TestRamStorage_Close(t *testing.T) {
 	assert.Nil(t, v)
 }
 
+//test del method
+func TestRamStorage_Del(t *testing.T) {
+	r := NewRamStorage()
+
+	r.Put([]byte("1"), []byte("2"))
+	r.Del([]byte("1"))
+
+	//the deleted key should not be accessible
+	_, err := r.Get([]byte("1"))
+	assert.Equal(t, ErrKeyInvalid, err)
+}
+
 //check if two storage instances affect each other
 func TestRamStorage_IndependantStorage(t *testing.T) {
 	r1 := NewRamStorage()
@@ -129,3 +141,39 @@ func TestRamStorage_BatchWrite(t *testing.T) {
 
 	rs.DisableBatch()
 }
+
+func TestRamStorage_BatchDel(t *testing.T) {
+	rs := NewRamStorage()
+	rs.Put([]byte("1"), []byte("a"))
+
+	rs.EnableBatch()
+	rs.Del([]byte("1"))
+
+	// Not deleted from storage before flushing
+	v1, err := rs.Get([]byte("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a"), v1)
+
+	err = rs.Flush()
+	assert.Nil(t, err)
+
+	_, err = rs.Get([]byte("1"))
+	assert.Equal(t, ErrKeyInvalid, err)
+
+	rs.DisableBatch()
+}
+
+func TestRamStorage_GetByPrefix(t *testing.T) {
+	rs := NewRamStorage()
+	rs.Put([]byte("ab2"), []byte("2"))
+	rs.Put([]byte("ab1"), []byte("1"))
+	rs.Put([]byte("b"), []byte("3"))
+
+	values, err := rs.GetByPrefix([]byte("ab"))
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, values)
+
+	values, err = rs.GetByPrefix([]byte("x"))
+	assert.Nil(t, err)
+	assert.Empty(t, values)
+}